@@ -2,11 +2,15 @@ package cleanup
 
 import (
 	"context"
+	"time"
 
 	"github.com/loft-sh/devspace/cmd/flags"
 	"github.com/loft-sh/devspace/pkg/devspace/build/localregistry"
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
 	"github.com/loft-sh/devspace/pkg/util/survey"
 	"github.com/sirupsen/logrus"
@@ -19,6 +23,10 @@ import (
 
 type localRegistryCmd struct {
 	*flags.GlobalFlags
+
+	GC       bool
+	KeepTags int
+	MaxAge   string
 }
 
 func newLocalRegistryCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
@@ -31,7 +39,12 @@ func newLocalRegistryCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cob
 #######################################################
 ######### devspace cleanup local-registry #############
 #######################################################
-Deletes the local image registry
+Deletes the local image registry or garbage collects
+it with --gc:
+
+devspace cleanup local-registry --gc
+devspace cleanup local-registry --gc --keep-tags 3
+devspace cleanup local-registry --gc --max-age 72h
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -39,6 +52,9 @@ Deletes the local image registry
 			return cmd.RunCleanupLocalRegistry(f, cobraCmd, args)
 		}}
 
+	localRegistryCmd.Flags().BoolVar(&cmd.GC, "gc", false, "Garbage collects the local registry according to the retention settings instead of deleting it")
+	localRegistryCmd.Flags().IntVar(&cmd.KeepTags, "keep-tags", -1, "Overrides the number of most recent tags kept per repository during garbage collection")
+	localRegistryCmd.Flags().StringVar(&cmd.MaxAge, "max-age", "", "Overrides the age after which tags are removed during garbage collection, e.g. 72h")
 	return localRegistryCmd
 }
 
@@ -88,11 +104,18 @@ func (cmd *localRegistryCmd) RunCleanupLocalRegistry(f factory.Factory, cobraCmd
 		return err
 	}
 
+	if client == nil {
+		return errors.New("cleaning up the local registry requires a valid kube context")
+	}
+
 	// clean up registry according to options
 	config := configInterface.Config()
 	options := localregistry.NewDefaultOptions().
 		WithNamespace(client.Namespace()).
 		WithLocalRegistryConfig(config.LocalRegistry)
+	if cmd.GC {
+		return cmd.garbageCollect(client, options, configInterface, log)
+	}
 
 	hasStatefulSet := true
 	_, err = client.KubeClient().AppsV1().StatefulSets(options.Namespace).Get(ctx, options.Name, v1.GetOptions{})
@@ -169,3 +192,31 @@ func (cmd *localRegistryCmd) RunCleanupLocalRegistry(f factory.Factory, cobraCmd
 	log.Donef("Successfully cleaned up local registry")
 	return nil
 }
+
+func (cmd *localRegistryCmd) garbageCollect(client kubectl.Client, options localregistry.Options, configInterface config.Config, logger log.Logger) error {
+	if cmd.KeepTags >= 0 {
+		options = options.WithKeepTags(&cmd.KeepTags)
+	}
+	if cmd.MaxAge != "" {
+		maxAge, err := time.ParseDuration(cmd.MaxAge)
+		if err != nil {
+			return errors.Errorf("error parsing --max-age %s: %v", cmd.MaxAge, err)
+		}
+
+		options = options.WithMaxAge(maxAge)
+	}
+
+	// the port forwarding to the registry is stopped as soon as the context is cancelled
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ctx := devspacecontext.NewContext(cancelCtx, configInterface.Variables(), logger).
+		WithConfig(configInterface).
+		WithKubeClient(client)
+	localRegistry, err := localregistry.GetLocalRegistry(ctx, options)
+	if err != nil {
+		return errors.Wrap(err, "find local registry")
+	}
+
+	return localRegistry.GarbageCollect(ctx)
+}
@@ -31,6 +31,8 @@ Deploys the current project to a Space or namespace:
 devspace deploy
 devspace deploy -n some-namespace
 devspace deploy --kube-context=deploy-context
+devspace deploy --diff
+devspace deploy --dry-run
 #######################################################`,
 		RunE: func(cobraCmd *cobra.Command, args []string) error {
 			return cmd.Run(cobraCmd, args, f, "deployCommand")
@@ -0,0 +1,206 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/hook"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/devspace/services/servicehosts"
+	"github.com/loft-sh/devspace/pkg/devspace/upgrade"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/hostsfile"
+	"github.com/loft-sh/devspace/pkg/util/interrupt"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// ForwardCmd holds the forward cmd flags
+type ForwardCmd struct {
+	*flags.GlobalFlags
+
+	HostsFile    string
+	NoHostsFile  bool
+	IPRangeStart string
+
+	// used for testing to allow interruption
+	Ctx context.Context
+}
+
+// NewForwardCmd creates a new forward command
+func NewForwardCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &ForwardCmd{GlobalFlags: globalFlags}
+
+	forwardCmd := &cobra.Command{
+		Use:   "forward [service...]",
+		Short: "Forwards all services of a namespace to local addresses",
+		Long: `
+#######################################################
+################## devspace forward ###################
+#######################################################
+Forwards every service of the namespace (or only the
+given services) to its own local loopback address and
+makes it reachable via SERVICE.NAMESPACE by writing
+a managed section into the hosts file. The section is
+removed again when the command exits.
+
+Writing to the system hosts file usually requires
+elevated privileges.
+
+Example:
+devspace forward
+devspace forward orders payments -n my-namespace
+devspace forward --hosts-file ./hosts
+#######################################################
+	`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			// Print upgrade message if new version available
+			upgrade.PrintUpgradeMessage(f.GetLog())
+			plugin.SetPluginCommand(cobraCmd, args)
+			return cmd.Run(f, args)
+		},
+	}
+
+	forwardCmd.Flags().StringVar(&cmd.HostsFile, "hosts-file", hostsfile.DefaultPath(), "The hosts file to write the service hostnames to")
+	forwardCmd.Flags().BoolVar(&cmd.NoHostsFile, "no-hosts-file", false, "If enabled, DevSpace will not write the service hostnames to the hosts file")
+	forwardCmd.Flags().StringVar(&cmd.IPRangeStart, "ip-range-start", servicehosts.DefaultIPRangeStart.String(), "The first loopback address to forward a service to")
+	return forwardCmd
+}
+
+// Run executes the command logic
+func (cmd *ForwardCmd) Run(f factory.Factory, args []string) error {
+	if cmd.Ctx == nil {
+		var cancelFn context.CancelFunc
+		cmd.Ctx, cancelFn = context.WithCancel(context.Background())
+		defer cancelFn()
+	}
+
+	ipRangeStart := net.ParseIP(cmd.IPRangeStart)
+	if ipRangeStart == nil {
+		return fmt.Errorf("--ip-range-start %s is not a valid ip address", cmd.IPRangeStart)
+	}
+
+	// Load generated config if possible
+	var localCache localcache.Cache
+	logger := f.GetLog()
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return err
+	}
+	if configLoader.Exists() {
+		configExists, err := configLoader.SetDevSpaceRoot(logger)
+		if err != nil {
+			return err
+		} else if configExists {
+			localCache, err = configLoader.LoadLocalCache()
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	// Get kubectl client
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
+	if err != nil {
+		return errors.Wrap(err, "new kube client")
+	}
+
+	// If the current kube context or namespace is different from old,
+	// show warnings and reset kube client if necessary
+	client, err = kubectl.CheckKubeContext(client, localCache, cmd.NoWarn, cmd.SwitchContext, false, logger)
+	if err != nil {
+		return err
+	}
+
+	// create the devspace context
+	ctx := devspacecontext.NewContext(cmd.Ctx, nil, logger).
+		WithKubeClient(client)
+
+	// Execute plugin hook
+	err = hook.ExecuteHooks(ctx, nil, "forward")
+	if err != nil {
+		return err
+	}
+
+	namespace := client.Namespace()
+	ctx, t := ctx.WithNewTomb()
+	return interrupt.Global.RunAlways(func() error {
+		var forwards []*servicehosts.ServiceForward
+		<-t.NotifyGo(func() error {
+			// this is needed as otherwise the context
+			// is cancelled alongside the tomb
+			t.Go(func() error {
+				<-ctx.Context().Done()
+				return nil
+			})
+
+			forwards, err = servicehosts.Start(ctx, servicehosts.Options{
+				Namespace:    namespace,
+				Services:     args,
+				IPRangeStart: ipRangeStart,
+			}, t)
+			return err
+		})
+		if !t.Alive() {
+			return t.Err()
+		}
+
+		if !cmd.NoHostsFile {
+			err := servicehosts.WriteHostsFile(cmd.HostsFile, namespace, forwards)
+			if err != nil {
+				t.Kill(nil)
+				return errors.Wrap(err, "update hosts file")
+			}
+		}
+
+		printServiceForwards(logger, forwards, !cmd.NoHostsFile)
+
+		select {
+		case <-t.Dead():
+			return t.Err()
+		case <-ctx.Context().Done():
+			_ = t.Wait()
+			return nil
+		}
+	}, func() {
+		if !cmd.NoHostsFile {
+			err := servicehosts.RemoveHostsFile(cmd.HostsFile, namespace)
+			if err != nil {
+				logger.Warnf("Error removing service hostnames from %s: %v", cmd.HostsFile, err)
+			}
+		}
+	})
+}
+
+func printServiceForwards(logger log.Logger, forwards []*servicehosts.ServiceForward, hostnames bool) {
+	values := [][]string{}
+	for _, forward := range forwards {
+		host := forward.IP
+		if hostnames {
+			host = forward.Hostnames[0]
+		}
+
+		ports := []string{}
+		for _, p := range forward.Ports {
+			ports = append(ports, strconv.Itoa(p.Local)+" -> "+strconv.Itoa(p.Service))
+		}
+
+		values = append(values, []string{
+			forward.Service,
+			host,
+			forward.IP,
+			strings.Join(ports, ", "),
+		})
+	}
+
+	log.PrintTable(logger, []string{"Service", "Host", "Address", "Ports (Local -> Service)"}, values)
+	logger.Donef("Forwarding %d services, press CTRL+C to stop", len(forwards))
+}
@@ -0,0 +1,23 @@
+package cmd
+
+import (
+	"context"
+	"os"
+
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm/postrender"
+	"github.com/spf13/cobra"
+)
+
+// NewHelmPostRenderCmd creates the hidden command helm executes as post renderer for helm deployments
+// with patches or replaceImageTags
+func NewHelmPostRenderCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:    postrender.Command,
+		Args:   cobra.NoArgs,
+		Hidden: true,
+		Short:  "Post renders the manifests of a helm deployment",
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return postrender.Run(context.Background(), os.Stdin, os.Stdout)
+		},
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/dependency"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
 	deployHelm "github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm"
+	deployJsonnet "github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/jsonnet"
 	deployKubectl "github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/helm"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
@@ -58,14 +59,6 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cob
 		return errors.New(message.ConfigNotFound)
 	}
 
-	var values [][]string
-	var headerValues = []string{
-		"NAME",
-		"TYPE",
-		"DEPLOY",
-		"STATUS",
-	}
-
 	// Create new kube client
 	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
 	if err != nil {
@@ -103,6 +96,24 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cob
 	}
 	ctx = ctx.WithDependencies(dependencies)
 
+	PrintDeploymentsStatus(ctx)
+	return nil
+}
+
+// PrintDeploymentsStatus prints the status of all deployments in the config as table
+func PrintDeploymentsStatus(ctx devspacecontext.Context) {
+	var (
+		logger       = ctx.Log()
+		err          error
+		values       [][]string
+		headerValues = []string{
+			"NAME",
+			"TYPE",
+			"DEPLOY",
+			"STATUS",
+		}
+	)
+
 	if ctx.Config().Config().Deployments != nil {
 		for _, deployConfig := range ctx.Config().Config().Deployments {
 			var deployClient deployer.Interface
@@ -126,6 +137,12 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cob
 					logger.Warnf("Unable to create helm deploy config for %s: %v", deployConfig.Name, err)
 					continue
 				}
+			} else if deployConfig.Jsonnet != nil {
+				deployClient, err = deployJsonnet.New(deployConfig)
+				if err != nil {
+					logger.Warnf("Unable to create jsonnet deploy config for %s: %v", deployConfig.Name, err)
+					continue
+				}
 			} else {
 				logger.Warnf("No deployment method defined for deployment %s", deployConfig.Name)
 				continue
@@ -147,5 +164,4 @@ func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, cobraCmd *cob
 	}
 
 	logpkg.PrintTable(logger, headerValues, values)
-	return nil
 }
@@ -4,8 +4,14 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/server"
+	"github.com/loft-sh/devspace/pkg/devspace/services/portforwarding"
 	"github.com/loft-sh/devspace/pkg/util/factory"
 	"github.com/loft-sh/devspace/pkg/util/log"
 	"github.com/loft-sh/devspace/pkg/util/message"
@@ -17,6 +23,8 @@ type portsCmd struct {
 	*flags.GlobalFlags
 
 	Output string
+	Live   bool
+	UIPort int
 }
 
 type jsonOutput struct {
@@ -36,6 +44,9 @@ func newPortsCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Comma
 ############### devspace list ports ###################
 #######################################################
 Lists the port forwarding configurations
+
+With --live the state of the port forwardings of
+running devspace dev sessions is shown instead
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -44,12 +55,18 @@ Lists the port forwarding configurations
 		}}
 
 	portsCmd.Flags().StringVarP(&cmd.Output, "output", "o", "", "The output format of the command. Can be either empty or json")
+	portsCmd.Flags().BoolVar(&cmd.Live, "live", false, "If enabled, shows the live state of the port forwardings of running DevSpace sessions")
+	portsCmd.Flags().IntVar(&cmd.UIPort, "ui-port", 0, "The port of the DevSpace UI server to query with --live. If empty, all local DevSpace UI servers are queried")
 	return portsCmd
 }
 
 // RunListPort runs the list port command logic
 func (cmd *portsCmd) RunListPort(f factory.Factory, cobraCmd *cobra.Command, args []string) error {
 	logger := f.GetLog()
+	if cmd.Live {
+		return cmd.runLive(logger)
+	}
+
 	// Set config root
 	configLoader, _ := f.NewConfigLoader(cmd.ConfigPath)
 	configExists, err := configLoader.SetDevSpaceRoot(logger)
@@ -118,3 +135,105 @@ func (cmd *portsCmd) RunListPort(f factory.Factory, cobraCmd *cobra.Command, arg
 	}
 	return nil
 }
+
+func (cmd *portsCmd) runLive(logger log.Logger) error {
+	checkPorts := []int{cmd.UIPort}
+	if cmd.UIPort == 0 {
+		checkPorts = []int{}
+		for i := 0; i < 20; i++ {
+			checkPorts = append(checkPorts, server.DefaultPort+i)
+		}
+	}
+
+	// collect the port forwardings of all running ui servers
+	client := &http.Client{Timeout: 2 * time.Second}
+	statuses := []portforwarding.PortStatus{}
+	foundServer := false
+	for _, checkPort := range checkPorts {
+		domain := fmt.Sprintf("http://localhost:%d", checkPort)
+		serverVersion := &server.UIServerVersion{}
+		err := getJSON(client, domain+"/api/version", serverVersion)
+		if err != nil || !serverVersion.DevSpace {
+			continue
+		}
+
+		serverStatuses := []portforwarding.PortStatus{}
+		err = getJSON(client, domain+"/api/ports", &serverStatuses)
+		if err != nil {
+			logger.Debugf("Error retrieving port forwardings from %s: %v", domain, err)
+			continue
+		}
+
+		foundServer = true
+		statuses = append(statuses, serverStatuses...)
+	}
+	if !foundServer {
+		return errors.New("couldn't find a running DevSpace UI server. Please make sure 'devspace dev' is running")
+	}
+
+	switch cmd.Output {
+	case "":
+		if len(statuses) == 0 {
+			logger.Info("No ports are forwarded.\n")
+			return nil
+		}
+
+		values := make([][]string, 0, len(statuses))
+		for _, status := range statuses {
+			values = append(values, []string{
+				status.Name,
+				fmt.Sprintf("%s:%d -> %d", status.BindAddress, status.LocalPort, status.RemotePort),
+				status.Pod,
+				string(status.State),
+				strconv.Itoa(status.Restarts),
+				strconv.FormatInt(status.ActiveConnections, 10),
+				formatBytes(status.BytesSent),
+				formatBytes(status.BytesReceived),
+				status.Error,
+			})
+		}
+
+		log.PrintTable(logger, []string{"Name", "Port", "Pod", "State", "Restarts", "Connections", "Sent", "Received", "Last Error"}, values)
+	case "json":
+		out, err := json.MarshalIndent(statuses, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(out))
+	}
+	return nil
+}
+
+func getJSON(client *http.Client, url string, obj interface{}) error {
+	response, err := client.Get(url)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return errors.Errorf("unexpected status code %d", response.StatusCode)
+	}
+
+	contents, err := io.ReadAll(response.Body)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(contents, obj)
+}
+
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+
+	return fmt.Sprintf("%.1f %ciB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
@@ -2,11 +2,14 @@ package cmd
 
 import (
 	"context"
+	"fmt"
 	runtimevar "github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl/selector"
+	"github.com/loft-sh/devspace/pkg/devspace/services/inspect"
 	"github.com/loft-sh/devspace/pkg/devspace/services/logs"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
 	"os"
 	"time"
 
@@ -37,6 +40,8 @@ type LogsCmd struct {
 	Follow            bool
 	Wait              bool
 	LastAmountOfLines int
+
+	HTTP bool
 }
 
 // NewLogsCmd creates a new login command
@@ -56,6 +61,7 @@ to it
 Example:
 devspace logs
 devspace logs --namespace=mynamespace
+devspace logs --http -f
 #######################################################
 	`,
 		Args: cobra.NoArgs,
@@ -73,6 +79,7 @@ devspace logs --namespace=mynamespace
 	logsCmd.Flags().BoolVarP(&cmd.Follow, "follow", "f", false, "Attach to logs afterwards")
 	logsCmd.Flags().IntVar(&cmd.LastAmountOfLines, "lines", 200, "Max amount of lines to print from the last log")
 	logsCmd.Flags().BoolVar(&cmd.Wait, "wait", false, "Wait for the pod(s) to start if they are not running")
+	logsCmd.Flags().BoolVar(&cmd.HTTP, "http", false, "Print the http requests recorded on forwarded ports with inspect enabled instead of container logs")
 
 	return logsCmd
 }
@@ -91,6 +98,13 @@ func (cmd *LogsCmd) RunLogs(f factory.Factory) error {
 		return err
 	}
 
+	// Print recorded http requests
+	if cmd.HTTP {
+		return inspect.TailRecords(context.Background(), logpkg.Logdir+inspect.LogFile, cmd.LastAmountOfLines, cmd.Follow, func(record *inspect.Record) {
+			_, _ = fmt.Fprintln(os.Stdout, inspect.FormatRecord(record))
+		})
+	}
+
 	// Get kubectl client
 	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
 	if err != nil {
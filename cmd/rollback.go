@@ -0,0 +1,106 @@
+package cmd
+
+import (
+	"context"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+// RollbackCmd holds the rollback cmd flags
+type RollbackCmd struct {
+	*flags.GlobalFlags
+
+	To int
+}
+
+// NewRollbackCmd creates a new rollback command
+func NewRollbackCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &RollbackCmd{GlobalFlags: globalFlags}
+
+	rollbackCmd := &cobra.Command{
+		Use:   "rollback [deployment]",
+		Short: "Rolls deployments back to a previous revision",
+		Long: `
+#######################################################
+################# devspace rollback ###################
+#######################################################
+Rolls deployments back to a previous revision. Helm
+deployments are rolled back to a previous release
+revision, kubectl deployments re-apply previously
+deployed manifests and delete objects that did not
+exist in that revision.
+
+Example:
+devspace rollback
+devspace rollback my-deployment
+devspace rollback my-deployment --to 3
+#######################################################
+	`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunRollback(f, args)
+		},
+	}
+
+	rollbackCmd.Flags().IntVar(&cmd.To, "to", 0, "The revision to roll back to. Defaults to the previous revision")
+	return rollbackCmd
+}
+
+// RunRollback executes the functionality "devspace rollback"
+func (cmd *RollbackCmd) RunRollback(f factory.Factory, args []string) error {
+	if cmd.To < 0 {
+		return errors.New("--to cannot be negative")
+	}
+
+	// Set config root
+	log := f.GetLog()
+	configOptions := cmd.ToConfigOptions()
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(log)
+	if err != nil {
+		return err
+	} else if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	// Create kubectl client
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// Load generated
+	localCache, err := configLoader.LoadLocalCache()
+	if err != nil {
+		return err
+	}
+
+	// If the current kube context or namespace is different from old,
+	// show warnings and reset kube client if necessary
+	client, err = kubectl.CheckKubeContext(client, localCache, cmd.NoWarn, cmd.SwitchContext, false, log)
+	if err != nil {
+		return err
+	}
+
+	// Get config with adjusted cluster config
+	configInterface, err := configLoader.LoadWithCache(context.Background(), localCache, client, configOptions, log)
+	if err != nil {
+		return err
+	}
+
+	// Create context
+	ctx := devspacecontext.NewContext(context.Background(), configInterface.Variables(), log).
+		WithConfig(configInterface).
+		WithKubeClient(client)
+
+	return f.NewDeployController().Rollback(ctx, args, cmd.To)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/kill"
 	"github.com/mgutz/ansi"
 
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/external"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/expression"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
@@ -31,6 +32,7 @@ import (
 	"github.com/loft-sh/devspace/cmd/remove"
 	"github.com/loft-sh/devspace/cmd/reset"
 	"github.com/loft-sh/devspace/cmd/set"
+	"github.com/loft-sh/devspace/cmd/status"
 	"github.com/loft-sh/devspace/cmd/update"
 	"github.com/loft-sh/devspace/cmd/use"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable"
@@ -270,6 +272,7 @@ Additional run commands:
 	rootCmd.AddCommand(remove.NewRemoveCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(reset.NewResetCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(set.NewSetCmd(f, globalFlags, plugins))
+	rootCmd.AddCommand(status.NewStatusCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(use.NewUseCmd(f, globalFlags, plugins))
 	rootCmd.AddCommand(update.NewUpdateCmd(f, globalFlags, plugins))
 
@@ -277,10 +280,12 @@ Additional run commands:
 	rootCmd.AddCommand(NewInitCmd(f))
 	rootCmd.AddCommand(NewRestartCmd(f, globalFlags))
 	rootCmd.AddCommand(NewSyncCmd(f, globalFlags))
+	rootCmd.AddCommand(NewForwardCmd(f, globalFlags))
 	rootCmd.AddCommand(NewRenderCmd(f, globalFlags, rawConfig))
 	rootCmd.AddCommand(NewUpgradeCmd())
 	rootCmd.AddCommand(NewEnterCmd(f, globalFlags))
 	rootCmd.AddCommand(NewAnalyzeCmd(f, globalFlags))
+	rootCmd.AddCommand(NewRollbackCmd(f, globalFlags))
 	rootCmd.AddCommand(NewLogsCmd(f, globalFlags))
 	rootCmd.AddCommand(NewOpenCmd(f, globalFlags))
 	rootCmd.AddCommand(NewUICmd(f, globalFlags))
@@ -290,6 +295,7 @@ Additional run commands:
 	rootCmd.AddCommand(NewRunPipelineCmd(f, globalFlags, rawConfig))
 	rootCmd.AddCommand(NewCompletionCmd())
 	rootCmd.AddCommand(NewVersionCmd())
+	rootCmd.AddCommand(NewHelmPostRenderCmd())
 
 	// check overwrite commands
 	rootCmd.AddCommand(NewDevCmd(f, globalFlags, rawConfig))
@@ -303,6 +309,7 @@ Additional run commands:
 	}
 	plugin.AddPluginCommands(rootCmd, plugins, "")
 	variable.AddPredefinedVars(plugins)
+	external.AddPluginBuilders(plugins)
 	return rootCmd
 }
 
@@ -41,6 +41,9 @@ type RunPipelineCmd struct {
 
 	Tags                    []string
 	Render                  bool
+	Diff                    bool
+	DiffExitCode            bool
+	DryRun                  bool
 	Pipeline                string
 	SkipPush                bool
 	SkipPushLocalKubernetes bool
@@ -77,6 +80,9 @@ func (cmd *RunPipelineCmd) AddPipelineFlags(f factory.Factory, command *cobra.Co
 	command.Flags().BoolVar(&cmd.BuildSequential, "build-sequential", cmd.BuildSequential, "Builds the images one after another instead of in parallel")
 	command.Flags().IntVar(&cmd.MaxConcurrentBuilds, "max-concurrent-builds", cmd.MaxConcurrentBuilds, "The maximum number of image builds built in parallel (0 for infinite)")
 	command.Flags().BoolVar(&cmd.Render, "render", cmd.Render, "If true will render manifests and print them instead of actually deploying them")
+	command.Flags().BoolVar(&cmd.Diff, "diff", cmd.Diff, "If true will print the difference between the rendered manifests and the cluster instead of actually deploying them. Images are not built or pushed")
+	command.Flags().BoolVar(&cmd.DiffExitCode, "diff-exit-code", cmd.DiffExitCode, "If true will exit with a non zero exit code if --diff found changes")
+	command.Flags().BoolVar(&cmd.DryRun, "dry-run", cmd.DryRun, "If true will apply the manifests of kubectl deployments as server side dry run and print which objects would change instead of actually deploying them")
 
 	command.Flags().BoolVar(&cmd.ForcePurge, "force-purge", cmd.ForcePurge, "Forces to purge every deployment even though it might be in use by another DevSpace project")
 	command.Flags().BoolVarP(&cmd.ForceDeploy, "force-deploy", "d", cmd.ForceDeploy, "Forces to deploy every deployment")
@@ -382,7 +388,7 @@ func (cmd *RunPipelineCmd) BuildOptions(configOptions *loader.ConfigOptions) *Co
 		Options: types.Options{
 			BuildOptions: build.Options{
 				Tags:                      cmd.Tags,
-				SkipBuild:                 cmd.SkipBuild,
+				SkipBuild:                 cmd.SkipBuild || cmd.Diff,
 				SkipPush:                  cmd.SkipPush,
 				SkipPushOnLocalKubernetes: cmd.SkipPushLocalKubernetes,
 				ForceRebuild:              cmd.ForceBuild,
@@ -393,6 +399,9 @@ func (cmd *RunPipelineCmd) BuildOptions(configOptions *loader.ConfigOptions) *Co
 				ForceDeploy:  cmd.ForceDeploy,
 				Render:       cmd.Render,
 				RenderWriter: cmd.RenderWriter,
+				Diff:         cmd.Diff,
+				DiffExitCode: cmd.DiffExitCode,
+				DryRun:       cmd.DryRun,
 				SkipDeploy:   cmd.SkipDeploy,
 			},
 			PurgeOptions: deploy.PurgeOptions{
@@ -443,7 +452,7 @@ func runPipeline(ctx devspacecontext.Context, args []string, options *CommandOpt
 	defer devPodManager.Close()
 
 	// create dependency registry
-	dependencyRegistry := registry.NewDependencyRegistry(ctx.Config().Config().Name, options.DeployOptions.Render)
+	dependencyRegistry := registry.NewDependencyRegistry(ctx.Config().Config().Name, options.DeployOptions.Render || options.DeployOptions.Diff || options.DeployOptions.DryRun)
 
 	// get deploy pipeline
 	pipe := pipelinepkg.NewPipeline(ctx.Config().Config().Name, devPodManager, dependencyRegistry, configPipeline, options.Options)
@@ -0,0 +1,192 @@
+package status
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/cmd/list"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/dependency"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/drift"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/message"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+)
+
+type deploymentsCmd struct {
+	*flags.GlobalFlags
+
+	Drift   bool
+	Reapply bool
+}
+
+func newDeploymentsCmd(f factory.Factory, globalFlags *flags.GlobalFlags) *cobra.Command {
+	cmd := &deploymentsCmd{GlobalFlags: globalFlags}
+
+	deploymentsCmd := &cobra.Command{
+		Use:   "deployments [deployment...]",
+		Short: "Shows the status of all deployments",
+		Long: `
+#######################################################
+############ devspace status deployments ##############
+#######################################################
+Shows the status of all deployments. With --drift the
+manifests of the last deploy are compared with the
+objects in the cluster to find changes that were made
+outside of DevSpace, e.g. with kubectl edit, by an
+autoscaler or deleted objects.
+
+Example:
+devspace status deployments
+devspace status deployments --drift
+devspace status deployments my-deployment --drift --reapply
+#######################################################
+	`,
+		RunE: func(cobraCmd *cobra.Command, args []string) error {
+			return cmd.RunDeploymentsStatus(f, args)
+		}}
+
+	deploymentsCmd.Flags().BoolVar(&cmd.Drift, "drift", false, "Compares the deployed manifests with the objects in the cluster")
+	deploymentsCmd.Flags().BoolVar(&cmd.Reapply, "reapply", false, "Redeploys the deployments that drifted. Requires --drift")
+	return deploymentsCmd
+}
+
+// RunDeploymentsStatus executes the devspace status deployments command logic
+func (cmd *deploymentsCmd) RunDeploymentsStatus(f factory.Factory, args []string) error {
+	if cmd.Reapply && !cmd.Drift {
+		return errors.New("--reapply can only be used together with --drift")
+	}
+
+	// Set config root
+	logger := f.GetLog()
+	configOptions := cmd.ToConfigOptions()
+	configLoader, err := f.NewConfigLoader(cmd.ConfigPath)
+	if err != nil {
+		return err
+	}
+	configExists, err := configLoader.SetDevSpaceRoot(logger)
+	if err != nil {
+		return err
+	} else if !configExists {
+		return errors.New(message.ConfigNotFound)
+	}
+
+	// Create new kube client
+	client, err := f.NewKubeClientFromContext(cmd.KubeContext, cmd.Namespace)
+	if err != nil {
+		return err
+	}
+
+	// Load generated
+	localCache, err := configLoader.LoadLocalCache()
+	if err != nil {
+		return err
+	}
+
+	// If the current kube context or namespace is different from old,
+	// show warnings and reset kube client if necessary
+	client, err = kubectl.CheckKubeContext(client, localCache, cmd.NoWarn, cmd.SwitchContext, false, logger)
+	if err != nil {
+		return err
+	}
+
+	// Get config with adjusted cluster config
+	configInterface, err := configLoader.LoadWithCache(context.Background(), localCache, client, configOptions, logger)
+	if err != nil {
+		return err
+	}
+
+	// Create context
+	ctx := devspacecontext.NewContext(context.Background(), configInterface.Variables(), logger).
+		WithConfig(configInterface).
+		WithKubeClient(client)
+
+	// Resolve dependencies
+	dependencies, err := f.NewDependencyManager(ctx, configOptions).ResolveAll(ctx, dependency.ResolveOptions{})
+	if err != nil {
+		return err
+	}
+	ctx = ctx.WithDependencies(dependencies)
+
+	if !cmd.Drift {
+		list.PrintDeploymentsStatus(ctx)
+		return nil
+	}
+
+	controller := f.NewDeployController()
+	deployments, err := controller.Drift(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	printDrift(logger, deployments)
+	if !cmd.Reapply {
+		return nil
+	}
+
+	drifted := []string{}
+	for _, deployment := range deployments {
+		if deployment.Drifted() {
+			drifted = append(drifted, deployment.Name)
+		}
+	}
+	if len(drifted) == 0 {
+		logger.Done("No deployment drifted")
+		return nil
+	}
+
+	logger.Infof("Redeploying drifted deployment(s) %s...", strings.Join(drifted, ", "))
+	return controller.Deploy(ctx, drifted, &deploy.Options{ForceDeploy: true})
+}
+
+// printDrift prints a table with the drift of each deployment and the drifted objects
+func printDrift(logger logpkg.Logger, deployments []*drift.Deployment) {
+	values := [][]string{}
+	for _, deployment := range deployments {
+		status := "In Sync"
+		if deployment.NotDeployed {
+			status = "Not Deployed"
+		} else if deployment.Drifted() {
+			status = "Drifted"
+		}
+
+		config := "Unchanged"
+		if deployment.NotDeployed {
+			config = "-"
+		} else if deployment.ConfigChanged {
+			config = "Changed"
+		}
+
+		values = append(values, []string{
+			deployment.Name,
+			status,
+			config,
+			strconv.Itoa(len(deployment.Objects)),
+		})
+	}
+
+	logpkg.PrintTable(logger, []string{"NAME", "STATUS", "CONFIG", "DRIFTED OBJECTS"}, values)
+
+	for _, deployment := range deployments {
+		if deployment.ConfigChanged {
+			logger.Warnf("%s: deployment config changed since the last deploy", deployment.Name)
+		}
+
+		for _, object := range deployment.Objects {
+			if object.Deleted {
+				logger.Warnf("%s: %s was deleted", deployment.Name, object.ID())
+				continue
+			}
+
+			for _, field := range object.Fields {
+				logger.Warnf("%s: %s %s", deployment.Name, object.ID(), field.String())
+			}
+		}
+	}
+}
@@ -0,0 +1,28 @@
+package status
+
+import (
+	"github.com/loft-sh/devspace/cmd/flags"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/factory"
+	"github.com/spf13/cobra"
+)
+
+// NewStatusCmd creates a new cobra command
+func NewStatusCmd(f factory.Factory, globalFlags *flags.GlobalFlags, plugins []plugin.Metadata) *cobra.Command {
+	statusCmd := &cobra.Command{
+		Use:   "status",
+		Short: "Shows the status of the project in the cluster",
+		Long: `
+#######################################################
+################### devspace status ###################
+#######################################################
+	`,
+		Args: cobra.NoArgs,
+	}
+
+	statusCmd.AddCommand(newDeploymentsCmd(f, globalFlags))
+
+	// Add plugin commands
+	plugin.AddPluginCommands(statusCmd, plugins, "status")
+	return statusCmd
+}
@@ -262,6 +262,14 @@ var Functions = []Function{
 		Flags:       commands.CreateDeploymentsOptions{},
 		Group:       groupDeployments,
 	},
+	{
+		Name:        "diff_deployments",
+		Description: `Prints the difference between the rendered deployments passed as arguments and the cluster`,
+		Args:        `[deployment-1] [deployment-2] ...`,
+		Handler:     commands.DiffDeployments,
+		Flags:       commands.DiffDeploymentsOptions{},
+		Group:       groupDeployments,
+	},
 	{
 		Name:        "purge_deployments",
 		Description: `Purges all deployments passed as arguments`,
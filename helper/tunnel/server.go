@@ -14,6 +14,9 @@ import (
 	"io"
 	"net"
 	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
 	"strings"
 )
 
@@ -190,32 +193,26 @@ func (t *tunnelServer) InitTunnel(stream remote.Tunnel_InitTunnelServer) error {
 	if err != nil {
 		return fmt.Errorf("failed receiving initial connection from tunnel")
 	}
-	port := request.GetPort()
-	if port == 0 {
-		err := stream.Send(&remote.SocketDataResponse{
-			HasErr: true,
-			LogMessage: &remote.LogMessage{
-				LogLevel: remote.LogLevel_ERROR,
-				Message:  "missing port",
-			},
-		})
-		if err != nil {
-			return err
-		}
-		return errors.New("missing port")
+	var ln net.Listener
+	if request.GetScheme() == remote.TunnelScheme_UNIX {
+		ln, err = listenUnix(request)
+	} else {
+		ln, err = listenPort(request)
 	}
-
-	ln, err := net.Listen(strings.ToLower(request.GetScheme().String()), fmt.Sprintf(":%d", port))
 	if err != nil {
-		_ = stream.Send(&remote.SocketDataResponse{
+		sendErr := stream.Send(&remote.SocketDataResponse{
 			HasErr: true,
 			LogMessage: &remote.LogMessage{
 				LogLevel: remote.LogLevel_ERROR,
-				Message:  fmt.Sprintf("failed opening listener type %s on port %d: %v", request.GetScheme(), request.GetPort(), err),
+				Message:  err.Error(),
 			},
 		})
-		return fmt.Errorf("failed listening on port %d: %v", port, err)
+		if sendErr != nil {
+			return sendErr
+		}
+		return err
 	}
+	address := ln.Addr().String()
 
 	sessions := make(chan *Session)
 	closeChan := make(chan struct{})
@@ -233,7 +230,7 @@ func (t *tunnelServer) InitTunnel(stream remote.Tunnel_InitTunnelServer) error {
 		if err != nil {
 			return err
 		}
-		stderrlog.Debugf("accepted new connection on ::%d", port)
+		stderrlog.Debugf("accepted new connection on %s", address)
 
 		// socket -> stream
 		session, err := NewSession(connection)
@@ -245,3 +242,109 @@ func (t *tunnelServer) InitTunnel(stream remote.Tunnel_InitTunnelServer) error {
 		go readConn(stream.Context(), session, sessions)
 	}
 }
+
+func listenPort(request *remote.SocketDataRequest) (net.Listener, error) {
+	port := request.GetPort()
+	if port == 0 {
+		return nil, errors.New("missing port")
+	}
+
+	ln, err := net.Listen(strings.ToLower(request.GetScheme().String()), fmt.Sprintf(":%d", port))
+	if err != nil {
+		return nil, fmt.Errorf("failed opening listener type %s on port %d: %v", request.GetScheme(), port, err)
+	}
+
+	return ln, nil
+}
+
+func listenUnix(request *remote.SocketDataRequest) (net.Listener, error) {
+	socketPath := request.GetSocketPath()
+	if socketPath == "" {
+		return nil, errors.New("missing socket path")
+	}
+
+	// remove a leftover socket from a previous session
+	stat, err := os.Lstat(socketPath)
+	if err == nil {
+		if stat.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s already exists and is not a socket", socketPath)
+		}
+
+		err = os.Remove(socketPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed removing old socket %s: %v", socketPath, err)
+		}
+	}
+
+	err = os.MkdirAll(filepath.Dir(socketPath), 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating directory for socket %s: %v", socketPath, err)
+	}
+
+	ln, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed opening listener on socket %s: %v", socketPath, err)
+	}
+
+	if request.GetSocketMode() != 0 {
+		err = os.Chmod(socketPath, os.FileMode(request.GetSocketMode()))
+		if err != nil {
+			_ = ln.Close()
+			return nil, fmt.Errorf("failed changing permissions of socket %s: %v", socketPath, err)
+		}
+	}
+
+	if request.GetSocketOwner() != "" || request.GetSocketGroup() != "" {
+		uid, gid, err := lookupOwner(request.GetSocketOwner(), request.GetSocketGroup())
+		if err != nil {
+			_ = ln.Close()
+			return nil, err
+		}
+
+		err = os.Chown(socketPath, uid, gid)
+		if err != nil {
+			_ = ln.Close()
+			return nil, fmt.Errorf("failed changing owner of socket %s: %v", socketPath, err)
+		}
+	}
+
+	return ln, nil
+}
+
+// lookupOwner resolves the given user and group names or ids. An empty
+// user or group is returned as -1, which leaves it unchanged on chown.
+func lookupOwner(owner, group string) (int, int, error) {
+	uid, gid := -1, -1
+	if owner != "" {
+		id, err := strconv.Atoi(owner)
+		if err != nil {
+			u, err := user.Lookup(owner)
+			if err != nil {
+				return 0, 0, fmt.Errorf("failed looking up user %s: %v", owner, err)
+			}
+
+			id, err = strconv.Atoi(u.Uid)
+			if err != nil {
+				return 0, 0, fmt.Errorf("unexpected uid %s of user %s", u.Uid, owner)
+			}
+		}
+		uid = id
+	}
+	if group != "" {
+		id, err := strconv.Atoi(group)
+		if err != nil {
+			g, err := user.LookupGroup(group)
+			if err != nil {
+				return 0, 0, fmt.Errorf("failed looking up group %s: %v", group, err)
+			}
+
+			id, err = strconv.Atoi(g.Gid)
+			if err != nil {
+				return 0, 0, fmt.Errorf("unexpected gid %s of group %s", g.Gid, group)
+			}
+		}
+		gid = id
+	}
+
+	return uid, gid, nil
+}
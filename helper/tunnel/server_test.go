@@ -0,0 +1,193 @@
+//go:build !windows
+// +build !windows
+
+package tunnel
+
+import (
+	"net"
+	"os"
+	"os/user"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"gotest.tools/assert"
+)
+
+type listenUnixTestCase struct {
+	name    string
+	prepare func(socketPath string)
+	request *remote.SocketDataRequest
+
+	expectedMode os.FileMode
+	expectedErr  string
+}
+
+func TestListenUnix(t *testing.T) {
+	current, err := user.Current()
+	assert.NilError(t, err)
+
+	testCases := []listenUnixTestCase{
+		{
+			name:         "New socket in a missing directory",
+			request:      &remote.SocketDataRequest{},
+			expectedMode: 0,
+		},
+		{
+			name:         "Socket mode",
+			request:      &remote.SocketDataRequest{SocketMode: 0600},
+			expectedMode: 0600,
+		},
+		{
+			name:         "Socket owner and group as ids",
+			request:      &remote.SocketDataRequest{SocketMode: 0660, SocketOwner: current.Uid, SocketGroup: current.Gid},
+			expectedMode: 0660,
+		},
+		{
+			name: "Leftover socket",
+			prepare: func(socketPath string) {
+				assert.NilError(t, os.MkdirAll(filepath.Dir(socketPath), 0755))
+				ln, err := net.Listen("unix", socketPath)
+				assert.NilError(t, err)
+				ln.(*net.UnixListener).SetUnlinkOnClose(false)
+				assert.NilError(t, ln.Close())
+			},
+			request:      &remote.SocketDataRequest{SocketMode: 0660},
+			expectedMode: 0660,
+		},
+		{
+			name: "Existing file",
+			prepare: func(socketPath string) {
+				assert.NilError(t, os.MkdirAll(filepath.Dir(socketPath), 0755))
+				assert.NilError(t, os.WriteFile(socketPath, []byte("test"), 0644))
+			},
+			request:     &remote.SocketDataRequest{},
+			expectedErr: "already exists and is not a socket",
+		},
+		{
+			name:        "Unknown owner",
+			request:     &remote.SocketDataRequest{SocketOwner: "devspace-unknown-user"},
+			expectedErr: "failed looking up user devspace-unknown-user",
+		},
+	}
+
+	for _, testCase := range testCases {
+		// unix socket paths are limited to about 100 characters, so keep them short
+		dir, err := os.MkdirTemp("", "tunnel")
+		assert.NilError(t, err)
+		socketPath := filepath.Join(dir, "run", "test.sock")
+		if testCase.prepare != nil {
+			testCase.prepare(socketPath)
+		}
+
+		testCase.request.SocketPath = socketPath
+		ln, err := listenUnix(testCase.request)
+		if testCase.expectedErr != "" {
+			assert.ErrorContains(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+			_ = os.RemoveAll(dir)
+			continue
+		}
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+
+		stat, err := os.Stat(socketPath)
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		assert.Assert(t, stat.Mode()&os.ModeSocket != 0, "No socket created in testCase %s", testCase.name)
+		if testCase.expectedMode != 0 {
+			assert.Equal(t, stat.Mode().Perm(), testCase.expectedMode, "Unexpected mode in testCase %s", testCase.name)
+		}
+
+		// connections to the socket are accepted by the listener
+		go func() {
+			conn, err := net.Dial("unix", socketPath)
+			if err == nil {
+				_, _ = conn.Write([]byte("ping"))
+				_ = conn.Close()
+			}
+		}()
+		conn, err := ln.Accept()
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		buf := make([]byte, 4)
+		_, err = conn.Read(buf)
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		assert.Equal(t, string(buf), "ping", "Unexpected data in testCase %s", testCase.name)
+
+		_ = conn.Close()
+		_ = ln.Close()
+		_ = os.RemoveAll(dir)
+	}
+
+	_, err = listenUnix(&remote.SocketDataRequest{})
+	assert.Error(t, err, "missing socket path")
+}
+
+type lookupOwnerTestCase struct {
+	name  string
+	owner string
+	group string
+
+	expectedUID int
+	expectedGID int
+	expectedErr string
+}
+
+func TestLookupOwner(t *testing.T) {
+	current, err := user.Current()
+	assert.NilError(t, err)
+	uid, err := strconv.Atoi(current.Uid)
+	assert.NilError(t, err)
+	gid, err := strconv.Atoi(current.Gid)
+	assert.NilError(t, err)
+	group, err := user.LookupGroupId(current.Gid)
+	assert.NilError(t, err)
+
+	testCases := []lookupOwnerTestCase{
+		{
+			name:        "Unchanged",
+			expectedUID: -1,
+			expectedGID: -1,
+		},
+		{
+			name:        "Numeric ids",
+			owner:       "1000",
+			group:       "2000",
+			expectedUID: 1000,
+			expectedGID: 2000,
+		},
+		{
+			name:        "Names",
+			owner:       current.Username,
+			group:       group.Name,
+			expectedUID: uid,
+			expectedGID: gid,
+		},
+		{
+			name:        "Only group",
+			group:       "2000",
+			expectedUID: -1,
+			expectedGID: 2000,
+		},
+		{
+			name:        "Unknown user",
+			owner:       "devspace-unknown-user",
+			expectedErr: "failed looking up user devspace-unknown-user",
+		},
+		{
+			name:        "Unknown group",
+			group:       "devspace-unknown-group",
+			expectedErr: "failed looking up group devspace-unknown-group",
+		},
+	}
+
+	for _, testCase := range testCases {
+		uid, gid, err := lookupOwner(testCase.owner, testCase.group)
+		if testCase.expectedErr != "" {
+			assert.ErrorContains(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+			continue
+		}
+
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		assert.Equal(t, uid, testCase.expectedUID, "Unexpected uid in testCase %s", testCase.name)
+		assert.Equal(t, gid, testCase.expectedGID, "Unexpected gid in testCase %s", testCase.name)
+	}
+}
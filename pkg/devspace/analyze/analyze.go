@@ -7,6 +7,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/wait"
 
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
@@ -45,6 +46,25 @@ type Options struct {
 	Patient bool
 
 	IgnorePodRestarts bool
+
+	// Selectors restricts the analysis to the pods, replica sets and stateful sets whose pods match
+	// one of the selectors and to their events. If empty, the whole namespace is analyzed
+	Selectors []labels.Selector
+}
+
+// matches checks if the pod labels match the selectors of the options
+func (o Options) matches(podLabels map[string]string) bool {
+	if len(o.Selectors) == 0 {
+		return true
+	}
+
+	for _, selector := range o.Selectors {
+		if selector.Matches(labels.Set(podLabels)) {
+			return true
+		}
+	}
+
+	return false
 }
 
 type analyzer struct {
@@ -104,7 +124,7 @@ func (a *analyzer) CreateReport(namespace string, options Options) ([]*ReportIte
 
 		// Analyze replicasets
 		if !checkEvents {
-			replicaSetProblems, err := a.replicaSets(namespace)
+			replicaSetProblems, err := a.replicaSets(namespace, options)
 			if err != nil {
 				return false, errors.Errorf("Error during analyzing replica sets: %v", err)
 			}
@@ -115,7 +135,7 @@ func (a *analyzer) CreateReport(namespace string, options Options) ([]*ReportIte
 
 		// Analyze statefulsets
 		if !checkEvents {
-			statefulSetProblems, err := a.statefulSets(namespace)
+			statefulSetProblems, err := a.statefulSets(namespace, options)
 			if err != nil {
 				return false, errors.Errorf("Error during analyzing stateful sets: %v", err)
 			}
@@ -126,7 +146,7 @@ func (a *analyzer) CreateReport(namespace string, options Options) ([]*ReportIte
 
 		if checkEvents {
 			// Analyze events
-			problems, err = a.events(namespace)
+			problems, err = a.events(namespace, options)
 			if err != nil {
 				return false, errors.Errorf("Error during analyzing events: %v", err)
 			}
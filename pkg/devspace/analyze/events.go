@@ -15,9 +15,19 @@ import (
 const EventRelevanceTime = 1800 * time.Second
 
 // Events checks the namespace events for warnings
-func (a *analyzer) events(namespace string) ([]string, error) {
+func (a *analyzer) events(namespace string, options Options) ([]string, error) {
 	problems := []string{}
 
+	// Get the objects the events have to belong to
+	var objects map[string]bool
+	if len(options.Selectors) > 0 {
+		var err error
+		objects, err = a.selectedObjects(namespace, options)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// Get all events
 	events, err := a.client.KubeClient().CoreV1().Events(namespace).List(context.TODO(), metav1.ListOptions{})
 	if err != nil {
@@ -27,6 +37,10 @@ func (a *analyzer) events(namespace string) ([]string, error) {
 	// loop through events
 	if events.Items != nil {
 		for _, event := range events.Items {
+			if objects != nil && !objects[event.InvolvedObject.Kind+"/"+event.InvolvedObject.Name] {
+				continue
+			}
+
 			if event.Type != "Normal" {
 				// This is a bad guess, but works for most resources
 				multiple, _ := meta.UnsafeGuessKindToResource(event.InvolvedObject.GroupVersionKind())
@@ -43,6 +57,72 @@ func (a *analyzer) events(namespace string) ([]string, error) {
 	return problems, nil
 }
 
+// selectedObjects returns the kind and name of the pods and their owners that match the selectors
+func (a *analyzer) selectedObjects(namespace string, options Options) (map[string]bool, error) {
+	objects := map[string]bool{}
+	pods, err := a.client.KubeClient().CoreV1().Pods(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, pod := range pods.Items {
+		if options.matches(pod.Labels) {
+			objects["Pod/"+pod.Name] = true
+		}
+	}
+
+	replicaSets, err := a.client.KubeClient().AppsV1().ReplicaSets(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, replicaSet := range replicaSets.Items {
+		if options.matches(replicaSet.Spec.Template.Labels) {
+			objects["ReplicaSet/"+replicaSet.Name] = true
+		}
+	}
+
+	deployments, err := a.client.KubeClient().AppsV1().Deployments(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, deployment := range deployments.Items {
+		if options.matches(deployment.Spec.Template.Labels) {
+			objects["Deployment/"+deployment.Name] = true
+		}
+	}
+
+	statefulSets, err := a.client.KubeClient().AppsV1().StatefulSets(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, statefulSet := range statefulSets.Items {
+		if options.matches(statefulSet.Spec.Template.Labels) {
+			objects["StatefulSet/"+statefulSet.Name] = true
+		}
+	}
+
+	daemonSets, err := a.client.KubeClient().AppsV1().DaemonSets(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, daemonSet := range daemonSets.Items {
+		if options.matches(daemonSet.Spec.Template.Labels) {
+			objects["DaemonSet/"+daemonSet.Name] = true
+		}
+	}
+
+	jobs, err := a.client.KubeClient().BatchV1().Jobs(namespace).List(context.TODO(), metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for _, job := range jobs.Items {
+		if options.matches(job.Spec.Template.Labels) {
+			objects["Job/"+job.Name] = true
+		}
+	}
+
+	return objects, nil
+}
+
 // Copied from dynamic client
 func makeURLSegments(resource schema.GroupVersionResource, namespace, name string) []string {
 	url := []string{}
@@ -46,6 +46,10 @@ func (a *analyzer) pods(namespace string, options Options) ([]string, error) {
 
 			if pods.Items != nil {
 				for _, pod := range pods.Items {
+					if !options.matches(pod.Labels) {
+						continue
+					}
+
 					podStatus := kubectl.GetPodStatus(&pod)
 					for _, status := range kubectl.WaitStatus {
 						if podStatus == status {
@@ -83,6 +87,10 @@ func (a *analyzer) pods(namespace string, options Options) ([]string, error) {
 	// Analyzing pods
 	if pods.Items != nil {
 		for _, pod := range pods.Items {
+			if !options.matches(pod.Labels) {
+				continue
+			}
+
 			problem := checkPod(a.client, &pod, options.IgnorePodRestarts)
 			if problem != nil {
 				problems = append(problems, printPodProblem(problem))
@@ -8,7 +8,7 @@ import (
 )
 
 // ReplicaSets checks replica sets for problems
-func (a *analyzer) replicaSets(namespace string) ([]string, error) {
+func (a *analyzer) replicaSets(namespace string, options Options) ([]string, error) {
 	problems := []string{}
 
 	// Get all pods
@@ -19,7 +19,7 @@ func (a *analyzer) replicaSets(namespace string) ([]string, error) {
 
 	// Check for issues
 	for _, replicaSet := range replicaSets.Items {
-		if replicaSet.Spec.Replicas == nil {
+		if replicaSet.Spec.Replicas == nil || !options.matches(replicaSet.Spec.Template.Labels) {
 			continue
 		}
 
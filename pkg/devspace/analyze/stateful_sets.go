@@ -8,7 +8,7 @@ import (
 )
 
 // StatefulSets checks stateful sets for problems
-func (a *analyzer) statefulSets(namespace string) ([]string, error) {
+func (a *analyzer) statefulSets(namespace string, options Options) ([]string, error) {
 	problems := []string{}
 
 	// Get all pods
@@ -19,7 +19,7 @@ func (a *analyzer) statefulSets(namespace string) ([]string, error) {
 
 	// Check for issues
 	for _, statefulSet := range statefulSets.Items {
-		if statefulSet.Spec.Replicas == nil {
+		if statefulSet.Spec.Replicas == nil || !options.matches(statefulSet.Spec.Template.Labels) {
 			continue
 		}
 
@@ -7,6 +7,8 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/pullsecrets"
 
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/build/localregistry"
 	"github.com/loft-sh/devspace/pkg/devspace/build/types"
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
@@ -63,7 +65,7 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 
 	// Check if we have at least 1 image to build
 	if options.SkipBuild {
-		ctx.Log().Debugf("Skip building because of --skip-build")
+		ctx.Log().Debugf("Skip building because of --skip-build or --diff")
 		return nil
 	} else if len(conf.Images) == 0 {
 		ctx.Log().Debugf("Skip building because no images are defined")
@@ -85,8 +87,18 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 		}
 	}
 
+	// Images that use the same context share the context hash
+	ctx = ctx.WithContext(helper.WithSharedContextHashes(ctx.Context()))
+
+	// Resolve the dependencies between the images
+	dependencies, err := resolveImageDependencies(ctx, conf.Images)
+	if err != nil {
+		return err
+	}
+
 	// Determine if we need to use the local registry to build any images.
 	builders := map[string]builder.Interface{}
+	imageConfs := map[string]*latest.Image{}
 	tags := map[string][]string{}
 
 	for imageConfigName, imageConf := range conf.Images {
@@ -135,6 +147,19 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 			}
 		}
 
+		// Dependent images get their own copy of the config, because the
+		// images of the dependencies are injected as build args
+		if len(dependencies[imageConfigName]) > 0 {
+			cImageConf := *imageConf
+			if imageConf.BuildArgs != nil {
+				cImageConf.BuildArgs = map[string]*string{}
+				for k, v := range imageConf.BuildArgs {
+					cImageConf.BuildArgs[k] = v
+				}
+			}
+			imageConf = &cImageConf
+		}
+
 		// Create new builder
 		builder, err := c.createBuilder(ctx, imageConf, imageTags, options)
 		if err != nil {
@@ -143,6 +168,7 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 
 		// Save builder for later use
 		builders[imageConfigName] = builder
+		imageConfs[imageConfigName] = imageConf
 
 		// Save image tags
 		tags[imageConfigName] = imageTags
@@ -154,68 +180,65 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 		return pluginErr
 	}
 
+	// Build the images in the order of their dependencies
+	imageNames := []string{}
+	for key := range builders {
+		imageNames = append(imageNames, key)
+	}
+	pending, err := sortImages(imageNames, dependencies)
+	if err != nil {
+		return err
+	}
+
 	imagesToBuild := 0
-	for key, imageConf := range conf.Images {
-		ctx := ctx.WithLogger(ctx.Log().WithPrefix("build:" + key + " "))
-		if len(images) > 0 && !stringutil.Contains(images, key) {
-			continue
-		}
+	skippedImages := map[string]bool{}
+	for len(pending) > 0 {
+		nextPending := []string{}
+		for _, key := range pending {
+			// Check if all dependencies are built or skipped
+			ready := true
+			rebuiltDependency := ""
+			for _, dependency := range dependencies[key] {
+				if _, ok := builders[dependency]; !ok {
+					continue
+				} else if _, ok := builtImages[dependency]; ok {
+					rebuiltDependency = dependency
+				} else if !skippedImages[dependency] {
+					ready = false
+				}
+			}
 
-		// This is necessary for parallel build otherwise we would override the image conf pointer during the loop
-		cImageConf := *imageConf
-		imageName := cImageConf.Image
-		imageConfigName := key
-		imageCache, _ := ctx.Config().LocalCache().GetImageCache(imageConfigName)
-		resolvedImage := imageCache.ResolveImage()
-		imageTags := tags[imageConfigName]
-		builder := builders[imageConfigName]
+			// wait until the dependencies are done and we are below the MaxConcurrency
+			if !ready || (!options.Sequential && options.MaxConcurrentBuilds > 0 && imagesToBuild >= options.MaxConcurrentBuilds) {
+				nextPending = append(nextPending, key)
+				continue
+			}
 
-		// Execute before images build hook
-		pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
-			"IMAGE_CONFIG_NAME": imageConfigName,
-			"IMAGE_NAME":        resolvedImage,
-			"IMAGE_CONFIG":      cImageConf,
-			"IMAGE_TAGS":        imageTags,
-		}, hook.EventsForSingle("before:build", imageConfigName).With("build.beforeBuild")...)
-		if pluginErr != nil {
-			return pluginErr
-		}
+			ctx := ctx.WithLogger(ctx.Log().WithPrefix("build:" + key + " "))
+			imageConfigName := key
+			imageConf := imageConfs[imageConfigName]
 
-		// Check if rebuild is needed
-		needRebuild, err := builder.ShouldRebuild(ctx, options.ForceRebuild)
-		if err != nil {
-			pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
-				"IMAGE_CONFIG_NAME": imageConfigName,
-				"IMAGE_NAME":        resolvedImage,
-				"IMAGE_CONFIG":      cImageConf,
-				"IMAGE_TAGS":        imageTags,
-				"ERROR":             err,
-			}, hook.EventsForSingle("error:build", imageConfigName).With("build.errorBuild")...)
-			if pluginErr != nil {
-				return pluginErr
-			}
-			return errors.Errorf("error during shouldRebuild check: %v", err)
-		}
+			// This is necessary for parallel build otherwise we would override the image conf pointer during the loop
+			cImageConf := *imageConf
+			imageName := cImageConf.Image
+			imageCache, _ := ctx.Config().LocalCache().GetImageCache(imageConfigName)
+			resolvedImage := imageCache.ResolveImage()
+			imageTags := tags[imageConfigName]
+			builder := builders[imageConfigName]
 
-		if !options.ForceRebuild && !needRebuild {
 			// Execute before images build hook
 			pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 				"IMAGE_CONFIG_NAME": imageConfigName,
 				"IMAGE_NAME":        resolvedImage,
 				"IMAGE_CONFIG":      cImageConf,
 				"IMAGE_TAGS":        imageTags,
-			}, hook.EventsForSingle("skip:build", imageConfigName)...)
+			}, hook.EventsForSingle("before:build", imageConfigName).With("build.beforeBuild")...)
 			if pluginErr != nil {
 				return pluginErr
 			}
-			ctx.Log().Infof("Skip building image '%s'", imageConfigName)
-			continue
-		}
 
-		// Sequential or parallel build?
-		if options.Sequential {
-			// Build the image
-			err = builder.Build(ctx)
+			// Check if rebuild is needed
+			needRebuild, err := builder.ShouldRebuild(ctx, options.ForceRebuild)
 			if err != nil {
 				pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 					"IMAGE_CONFIG_NAME": imageConfigName,
@@ -227,73 +250,120 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 				if pluginErr != nil {
 					return pluginErr
 				}
-				return errors.Wrapf(err, "error building image %s:%s", resolvedImage, imageTags[0])
+				return errors.Errorf("error during shouldRebuild check: %v", err)
 			}
-
-			// Update cache
-			imageCache, _ := ctx.Config().LocalCache().GetImageCache(imageConfigName)
-			if imageCache.Tag == imageTags[0] {
-				ctx.Log().Warnf("Newly built image '%s' has the same tag as in the last build (%s), this can lead to problems that the image during deployment is not updated", resolvedImage, imageTags[0])
-			}
-
-			imageCache.ImageName = imageName
-			imageCache.Tag = imageTags[0]
-			ctx.Config().LocalCache().SetImageCache(imageConfigName, imageCache)
-
-			// Track built images
-			builtImages[imageConfigName] = types.ImageNameTag{
-				ImageConfigName: imageConfigName,
-				ImageName:       imageName,
-				ImageTag:        imageTags[0],
+			if !needRebuild && rebuiltDependency != "" {
+				ctx.Log().Infof("Rebuild image %s because image %s was rebuilt", imageConfigName, rebuiltDependency)
+				needRebuild = true
 			}
 
-			// Execute before images build hook
-			pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
-				"IMAGE_CONFIG_NAME": imageConfigName,
-				"IMAGE_NAME":        resolvedImage,
-				"IMAGE_CONFIG":      cImageConf,
-				"IMAGE_TAGS":        imageTags,
-			}, hook.EventsForSingle("after:build", imageConfigName).With("build.afterBuild")...)
-			if pluginErr != nil {
-				return pluginErr
-			}
-		} else {
-			// wait until we are below the MaxConcurrency
-			if options.MaxConcurrentBuilds > 0 && imagesToBuild >= options.MaxConcurrentBuilds {
-				err = c.waitForBuild(ctx, errChan, cacheChan, builtImages)
-				if err != nil {
-					return err
+			if !options.ForceRebuild && !needRebuild {
+				// Execute before images build hook
+				pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
+					"IMAGE_CONFIG_NAME": imageConfigName,
+					"IMAGE_NAME":        resolvedImage,
+					"IMAGE_CONFIG":      cImageConf,
+					"IMAGE_TAGS":        imageTags,
+				}, hook.EventsForSingle("skip:build", imageConfigName)...)
+				if pluginErr != nil {
+					return pluginErr
 				}
-
-				imagesToBuild--
+				ctx.Log().Infof("Skip building image '%s'", imageConfigName)
+				skippedImages[imageConfigName] = true
+				continue
 			}
 
-			imagesToBuild++
-			go func(ctx devspacecontext.Context) {
+			// Pass the images of the dependencies as build args
+			injectDependencyBuildArgs(ctx, imageConf, dependencies[imageConfigName])
+
+			// Sequential or parallel build?
+			if options.Sequential {
 				// Build the image
-				err := builder.Build(ctx)
+				err = builder.Build(ctx)
 				if err != nil {
-					hook.LogExecuteHooks(ctx, map[string]interface{}{
+					pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 						"IMAGE_CONFIG_NAME": imageConfigName,
 						"IMAGE_NAME":        resolvedImage,
 						"IMAGE_CONFIG":      cImageConf,
 						"IMAGE_TAGS":        imageTags,
 						"ERROR":             err,
 					}, hook.EventsForSingle("error:build", imageConfigName).With("build.errorBuild")...)
-					errChan <- errors.Errorf("error building image %s:%s: %v", resolvedImage, imageTags[0], err)
-					return
+					if pluginErr != nil {
+						return pluginErr
+					}
+					return errors.Wrapf(err, "error building image %s:%s", resolvedImage, imageTags[0])
 				}
 
-				// Send the response
-				cacheChan <- imageNameAndTag{
-					imageConfigName: imageConfigName,
-					imageName:       imageName,
-					imageTag:        imageTags[0],
-					imageTags:       imageTags,
-					imageConfig:     cImageConf,
+				// Update cache
+				imageCache, _ := ctx.Config().LocalCache().GetImageCache(imageConfigName)
+				if imageCache.Tag == imageTags[0] {
+					ctx.Log().Warnf("Newly built image '%s' has the same tag as in the last build (%s), this can lead to problems that the image during deployment is not updated", resolvedImage, imageTags[0])
 				}
-			}(ctx)
+
+				imageCache.ImageName = imageName
+				imageCache.Tag = imageTags[0]
+				ctx.Config().LocalCache().SetImageCache(imageConfigName, imageCache)
+
+				// Track built images
+				builtImages[imageConfigName] = types.ImageNameTag{
+					ImageConfigName: imageConfigName,
+					ImageName:       imageName,
+					ImageTag:        imageTags[0],
+				}
+
+				// Execute before images build hook
+				pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
+					"IMAGE_CONFIG_NAME": imageConfigName,
+					"IMAGE_NAME":        resolvedImage,
+					"IMAGE_CONFIG":      cImageConf,
+					"IMAGE_TAGS":        imageTags,
+				}, hook.EventsForSingle("after:build", imageConfigName).With("build.afterBuild")...)
+				if pluginErr != nil {
+					return pluginErr
+				}
+			} else {
+				imagesToBuild++
+				go func(ctx devspacecontext.Context) {
+					// Build the image
+					err := builder.Build(ctx)
+					if err != nil {
+						hook.LogExecuteHooks(ctx, map[string]interface{}{
+							"IMAGE_CONFIG_NAME": imageConfigName,
+							"IMAGE_NAME":        resolvedImage,
+							"IMAGE_CONFIG":      cImageConf,
+							"IMAGE_TAGS":        imageTags,
+							"ERROR":             err,
+						}, hook.EventsForSingle("error:build", imageConfigName).With("build.errorBuild")...)
+						errChan <- errors.Errorf("error building image %s:%s: %v", resolvedImage, imageTags[0], err)
+						return
+					}
+
+					// Send the response
+					cacheChan <- imageNameAndTag{
+						imageConfigName: imageConfigName,
+						imageName:       imageName,
+						imageTag:        imageTags[0],
+						imageTags:       imageTags,
+						imageConfig:     cImageConf,
+					}
+				}(ctx)
+			}
 		}
+
+		// wait for a running build before we check the pending images again
+		if len(nextPending) > 0 {
+			if imagesToBuild == 0 {
+				return errors.Errorf("couldn't build images %s, because their dependencies are not built", strings.Join(nextPending, ", "))
+			}
+
+			err := c.waitForBuild(ctx, errChan, cacheChan, builtImages)
+			if err != nil {
+				return err
+			}
+
+			imagesToBuild--
+		}
+		pending = nextPending
 	}
 
 	// wait for the builds to finish
@@ -308,6 +378,20 @@ func (c *controller) Build(ctx devspacecontext.Context, images []string, options
 		}
 	}
 
+	// garbage collect the local registries images were pushed to
+	if len(builtImages) > 0 {
+		for _, localRegistry := range localregistry.GetStartedLocalRegistries() {
+			if !localRegistry.GCAfterBuild {
+				continue
+			}
+
+			err := localRegistry.GarbageCollect(ctx)
+			if err != nil {
+				ctx.Log().Warnf("Error garbage collecting local registry: %v", err)
+			}
+		}
+	}
+
 	// Execute after images build hook
 	pluginErr = hook.ExecuteHooks(ctx, map[string]interface{}{}, "after:build")
 	if pluginErr != nil {
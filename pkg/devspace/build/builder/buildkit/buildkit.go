@@ -121,10 +121,27 @@ func (b *Builder) BuildImage(ctx devspacecontext.Context, contextPath, dockerfil
 
 	// Should we build with cli?
 	skipPush := b.skipPush || b.helper.ImageConf.SkipPush
-	return buildWithCLI(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), body, writer, ctx.KubeClient(), builder, buildKitConfig, *buildOptions, useMinikubeDocker, skipPush, ctx.Log())
+	secretArgs, secretEnv := helper.SecretArgs(ctx, b.helper.ImageConf)
+	additionalArgs := append(cacheArgs(ctx, b.helper.ImageConf), secretArgs...)
+	environ := env.NewVariableEnvProvider(ctx.Environ(), secretEnv)
+	return buildWithCLI(ctx.Context(), ctx.WorkingDir(), environ, body, writer, ctx.KubeClient(), builder, buildKitConfig, *buildOptions, additionalArgs, useMinikubeDocker, skipPush, ctx.Log())
 }
 
-func buildWithCLI(ctx context.Context, dir string, environ expand.Environ, context io.Reader, writer io.Writer, kubeClient kubectl.Client, builder string, imageConf *latest.BuildKitConfig, options types.ImageBuildOptions, useMinikubeDocker, skipPush bool, log logpkg.Logger) error {
+// cacheArgs returns the --cache-from and --cache-to flags for the configured build cache
+func cacheArgs(ctx devspacecontext.Context, imageConf *latest.Image) []string {
+	args := []string{}
+	cacheFrom, cacheTo := helper.ResolveCache(ctx, imageConf)
+	for _, location := range cacheFrom {
+		args = append(args, "--cache-from", helper.BuildKitCacheArg(location, false))
+	}
+	if cacheTo != nil {
+		args = append(args, "--cache-to", helper.BuildKitCacheArg(cacheTo, true))
+	}
+
+	return args
+}
+
+func buildWithCLI(ctx context.Context, dir string, environ expand.Environ, context io.Reader, writer io.Writer, kubeClient kubectl.Client, builder string, imageConf *latest.BuildKitConfig, options types.ImageBuildOptions, additionalArgs []string, useMinikubeDocker, skipPush bool, log logpkg.Logger) error {
 	command := []string{"docker", "buildx"}
 	if len(imageConf.Command) > 0 {
 		command = imageConf.Command
@@ -161,6 +178,9 @@ func buildWithCLI(ctx context.Context, dir string, environ expand.Environ, conte
 	if options.Target != "" {
 		args = append(args, "--target", options.Target)
 	}
+	if options.Platform != "" {
+		args = append(args, "--platform", options.Platform)
+	}
 	if builder != "" {
 		tempFile, err := tempKubeContextFromClient(kubeClient)
 		if err != nil {
@@ -177,6 +197,7 @@ func buildWithCLI(ctx context.Context, dir string, environ expand.Environ, conte
 		// is created in parallel.
 		time.Sleep(time.Millisecond * time.Duration(rand.Intn(3000)+500))
 	}
+	args = append(args, additionalArgs...)
 	args = append(args, imageConf.Args...)
 
 	args = append(args, "-")
@@ -5,16 +5,20 @@ import (
 	"encoding/base64"
 	"encoding/json"
 	"io"
+	"strings"
 
 	"github.com/docker/cli/cli/streams"
 	"github.com/docker/distribution/reference"
+	"github.com/docker/docker/api/types"
 	"github.com/docker/docker/api/types/image"
 	dockerregistry "github.com/docker/docker/api/types/registry"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/buildkit"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	dockerclient "github.com/loft-sh/devspace/pkg/devspace/docker"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/env"
 	"github.com/loft-sh/devspace/pkg/devspace/pullsecrets"
 	command2 "github.com/loft-sh/utils/pkg/command"
 
@@ -80,6 +84,18 @@ func (b *Builder) BuildImage(ctx devspacecontext.Context, contextPath, dockerfil
 		displayRegistryURL = "hub.docker.com"
 	)
 
+	// the docker daemon can only build a single platform at once, so we let buildx
+	// build all platforms and push the manifest list instead
+	if len(b.helper.Platforms) > 1 {
+		ctx.Log().Infof("Build image %s for platforms %s with BuildKit, because the docker daemon only builds a single platform", b.helper.ImageName, strings.Join(b.helper.Platforms, ","))
+		buildKitBuilder, err := buildkit.NewBuilder(ctx, buildKitImageConf(b.helper.ImageConf), b.helper.ImageTags, b.skipPush, b.skipPushOnLocalKubernetes)
+		if err != nil {
+			return errors.Wrap(err, "create buildkit builder")
+		}
+
+		return buildKitBuilder.BuildImage(ctx, contextPath, dockerfilePath, entrypoint, cmd)
+	}
+
 	// Display nice registry name
 	registryURL, err := pullsecrets.GetRegistryFromImageName(b.helper.ImageName)
 	if err != nil {
@@ -120,6 +136,9 @@ func (b *Builder) BuildImage(ctx devspacecontext.Context, contextPath, dockerfil
 		return err
 	}
 
+	// Use the configured build cache
+	b.applyCache(ctx, buildOptions)
+
 	// Should we build with cli?
 	useBuildKit := false
 	useDockerCli := b.helper.ImageConf.Docker != nil && b.helper.ImageConf.Docker.UseCLI
@@ -131,7 +150,14 @@ func (b *Builder) BuildImage(ctx devspacecontext.Context, contextPath, dockerfil
 		}
 	}
 	if useDockerCli || useBuildKit || len(cliArgs) > 0 {
-		err = b.client.ImageBuildCLI(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), useBuildKit, body, writer, cliArgs, *buildOptions, ctx.Log())
+		// secrets and ssh mounts are only supported by buildkit
+		secretArgs, secretEnv := helper.SecretArgs(ctx, b.helper.ImageConf)
+		if len(secretArgs) > 0 {
+			useBuildKit = true
+		}
+
+		environ := env.NewVariableEnvProvider(ctx.Environ(), secretEnv)
+		err = b.client.ImageBuildCLI(ctx.Context(), ctx.WorkingDir(), environ, useBuildKit, body, writer, append(secretArgs, cliArgs...), *buildOptions, ctx.Log())
 		if err != nil {
 			return err
 		}
@@ -139,6 +165,13 @@ func (b *Builder) BuildImage(ctx devspacecontext.Context, contextPath, dockerfil
 		// make sure to use the correct proxy configuration
 		buildOptions.BuildArgs = b.client.ParseProxyConfig(buildOptions.BuildArgs)
 
+		// provide the secrets through a build session
+		closeSession, err := helper.StartSecretSession(ctx, b.client, b.helper.ImageConf, buildOptions)
+		if err != nil {
+			return err
+		}
+		defer closeSession()
+
 		response, err := b.client.ImageBuild(ctx.Context(), body, *buildOptions)
 		if err != nil {
 			return err
@@ -180,6 +213,34 @@ func (b *Builder) BuildImage(ctx devspacecontext.Context, contextPath, dockerfil
 	return nil
 }
 
+// applyCache adds the configured build cache to the build options. The docker builder can only
+// import caches from images and export inline caches.
+func (b *Builder) applyCache(ctx devspacecontext.Context, buildOptions *types.ImageBuildOptions) {
+	cacheFrom, cacheTo := helper.ResolveCache(ctx, b.helper.ImageConf)
+	for _, location := range cacheFrom {
+		if location.Type == latest.BuildCacheTypeLocal {
+			ctx.Log().Warnf("Skip importing local cache %s, because the docker builder doesn't support local caches. Please use buildKit instead", location.Path)
+			continue
+		}
+
+		buildOptions.CacheFrom = append(buildOptions.CacheFrom, location.Ref)
+	}
+	if cacheTo != nil {
+		if cacheTo.Type != latest.BuildCacheTypeInline {
+			ctx.Log().Warnf("Skip exporting %s cache, because the docker builder only supports inline caches. Please use buildKit instead", cacheTo.Type)
+			return
+		}
+
+		buildArgs := map[string]*string{}
+		for k, v := range buildOptions.BuildArgs {
+			buildArgs[k] = v
+		}
+		inlineCache := "1"
+		buildArgs["BUILDKIT_INLINE_CACHE"] = &inlineCache
+		buildOptions.BuildArgs = buildArgs
+	}
+}
+
 // Authenticate authenticates the client with a remote registry
 func (b *Builder) Authenticate(ctx context.Context) (*dockerregistry.AuthConfig, error) {
 	registryURL, err := pullsecrets.GetRegistryFromImageName(b.helper.ImageName + ":" + b.helper.ImageTags[0])
@@ -231,3 +292,16 @@ func encodeAuthToBase64(authConfig dockerregistry.AuthConfig) (string, error) {
 
 	return base64.URLEncoding.EncodeToString(buf), nil
 }
+
+// buildKitImageConf returns a copy of the image config that builds the image with
+// docker buildx and the docker options that apply to it
+func buildKitImageConf(imageConf *latest.Image) *latest.Image {
+	buildKitConf := *imageConf
+	buildKitConf.BuildKit = &latest.BuildKitConfig{}
+	if imageConf.Docker != nil {
+		buildKitConf.BuildKit.PreferMinikube = imageConf.Docker.PreferMinikube
+		buildKitConf.BuildKit.Args = imageConf.Docker.Args
+	}
+
+	return &buildKitConf
+}
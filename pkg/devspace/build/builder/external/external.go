@@ -0,0 +1,252 @@
+package external
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/plugin"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/utils/pkg/command"
+	dockerterm "github.com/moby/term"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	_, stdout, stderr = dockerterm.StdStreams()
+)
+
+type pluginBuilder struct {
+	binary   string
+	baseArgs []string
+}
+
+var (
+	pluginBuilders      = map[string]pluginBuilder{}
+	pluginBuildersMutex sync.Mutex
+)
+
+// AddPluginBuilders registers the builders of the given plugins, so that they can be
+// referenced via images.*.external.builder
+func AddPluginBuilders(plugins []plugin.Metadata) {
+	pluginBuildersMutex.Lock()
+	defer pluginBuildersMutex.Unlock()
+
+	for _, p := range plugins {
+		for _, b := range p.Builders {
+			pluginBuilders[b.Name] = pluginBuilder{
+				binary:   filepath.Join(p.PluginFolder, plugin.PluginBinary),
+				baseArgs: b.BaseArgs,
+			}
+		}
+	}
+}
+
+// Builder holds all the relevant information for an external build
+type Builder struct {
+	imageConf *latest.Image
+	imageTags []string
+	skipPush  bool
+}
+
+// NewBuilder creates a new external builder
+func NewBuilder(imageConf *latest.Image, imageTags []string, skipPush bool) *Builder {
+	return &Builder{
+		imageConf: imageConf,
+		imageTags: imageTags,
+		skipPush:  skipPush,
+	}
+}
+
+// ShouldRebuild implements interface
+func (b *Builder) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bool) (bool, error) {
+	// make sure a remote context is cloned or extracted before the builder is called
+	_, err := helper.PrepareContext(ctx, b.imageConf)
+	if err != nil {
+		return false, errors.Wrapf(err, "prepare context of image %s", b.imageConf.Name)
+	}
+
+	// Hash image config
+	configStr, err := yaml.Marshal(*b.imageConf)
+	if err != nil {
+		return false, errors.Wrap(err, "marshal image config")
+	}
+	imageConfigHash := hash.String(string(configStr))
+
+	imageCache, _ := ctx.Config().LocalCache().GetImageCache(b.imageConf.Name)
+
+	// rebuild if the config has changed, otherwise ask the builder
+	mustRebuild := forceRebuild || b.imageConf.RebuildStrategy == latest.RebuildStrategyAlways || imageCache.Tag == "" || imageCache.ImageConfigHash != imageConfigHash
+	if !mustRebuild {
+		result, err := b.run(ctx, b.newRequest(ctx, ActionShouldRebuild, forceRebuild, imageCache.BuilderState))
+		if err != nil {
+			return false, err
+		}
+
+		mustRebuild = result.Rebuild
+		if mustRebuild && result.Reason != "" {
+			ctx.Log().Infof("Rebuild image %s because %s", b.imageConf.Image, result.Reason)
+		}
+	}
+
+	imageCache.ImageConfigHash = imageConfigHash
+	ctx.Config().LocalCache().SetImageCache(b.imageConf.Name, imageCache)
+	return mustRebuild, nil
+}
+
+// Build implements interface
+func (b *Builder) Build(ctx devspacecontext.Context) error {
+	if len(b.imageTags) == 0 {
+		return fmt.Errorf("no tags specified for image %s", b.imageConf.Image)
+	}
+
+	key := fmt.Sprintf("images.%s", b.imageConf.Name)
+	ctx.Config().SetRuntimeVariable(key, b.imageConf.Image+":"+b.imageTags[0])
+	ctx.Config().SetRuntimeVariable(key+".image", b.imageConf.Image)
+	ctx.Config().SetRuntimeVariable(key+".tag", b.imageTags[0])
+
+	imageCache, _ := ctx.Config().LocalCache().GetImageCache(b.imageConf.Name)
+	ctx.Log().Infof("Build %s:%s with external builder %s", b.imageConf.Image, b.imageTags[0], b.builderName())
+	result, err := b.run(ctx, b.newRequest(ctx, ActionBuild, false, imageCache.BuilderState))
+	if err != nil {
+		return errors.Errorf("error building image: %v", err)
+	}
+	if result.Digest != "" {
+		ctx.Config().SetRuntimeVariable(key+".digest", result.Digest)
+	}
+	for _, image := range result.Images {
+		ctx.Log().Debugf("External builder %s built %s", b.builderName(), image)
+	}
+
+	// save the builder state for the next run
+	imageCache, _ = ctx.Config().LocalCache().GetImageCache(b.imageConf.Name)
+	imageCache.BuilderState = ""
+	if len(result.State) > 0 {
+		state, err := json.Marshal(result.State)
+		if err != nil {
+			return errors.Wrap(err, "marshal builder state")
+		}
+
+		imageCache.BuilderState = string(state)
+	}
+	ctx.Config().LocalCache().SetImageCache(b.imageConf.Name, imageCache)
+
+	ctx.Log().Done("Done processing image '" + b.imageConf.Image + "'")
+	return nil
+}
+
+func (b *Builder) builderName() string {
+	if b.imageConf.External.Builder != "" {
+		return b.imageConf.External.Builder
+	}
+
+	return b.imageConf.External.Command
+}
+
+func (b *Builder) newRequest(ctx devspacecontext.Context, action string, forceRebuild bool, builderState string) *Request {
+	dockerfilePath, contextPath := helper.GetDockerfileAndContext(ctx, b.imageConf)
+	request := &Request{
+		Version:        ProtocolVersion,
+		Action:         action,
+		Name:           b.imageConf.Name,
+		Image:          b.imageConf,
+		Tags:           b.imageTags,
+		Options:        b.imageConf.External.Options,
+		ContextPath:    contextPath,
+		DockerfilePath: dockerfilePath,
+		WorkingDir:     ctx.WorkingDir(),
+		SkipPush:       b.skipPush,
+		ForceRebuild:   forceRebuild,
+	}
+	if ctx.KubeClient() != nil {
+		request.KubeContext = ctx.KubeClient().CurrentContext()
+		request.Namespace = ctx.KubeClient().Namespace()
+	}
+	if builderState != "" {
+		err := json.Unmarshal([]byte(builderState), &request.State)
+		if err != nil {
+			ctx.Log().Debugf("Error parsing cached state of external builder %s: %v", b.builderName(), err)
+		}
+	}
+
+	return request
+}
+
+func (b *Builder) resolveCommand(ctx devspacecontext.Context) (string, []string, error) {
+	if b.imageConf.External.Builder != "" {
+		pluginBuildersMutex.Lock()
+		defer pluginBuildersMutex.Unlock()
+
+		pb, ok := pluginBuilders[b.imageConf.External.Builder]
+		if !ok {
+			return "", nil, fmt.Errorf("couldn't find external builder %s. Please make sure the plugin that provides the builder is installed (run 'devspace list plugins' to see all installed plugins)", b.imageConf.External.Builder)
+		}
+
+		return pb.binary, pb.baseArgs, nil
+	}
+
+	commandPath, err := runtime.NewRuntimeResolver(ctx.WorkingDir(), false).FillRuntimeVariablesAsString(ctx.Context(), b.imageConf.External.Command, ctx.Config(), ctx.Dependencies())
+	if err != nil {
+		return "", nil, err
+	}
+	if strings.ContainsAny(commandPath, `/\`) {
+		commandPath = ctx.ResolvePath(commandPath)
+	}
+
+	return commandPath, nil, nil
+}
+
+// run executes the builder with the given request and returns its result
+func (b *Builder) run(ctx devspacecontext.Context, request *Request) (*Result, error) {
+	commandPath, args, err := b.resolveCommand(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	payload, err := json.Marshal(request)
+	if err != nil {
+		return nil, errors.Wrap(err, "marshal builder request")
+	}
+
+	// Determine output writer for stderr
+	var writer io.WriteCloser
+	if ctx.Log() == logpkg.GetInstance() {
+		writer = logpkg.WithNopCloser(stderr)
+	} else {
+		writer = ctx.Log().Writer(logrus.InfoLevel, false)
+	}
+	defer writer.Close()
+
+	// parse the messages of the builder while it is running
+	reader, pipeWriter := io.Pipe()
+	resultChan := make(chan *Result, 1)
+	go func() {
+		resultChan <- readMessages(reader, ctx.Log())
+	}()
+
+	args = append(append([]string{}, args...), request.Action)
+	ctx.Log().Debugf("Run external builder '%s %s' in working dir %s", commandPath, strings.Join(args, " "), ctx.WorkingDir())
+	err = command.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), pipeWriter, writer, bytes.NewReader(payload), commandPath, args...)
+	_ = pipeWriter.Close()
+	result := <-resultChan
+	if result != nil && result.Error != "" {
+		return nil, fmt.Errorf("external builder %s: %s", b.builderName(), result.Error)
+	} else if err != nil {
+		return nil, errors.Wrapf(err, "run external builder %s", b.builderName())
+	} else if result == nil {
+		return nil, fmt.Errorf("external builder %s didn't return a result for %s", b.builderName(), request.Action)
+	}
+
+	return result, nil
+}
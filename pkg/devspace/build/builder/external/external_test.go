@@ -0,0 +1,104 @@
+package external
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestReadMessages(t *testing.T) {
+	output := strings.Join([]string{
+		`{"type":"log","level":"info","message":"building"}`,
+		`plain output`,
+		``,
+		`{"type":"result","result":{"rebuild":true,"reason":"sources changed"}}`,
+		`{"type":"result","result":{"digest":"sha256:123","state":{"hash":"abc"}}}`,
+	}, "\n")
+
+	result := readMessages(strings.NewReader(output), log.Discard)
+	assert.Assert(t, result != nil)
+	assert.Equal(t, result.Digest, "sha256:123")
+	assert.Equal(t, result.State["hash"], "abc")
+	assert.Equal(t, result.Rebuild, false)
+
+	result = readMessages(strings.NewReader("no json\n"), log.Discard)
+	assert.Assert(t, result == nil)
+}
+
+const fakeBuilder = `#!/bin/sh
+input=$(cat)
+case "$1" in
+  should-rebuild)
+    case "$input" in
+      *'"hash":"abc"'*) echo '{"type":"result","result":{"rebuild":false}}' ;;
+      *) echo '{"type":"result","result":{"rebuild":true,"reason":"no state"}}' ;;
+    esac
+    ;;
+  build)
+    echo '{"type":"log","level":"info","message":"building"}'
+    echo '{"type":"result","result":{"digest":"sha256:123","state":{"hash":"abc"}}}'
+    ;;
+  *)
+    echo '{"type":"result","result":{"error":"unknown action"}}'
+    exit 1
+    ;;
+esac
+`
+
+func TestBuilder(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake builder is a shell script")
+	}
+
+	dir := t.TempDir()
+	builderPath := filepath.Join(dir, "builder.sh")
+	err := os.WriteFile(builderPath, []byte(fakeBuilder), 0755)
+	assert.NilError(t, err)
+
+	imageConf := &latest.Image{
+		Name:  "test",
+		Image: "test/test",
+		External: &latest.ExternalConfig{
+			Command: builderPath,
+		},
+	}
+	cache := &localcache.LocalCache{
+		Images: map[string]localcache.ImageCache{
+			"test": {Tag: "old"},
+		},
+	}
+	conf := config.NewConfig(nil, nil, latest.NewRaw(), cache, &remotecache.RemoteCache{}, nil, "")
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard).WithConfig(conf).WithWorkingDir(dir)
+	builder := NewBuilder(imageConf, []string{"new"}, true)
+
+	// config hash changed
+	rebuild, err := builder.ShouldRebuild(ctx, false)
+	assert.NilError(t, err)
+	assert.Equal(t, rebuild, true)
+
+	// builder has no state yet
+	rebuild, err = builder.ShouldRebuild(ctx, false)
+	assert.NilError(t, err)
+	assert.Equal(t, rebuild, true)
+
+	err = builder.Build(ctx)
+	assert.NilError(t, err)
+	digest, _ := conf.GetRuntimeVariable("images.test.digest")
+	assert.Equal(t, digest, "sha256:123")
+
+	// builder state is up to date
+	rebuild, err = builder.ShouldRebuild(ctx, false)
+	assert.NilError(t, err)
+	assert.Equal(t, rebuild, false)
+}
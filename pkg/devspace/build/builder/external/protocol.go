@@ -0,0 +1,148 @@
+package external
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+)
+
+// ProtocolVersion is the version of the external builder protocol DevSpace speaks
+const ProtocolVersion = "v1"
+
+// The actions an external builder has to implement. DevSpace appends the action as last
+// argument when calling the builder.
+const (
+	// ActionShouldRebuild asks the builder if the image needs to be rebuilt
+	ActionShouldRebuild = "should-rebuild"
+	// ActionBuild tells the builder to build (and push) the image
+	ActionBuild = "build"
+)
+
+// The message types a builder can write to stdout, one json object per line
+const (
+	MessageTypeLog    = "log"
+	MessageTypeResult = "result"
+)
+
+// Request is written as json to the stdin of the external builder
+type Request struct {
+	// Version is the protocol version
+	Version string `json:"version"`
+	// Action is either should-rebuild or build
+	Action string `json:"action"`
+
+	// Name is the name of the image in the images section
+	Name string `json:"name"`
+	// Image is the resolved image configuration
+	Image *latest.Image `json:"image"`
+	// Tags are the tags that should get built
+	Tags []string `json:"tags,omitempty"`
+	// Options are the builder specific options from images.*.external.options
+	Options map[string]interface{} `json:"options,omitempty"`
+
+	// ContextPath is the absolute path to the build context
+	ContextPath string `json:"contextPath"`
+	// DockerfilePath is the absolute path to the dockerfile
+	DockerfilePath string `json:"dockerfilePath"`
+	// WorkingDir is the directory of the devspace.yaml
+	WorkingDir string `json:"workingDir"`
+
+	// KubeContext and Namespace are the currently used kube context and namespace
+	KubeContext string `json:"kubeContext,omitempty"`
+	Namespace   string `json:"namespace,omitempty"`
+
+	// SkipPush is true if the image should not be pushed after building
+	SkipPush bool `json:"skipPush,omitempty"`
+	// ForceRebuild is true if the user forces a rebuild
+	ForceRebuild bool `json:"forceRebuild,omitempty"`
+
+	// State is the state the builder returned after the last successful build
+	State map[string]string `json:"state,omitempty"`
+}
+
+// Message is a single line the external builder writes to stdout
+type Message struct {
+	// Type is either log or result
+	Type string `json:"type"`
+
+	// Level is the log level of a log message, e.g. debug, info, warn, error or done
+	Level string `json:"level,omitempty"`
+	// Message is the log message
+	Message string `json:"message,omitempty"`
+
+	// Result is the result of the requested action
+	Result *Result `json:"result,omitempty"`
+}
+
+// Result is the outcome of an action
+type Result struct {
+	// Rebuild is returned for should-rebuild and tells DevSpace if the image needs to be rebuilt
+	Rebuild bool `json:"rebuild,omitempty"`
+	// Reason is an optional human readable reason why the image needs to be rebuilt
+	Reason string `json:"reason,omitempty"`
+
+	// Digest is the digest of the built image
+	Digest string `json:"digest,omitempty"`
+	// Images are the fully qualified image references that were built
+	Images []string `json:"images,omitempty"`
+	// State is saved in the local cache and passed to the builder on the next request
+	State map[string]string `json:"state,omitempty"`
+
+	// Error is set if the action failed
+	Error string `json:"error,omitempty"`
+}
+
+// readMessages parses the messages written by the builder, prints all log messages and returns
+// the last result. Lines that are not valid json are printed as they are.
+func readMessages(reader io.Reader, log logpkg.Logger) *Result {
+	var result *Result
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		message := &Message{}
+		err := json.Unmarshal([]byte(line), message)
+		if err != nil || message.Type == "" {
+			log.Info(line)
+			continue
+		}
+
+		switch message.Type {
+		case MessageTypeLog:
+			printMessage(log, message)
+		case MessageTypeResult:
+			if message.Result != nil {
+				result = message.Result
+			}
+		default:
+			log.Debugf("Unknown message type %s from external builder", message.Type)
+		}
+	}
+
+	// make sure the builder is never blocked on writing
+	_, _ = io.Copy(io.Discard, reader)
+	return result
+}
+
+func printMessage(log logpkg.Logger, message *Message) {
+	switch strings.ToLower(message.Level) {
+	case "debug":
+		log.Debug(message.Message)
+	case "warn", "warning":
+		log.Warn(message.Message)
+	case "error":
+		log.Error(message.Message)
+	case "done":
+		log.Done(message.Message)
+	default:
+		log.Info(message.Message)
+	}
+}
@@ -0,0 +1,125 @@
+package helper
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/docker/distribution/reference"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+)
+
+// DefaultCacheTag is the tag of the registry cache if no ref is specified
+const DefaultCacheTag = "buildcache"
+
+// ResolveCache returns the caches to import layers from and the cache to export layers to
+// with all defaults applied. Returns nil if no cache is configured.
+func ResolveCache(ctx devspacecontext.Context, imageConf *latest.Image) ([]*latest.BuildCacheLocation, *latest.BuildCacheLocation) {
+	if imageConf.Cache == nil {
+		return nil, nil
+	}
+
+	to := imageConf.Cache.To
+	from := imageConf.Cache.From
+	if to == nil && len(from) == 0 {
+		to = &latest.BuildCacheLocation{}
+	}
+	if to != nil {
+		to = resolveCacheLocation(ctx, imageConf, to)
+		if len(from) == 0 {
+			from = []*latest.BuildCacheLocation{to}
+		}
+	}
+
+	retFrom := []*latest.BuildCacheLocation{}
+	for _, location := range from {
+		retFrom = append(retFrom, resolveCacheLocation(ctx, imageConf, location))
+	}
+
+	return retFrom, to
+}
+
+func resolveCacheLocation(ctx devspacecontext.Context, imageConf *latest.Image, location *latest.BuildCacheLocation) *latest.BuildCacheLocation {
+	resolved := *location
+	if resolved.Type == "" {
+		resolved.Type = latest.BuildCacheTypeRegistry
+	}
+
+	switch resolved.Type {
+	case latest.BuildCacheTypeRegistry:
+		if resolved.Ref == "" {
+			resolved.Ref = imageConf.Image + ":" + DefaultCacheTag
+		}
+		if resolved.Mode == "" {
+			resolved.Mode = "max"
+		}
+	case latest.BuildCacheTypeLocal:
+		resolved.Path = ctx.ResolvePath(resolved.Path)
+		if resolved.Mode == "" {
+			resolved.Mode = "max"
+		}
+	case latest.BuildCacheTypeInline:
+		if resolved.Ref == "" {
+			resolved.Ref = imageConf.Image
+		}
+	}
+
+	return &resolved
+}
+
+// BuildKitCacheAttrs returns the buildkit cache type and attributes of a cache location
+func BuildKitCacheAttrs(location *latest.BuildCacheLocation, export bool) (string, map[string]string) {
+	attrs := map[string]string{}
+	switch location.Type {
+	case latest.BuildCacheTypeLocal:
+		if export {
+			attrs["dest"] = location.Path
+			attrs["mode"] = location.Mode
+		} else {
+			attrs["src"] = location.Path
+		}
+	case latest.BuildCacheTypeInline:
+		if !export {
+			// inline caches are imported from the image itself
+			attrs["ref"] = location.Ref
+			return string(latest.BuildCacheTypeRegistry), attrs
+		}
+	default:
+		attrs["ref"] = location.Ref
+		if export {
+			attrs["mode"] = location.Mode
+		}
+	}
+
+	return string(location.Type), attrs
+}
+
+// BuildKitCacheArg returns the value of a --cache-from or --cache-to flag, e.g. type=registry,ref=my-image:buildcache
+func BuildKitCacheArg(location *latest.BuildCacheLocation, export bool) string {
+	cacheType, attrs := BuildKitCacheAttrs(location, export)
+	keys := []string{}
+	for k, v := range attrs {
+		if v != "" {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	parts := []string{"type=" + cacheType}
+	for _, k := range keys {
+		parts = append(parts, k+"="+attrs[k])
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// CacheRepository returns the repository without tag of a registry or inline cache, which is
+// used for builders that only support caching in a repository, e.g. kaniko
+func CacheRepository(location *latest.BuildCacheLocation) (string, error) {
+	ref, err := reference.ParseNormalizedNamed(location.Ref)
+	if err != nil {
+		return "", err
+	}
+
+	return ref.Name(), nil
+}
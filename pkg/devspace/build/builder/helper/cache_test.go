@@ -0,0 +1,59 @@
+package helper
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestResolveCache(t *testing.T) {
+	ctx := devspacecontext.NewContext(context.TODO(), nil, log.Discard).WithWorkingDir("/project")
+
+	from, to := ResolveCache(ctx, &latest.Image{Image: "myregistry.com/backend"})
+	assert.Assert(t, to == nil)
+	assert.Equal(t, len(from), 0)
+
+	// an empty cache config uses a registry cache next to the image
+	from, to = ResolveCache(ctx, &latest.Image{Image: "myregistry.com/backend", Cache: &latest.BuildCacheConfig{}})
+	expected := &latest.BuildCacheLocation{Type: latest.BuildCacheTypeRegistry, Ref: "myregistry.com/backend:buildcache", Mode: "max"}
+	assert.DeepEqual(t, to, expected)
+	assert.DeepEqual(t, from, []*latest.BuildCacheLocation{expected})
+
+	from, to = ResolveCache(ctx, &latest.Image{
+		Image: "myregistry.com/backend",
+		Cache: &latest.BuildCacheConfig{
+			From: []*latest.BuildCacheLocation{
+				{Type: latest.BuildCacheTypeInline},
+				{Type: latest.BuildCacheTypeLocal, Path: ".cache"},
+			},
+		},
+	})
+	assert.Assert(t, to == nil)
+	assert.DeepEqual(t, from, []*latest.BuildCacheLocation{
+		{Type: latest.BuildCacheTypeInline, Ref: "myregistry.com/backend"},
+		{Type: latest.BuildCacheTypeLocal, Path: filepath.Join("/project", ".cache"), Mode: "max"},
+	})
+}
+
+func TestBuildKitCacheArg(t *testing.T) {
+	registry := &latest.BuildCacheLocation{Type: latest.BuildCacheTypeRegistry, Ref: "backend:buildcache", Mode: "min"}
+	assert.Equal(t, BuildKitCacheArg(registry, false), "type=registry,ref=backend:buildcache")
+	assert.Equal(t, BuildKitCacheArg(registry, true), "type=registry,mode=min,ref=backend:buildcache")
+
+	local := &latest.BuildCacheLocation{Type: latest.BuildCacheTypeLocal, Path: "/tmp/cache", Mode: "max"}
+	assert.Equal(t, BuildKitCacheArg(local, false), "type=local,src=/tmp/cache")
+	assert.Equal(t, BuildKitCacheArg(local, true), "type=local,dest=/tmp/cache,mode=max")
+
+	inline := &latest.BuildCacheLocation{Type: latest.BuildCacheTypeInline, Ref: "backend"}
+	assert.Equal(t, BuildKitCacheArg(inline, false), "type=registry,ref=backend")
+	assert.Equal(t, BuildKitCacheArg(inline, true), "type=inline")
+
+	repository, err := CacheRepository(registry)
+	assert.NilError(t, err)
+	assert.Equal(t, repository, "docker.io/library/backend")
+}
@@ -0,0 +1,54 @@
+package helper
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+)
+
+type contextHashesKey struct{}
+
+type contextHashes struct {
+	m sync.Mutex
+
+	hashes map[string]string
+}
+
+// WithSharedContextHashes returns a context in which images that use the same build context
+// with the same excludes only hash the context once
+func WithSharedContextHashes(ctx context.Context) context.Context {
+	return context.WithValue(ctx, contextHashesKey{}, &contextHashes{
+		hashes: map[string]string{},
+	})
+}
+
+// HashContext hashes the build context directory without the excluded files. Only files that have changed
+// since the last hash are hashed again, the hashes of all other files are taken from the local cache.
+func HashContext(ctx devspacecontext.Context, contextDir string, excludes []string) (string, error) {
+	key := hash.String(contextDir + ";" + strings.Join(excludes, ";"))
+
+	// check if the context was hashed already by another image
+	shared, _ := ctx.Context().Value(contextHashesKey{}).(*contextHashes)
+	if shared != nil {
+		shared.m.Lock()
+		defer shared.m.Unlock()
+
+		if contextHash, ok := shared.hashes[key]; ok {
+			return contextHash, nil
+		}
+	}
+
+	contextHash, files, err := hash.DirectoryExcludesIncremental(contextDir, excludes, ctx.Config().LocalCache().GetContextFiles(key))
+	if err != nil {
+		return "", err
+	}
+
+	ctx.Config().LocalCache().SetContextFiles(key, files)
+	if shared != nil {
+		shared.hashes[key] = contextHash
+	}
+	return contextHash, nil
+}
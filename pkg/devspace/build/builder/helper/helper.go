@@ -47,6 +47,12 @@ type BuildHelper struct {
 	ImageTags  []string
 	Entrypoint []string
 	Cmd        []string
+
+	// Platforms are the platforms the image is built for
+	Platforms []string
+
+	// remoteContextHash is the resolved commit or archive hash of a remote context
+	remoteContextHash string
 }
 
 // BuildHelperInterface is the interface the build helper uses to build an image
@@ -74,6 +80,11 @@ func NewBuildHelper(ctx devspacecontext.Context, engineName string, imageConf *l
 		cmd = imageConf.Cmd
 	}
 
+	var config *latest.Config
+	if ctx.Config() != nil {
+		config = ctx.Config().Config()
+	}
+
 	return &BuildHelper{
 		ImageConf: imageConf,
 
@@ -86,6 +97,8 @@ func NewBuildHelper(ctx devspacecontext.Context, engineName string, imageConf *l
 
 		Entrypoint: entrypoint,
 		Cmd:        cmd,
+
+		Platforms: ResolvePlatforms(config, imageConf),
 	}
 }
 
@@ -93,8 +106,14 @@ func NewBuildHelper(ctx devspacecontext.Context, engineName string, imageConf *l
 func (b *BuildHelper) Build(ctx devspacecontext.Context, imageBuilder BuildHelperInterface) error {
 	ctx.Log().Infof("Building image '%s:%s' with engine '%s'", b.ImageName, b.ImageTags[0], b.EngineName)
 
+	// make sure a remote context is available
+	err := b.prepareContext(ctx)
+	if err != nil {
+		return err
+	}
+
 	// Build Image
-	err := imageBuilder.BuildImage(ctx, b.ContextPath, b.DockerfilePath, b.Entrypoint, b.Cmd)
+	err = imageBuilder.BuildImage(ctx, b.ContextPath, b.DockerfilePath, b.Entrypoint, b.Cmd)
 	if err != nil {
 		return err
 	}
@@ -113,8 +132,14 @@ func (b *BuildHelper) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bo
 		return true, nil
 	}
 
+	// Clone or extract a remote context first, as it might contain the Dockerfile
+	err := b.prepareContext(ctx)
+	if err != nil {
+		return false, err
+	}
+
 	// Hash dockerfile
-	_, err := os.Stat(b.DockerfilePath)
+	_, err = os.Stat(b.DockerfilePath)
 	if err != nil {
 		return false, errors.Errorf("Dockerfile %s missing: %v", b.DockerfilePath, err)
 	}
@@ -147,8 +172,11 @@ func (b *BuildHelper) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bo
 		entrypointHash = hash.String(entrypointHash)
 	}
 
+	// Platforms might be selected by the dev containers, so we cannot rely on the config hash
+	platforms := strings.Join(b.Platforms, ",")
+
 	// only rebuild Docker image when Dockerfile or context has changed since latest build
-	mustRebuild := imageCache.Tag == "" || imageCache.DockerfileHash != dockerfileHash || imageCache.ImageConfigHash != imageConfigHash || imageCache.EntrypointHash != entrypointHash
+	mustRebuild := imageCache.Tag == "" || imageCache.DockerfileHash != dockerfileHash || imageCache.ImageConfigHash != imageConfigHash || imageCache.EntrypointHash != entrypointHash || imageCache.Platforms != platforms
 	if imageCache.Tag == "" {
 		ctx.Log().Infof("Rebuild image %s because tag is missing", imageCache.ImageName)
 	} else if imageCache.DockerfileHash != dockerfileHash {
@@ -157,6 +185,8 @@ func (b *BuildHelper) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bo
 		ctx.Log().Infof("Rebuild image %s because image config has changed", imageCache.ImageName)
 	} else if imageCache.EntrypointHash != entrypointHash {
 		ctx.Log().Infof("Rebuild image %s because entrypoint has changed", imageCache.ImageName)
+	} else if imageCache.Platforms != platforms {
+		ctx.Log().Infof("Rebuild image %s because platforms have changed", imageCache.ImageName)
 	}
 
 	var lastContextClient kubectl.Client
@@ -188,7 +218,16 @@ func (b *BuildHelper) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bo
 	}
 
 	// Check if should consider context path changes for rebuilding
-	if b.ImageConf.RebuildStrategy != latest.RebuildStrategyIgnoreContextChanges {
+	if b.ImageConf.RebuildStrategy != latest.RebuildStrategyIgnoreContextChanges && b.remoteContextHash != "" {
+		// Remote contexts are identified by their resolved commit or archive hash
+		if !mustRebuild && imageCache.ContextHash != b.remoteContextHash {
+			ctx.Log().Infof("Rebuild image %s because build context has changed", imageCache.ImageName)
+		}
+		mustRebuild = mustRebuild || imageCache.ContextHash != b.remoteContextHash
+		if forceRebuild || mustRebuild {
+			imageCache.ContextHash = b.remoteContextHash
+		}
+	} else if b.ImageConf.RebuildStrategy != latest.RebuildStrategyIgnoreContextChanges {
 		// Hash context path
 		contextDir, relDockerfile, err := build.GetContextFromLocalDir(b.ContextPath, b.DockerfilePath)
 		if err != nil {
@@ -201,7 +240,7 @@ func (b *BuildHelper) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bo
 			return false, errors.Errorf("Error reading .dockerignore: %v", err)
 		}
 
-		contextHash, err := hash.DirectoryExcludes(contextDir, excludes, false)
+		contextHash, err := HashContext(ctx, contextDir, excludes)
 		if err != nil {
 			return false, errors.Errorf("Error hashing %s: %v", contextDir, err)
 		}
@@ -225,12 +264,27 @@ func (b *BuildHelper) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bo
 		imageCache.DockerfileHash = dockerfileHash
 		imageCache.ImageConfigHash = imageConfigHash
 		imageCache.EntrypointHash = entrypointHash
+		imageCache.Platforms = platforms
 	}
 
 	ctx.Config().LocalCache().SetImageCache(b.ImageConf.Name, imageCache)
 	return mustRebuild, nil
 }
 
+func (b *BuildHelper) prepareContext(ctx devspacecontext.Context) error {
+	if b.remoteContextHash != "" {
+		return nil
+	}
+
+	remoteContextHash, err := PrepareContext(ctx, b.ImageConf)
+	if err != nil {
+		return errors.Wrapf(err, "prepare context of image %s", b.ImageConf.Name)
+	}
+
+	b.remoteContextHash = remoteContextHash
+	return nil
+}
+
 func (b *BuildHelper) IsImageAvailableLocally(ctx devspacecontext.Context, dockerClient dockerclient.Client) (bool, error) {
 	// Hack to check if docker is present in the system
 	// if docker is not present then skip the image availability check
@@ -399,6 +453,7 @@ func (b *BuildHelper) CreateContextStream(contextPath, dockerfilePath string, en
 		Target:      options.Target,
 		NetworkMode: options.NetworkMode,
 		AuthConfigs: authConfigs,
+		Platform:    strings.Join(b.Platforms, ","),
 	}
 
 	return body, writer, outStream, buildOptions, nil
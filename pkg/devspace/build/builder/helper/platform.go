@@ -0,0 +1,129 @@
+package helper
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/authn"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/pkg/errors"
+)
+
+// ResolvePlatforms returns the platforms the image should be built for. If the image doesn't
+// specify any platforms, the architectures of the dev containers that use the image are used.
+func ResolvePlatforms(config *latest.Config, imageConf *latest.Image) []string {
+	if len(imageConf.Platforms) > 0 {
+		return imageConf.Platforms
+	} else if config == nil {
+		return nil
+	}
+
+	platforms := map[string]bool{}
+	for _, devPod := range config.Dev {
+		if devPod == nil {
+			continue
+		}
+
+		devContainers := []*latest.DevContainer{&devPod.DevContainer}
+		if len(devPod.Containers) > 0 {
+			devContainers = []*latest.DevContainer{}
+			for _, devContainer := range devPod.Containers {
+				devContainers = append(devContainers, devContainer)
+			}
+		}
+
+		for _, devContainer := range devContainers {
+			if devContainer.Arch == "" {
+				continue
+			}
+
+			if referencesImage(devContainer.DevImage, imageConf) || (devContainer.DevImage == "" && referencesImage(devPod.ImageSelector, imageConf)) {
+				platforms["linux/"+string(devContainer.Arch)] = true
+			}
+		}
+	}
+
+	retPlatforms := []string{}
+	for platform := range platforms {
+		retPlatforms = append(retPlatforms, platform)
+	}
+	sort.Strings(retPlatforms)
+	return retPlatforms
+}
+
+// referencesImage checks if the given image selector or dev image refers to the image
+func referencesImage(value string, imageConf *latest.Image) bool {
+	if value == "" {
+		return false
+	}
+
+	// check for runtime variables such as ${runtime.images.my-image.image}
+	if strings.Contains(value, "images."+imageConf.Name+".") || strings.Contains(value, "images."+imageConf.Name+"}") {
+		return true
+	}
+
+	return value == imageConf.Image || strings.HasPrefix(value, imageConf.Image+":") || strings.HasPrefix(value, imageConf.Image+"@")
+}
+
+// PlatformTag returns the tag of the platform specific image that is part of a manifest list,
+// e.g. latest-linux-arm64 for latest and linux/arm64
+func PlatformTag(tag, platform string) string {
+	return tag + "-" + strings.ReplaceAll(platform, "/", "-")
+}
+
+// CreateManifestList combines the platform specific images into a manifest list for each of
+// the given tags
+func CreateManifestList(ctx context.Context, image string, tags []string, platforms []string, insecure bool) error {
+	options := []name.Option{}
+	if insecure {
+		options = append(options, name.Insecure)
+	}
+	remoteOptions := []remote.Option{remote.WithContext(ctx), remote.WithAuthFromKeychain(authn.DefaultKeychain)}
+
+	for _, tag := range tags {
+		addenda := []mutate.IndexAddendum{}
+		for _, platform := range platforms {
+			ref, err := name.ParseReference(image+":"+PlatformTag(tag, platform), options...)
+			if err != nil {
+				return err
+			}
+
+			platformImage, err := remote.Image(ref, remoteOptions...)
+			if err != nil {
+				return errors.Wrapf(err, "retrieve image %s", ref.String())
+			}
+
+			parsedPlatform, err := v1.ParsePlatform(platform)
+			if err != nil {
+				return err
+			}
+
+			addenda = append(addenda, mutate.IndexAddendum{
+				Add: platformImage,
+				Descriptor: v1.Descriptor{
+					Platform: parsedPlatform,
+				},
+			})
+		}
+
+		ref, err := name.ParseReference(image+":"+tag, options...)
+		if err != nil {
+			return err
+		}
+
+		index := mutate.AppendManifests(mutate.IndexMediaType(empty.Index, types.DockerManifestList), addenda...)
+		err = remote.WriteIndex(ref, index, remoteOptions...)
+		if err != nil {
+			return errors.Wrapf(err, "push manifest list %s", ref.String())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package helper
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+func TestResolvePlatforms(t *testing.T) {
+	imageConf := &latest.Image{
+		Name:  "backend",
+		Image: "myregistry.com/backend",
+	}
+	config := &latest.Config{
+		Dev: map[string]*latest.DevPod{
+			"backend": {
+				ImageSelector: "${runtime.images.backend.image}:${runtime.images.backend.tag}",
+				DevContainer: latest.DevContainer{
+					Arch: latest.ContainerArchitectureArm64,
+				},
+			},
+			"other": {
+				ImageSelector: "myregistry.com/backend-other",
+				DevContainer: latest.DevContainer{
+					Arch: latest.ContainerArchitectureAmd64,
+				},
+			},
+			"multi": {
+				Containers: map[string]*latest.DevContainer{
+					"a": {DevImage: "myregistry.com/backend:dev", Arch: latest.ContainerArchitectureAmd64},
+					"b": {DevImage: "alpine", Arch: latest.ContainerArchitectureArm64},
+				},
+			},
+		},
+	}
+
+	assert.DeepEqual(t, ResolvePlatforms(config, imageConf), []string{"linux/amd64", "linux/arm64"})
+	assert.DeepEqual(t, ResolvePlatforms(&latest.Config{}, imageConf), []string{})
+
+	imageConf.Platforms = []string{"linux/riscv64"}
+	assert.DeepEqual(t, ResolvePlatforms(config, imageConf), []string{"linux/riscv64"})
+}
+
+func TestPlatformTag(t *testing.T) {
+	assert.Equal(t, PlatformTag("latest", "linux/arm64/v8"), "latest-linux-arm64-v8")
+}
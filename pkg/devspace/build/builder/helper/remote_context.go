@@ -0,0 +1,224 @@
+package helper
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+
+	"github.com/docker/docker/pkg/archive"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/git"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/mitchellh/go-homedir"
+	"github.com/pkg/errors"
+)
+
+// GitContextPrefix marks a build context that is cloned from a git repository,
+// e.g. git+https://github.com/org/repo.git#main:path/to/service
+const GitContextPrefix = "git+"
+
+// ContextFolder is the folder in the home directory of the user where remote build contexts are stored
+const ContextFolder = ".devspace/contexts"
+
+// ContextFolderPath will be filled during init
+var ContextFolderPath string
+
+func init() {
+	homedir, _ := homedir.Dir()
+
+	ContextFolderPath = filepath.Join(homedir, filepath.FromSlash(ContextFolder))
+}
+
+var (
+	commitRegEx = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+	archiveSuffixes = []string{".tar", ".tar.gz", ".tgz", ".tar.bz2", ".tar.xz"}
+
+	// prepareMutex makes sure we only clone or extract a single context at a time
+	prepareMutex sync.Mutex
+)
+
+// RemoteContext is a build context that is not a local directory, but a git repository or an archive
+type RemoteContext struct {
+	// GitURL is the url of the git repository
+	GitURL string
+	// Ref is the branch, tag or commit of the git repository
+	Ref string
+	// Subdir is the directory within the git repository that is used as context
+	Subdir string
+
+	// Archive is the absolute path of a local archive
+	Archive string
+}
+
+// ParseRemoteContext returns the remote context of an image or nil if the context is a local directory
+func ParseRemoteContext(ctx devspacecontext.Context, imageConf *latest.Image) *RemoteContext {
+	if strings.HasPrefix(imageConf.Context, GitContextPrefix) {
+		remoteContext := &RemoteContext{
+			GitURL: strings.TrimPrefix(imageConf.Context, GitContextPrefix),
+		}
+
+		// the fragment has the form ref:subdir, both parts are optional
+		if index := strings.LastIndex(remoteContext.GitURL, "#"); index != -1 {
+			fragment := remoteContext.GitURL[index+1:]
+			remoteContext.GitURL = remoteContext.GitURL[:index]
+
+			splitted := strings.SplitN(fragment, ":", 2)
+			remoteContext.Ref = splitted[0]
+			if len(splitted) == 2 {
+				remoteContext.Subdir = filepath.Clean(filepath.FromSlash(strings.Trim(splitted[1], "/")))
+				if remoteContext.Subdir == "." {
+					remoteContext.Subdir = ""
+				}
+			}
+		}
+
+		return remoteContext
+	}
+
+	for _, suffix := range archiveSuffixes {
+		if strings.HasSuffix(strings.ToLower(imageConf.Context), suffix) {
+			return &RemoteContext{
+				Archive: ctx.ResolvePath(imageConf.Context),
+			}
+		}
+	}
+
+	return nil
+}
+
+// LocalPath returns the directory the remote context is cloned or extracted to
+func (r *RemoteContext) LocalPath() string {
+	if r.Archive != "" {
+		return filepath.Join(ContextFolderPath, "archive-"+hash.String(r.Archive)[:16])
+	}
+
+	return filepath.Join(ContextFolderPath, "git-"+hash.String(r.GitURL + "#" + r.Ref)[:16])
+}
+
+// ContextPath returns the local directory that is used as build context
+func (r *RemoteContext) ContextPath() string {
+	// subdirs outside of the repository are rejected by Prepare
+	if r.Subdir != "" && !filepath.IsLocal(r.Subdir) {
+		return r.LocalPath()
+	}
+
+	return filepath.Join(r.LocalPath(), r.Subdir)
+}
+
+// Prepare clones or extracts the remote context into its local path and returns a hash that identifies
+// the content of the context. For git contexts this is the resolved commit, for archives the hash of the
+// archive itself.
+func (r *RemoteContext) Prepare(ctx devspacecontext.Context) (string, error) {
+	if r.Subdir != "" && !filepath.IsLocal(r.Subdir) {
+		return "", errors.Errorf("subdir %s of build context %s must be a directory within the repository", filepath.ToSlash(r.Subdir), r.GitURL)
+	}
+
+	prepareMutex.Lock()
+	defer prepareMutex.Unlock()
+
+	if r.Archive != "" {
+		return r.extract(ctx)
+	}
+
+	return r.clone(ctx)
+}
+
+func (r *RemoteContext) clone(ctx devspacecontext.Context) (string, error) {
+	localPath := r.LocalPath()
+	_, statErr := os.Stat(localPath)
+
+	repo, err := git.NewGitCLIRepository(ctx.Context(), localPath)
+	if err != nil {
+		return "", err
+	}
+
+	cloneOptions := git.CloneOptions{
+		URL: r.GitURL,
+	}
+	if commitRegEx.MatchString(r.Ref) {
+		cloneOptions.Commit = r.Ref
+	} else {
+		cloneOptions.Branch = r.Ref
+	}
+
+	if statErr != nil {
+		ctx.Log().Infof("Cloning build context %s...", r.GitURL)
+		err = repo.Clone(ctx.Context(), cloneOptions)
+		if err != nil {
+			_ = os.RemoveAll(localPath)
+			return "", errors.Wrap(err, "clone build context")
+		}
+	} else if cloneOptions.Commit == "" {
+		// branches could have moved since the last clone
+		err = repo.Pull(ctx.Context())
+		if err != nil {
+			ctx.Log().Warn(err)
+		}
+	}
+
+	commit, err := git.GetHash(ctx.Context(), localPath)
+	if err != nil {
+		return "", errors.Wrap(err, "resolve commit of build context")
+	}
+
+	return commit, nil
+}
+
+func (r *RemoteContext) extract(ctx devspacecontext.Context) (string, error) {
+	archiveHash, err := hash.File(r.Archive)
+	if err != nil {
+		return "", errors.Wrap(err, "hash build context archive")
+	}
+
+	// only extract the archive again if it has changed. The hash is stored next to the
+	// extracted directory, so it doesn't end up in the build context.
+	localPath := r.LocalPath()
+	hashFile := localPath + ".hash"
+	existingHash, err := os.ReadFile(hashFile)
+	if err == nil && string(existingHash) == archiveHash {
+		return archiveHash, nil
+	}
+
+	ctx.Log().Infof("Extracting build context %s...", r.Archive)
+	err = os.RemoveAll(localPath)
+	if err != nil {
+		return "", err
+	}
+	err = os.MkdirAll(localPath, 0755)
+	if err != nil {
+		return "", err
+	}
+
+	file, err := os.Open(r.Archive)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	err = archive.Untar(file, localPath, &archive.TarOptions{NoLchown: true})
+	if err != nil {
+		return "", errors.Wrap(err, "extract build context archive")
+	}
+
+	err = os.WriteFile(hashFile, []byte(archiveHash), 0644)
+	if err != nil {
+		return "", err
+	}
+
+	return archiveHash, nil
+}
+
+// PrepareContext clones or extracts the context of the image if it is a remote context and returns
+// the hash that identifies its content. For local contexts an empty hash is returned.
+func PrepareContext(ctx devspacecontext.Context, imageConf *latest.Image) (string, error) {
+	remoteContext := ParseRemoteContext(ctx, imageConf)
+	if remoteContext == nil {
+		return "", nil
+	}
+
+	return remoteContext.Prepare(ctx)
+}
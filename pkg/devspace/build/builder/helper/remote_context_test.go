@@ -0,0 +1,101 @@
+package helper
+
+import (
+	"archive/tar"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestParseRemoteContext(t *testing.T) {
+	ctx := devspacecontext.NewContext(context.TODO(), nil, log.Discard).WithWorkingDir("/project")
+
+	remoteContext := ParseRemoteContext(ctx, &latest.Image{Context: "./"})
+	assert.Assert(t, remoteContext == nil)
+
+	remoteContext = ParseRemoteContext(ctx, &latest.Image{Context: "git+https://github.com/org/repo.git"})
+	assert.DeepEqual(t, remoteContext, &RemoteContext{GitURL: "https://github.com/org/repo.git"})
+
+	remoteContext = ParseRemoteContext(ctx, &latest.Image{Context: "git+https://github.com/org/repo.git#v1.0.0"})
+	assert.DeepEqual(t, remoteContext, &RemoteContext{GitURL: "https://github.com/org/repo.git", Ref: "v1.0.0"})
+
+	remoteContext = ParseRemoteContext(ctx, &latest.Image{Context: "git+https://github.com/org/repo.git#main:services/api/"})
+	assert.DeepEqual(t, remoteContext, &RemoteContext{GitURL: "https://github.com/org/repo.git", Ref: "main", Subdir: filepath.FromSlash("services/api")})
+
+	remoteContext = ParseRemoteContext(ctx, &latest.Image{Context: "git+https://github.com/org/repo.git#main:./services//api/../web"})
+	assert.DeepEqual(t, remoteContext, &RemoteContext{GitURL: "https://github.com/org/repo.git", Ref: "main", Subdir: filepath.FromSlash("services/web")})
+
+	remoteContext = ParseRemoteContext(ctx, &latest.Image{Context: "git+https://github.com/org/repo.git#main:./"})
+	assert.DeepEqual(t, remoteContext, &RemoteContext{GitURL: "https://github.com/org/repo.git", Ref: "main"})
+
+	remoteContext = ParseRemoteContext(ctx, &latest.Image{Context: "context.tar.gz"})
+	assert.DeepEqual(t, remoteContext, &RemoteContext{Archive: filepath.Join("/project", "context.tar.gz")})
+}
+
+func TestPrepareArchiveContext(t *testing.T) {
+	dir := t.TempDir()
+	contextFolderBackup := ContextFolderPath
+	ContextFolderPath = filepath.Join(dir, "contexts")
+	defer func() { ContextFolderPath = contextFolderBackup }()
+
+	writeArchive := func(content string) {
+		file, err := os.Create(filepath.Join(dir, "context.tar"))
+		assert.NilError(t, err)
+		defer file.Close()
+
+		writer := tar.NewWriter(file)
+		err = writer.WriteHeader(&tar.Header{Name: "Dockerfile", Mode: 0644, Size: int64(len(content))})
+		assert.NilError(t, err)
+		_, err = writer.Write([]byte(content))
+		assert.NilError(t, err)
+		assert.NilError(t, writer.Close())
+	}
+
+	ctx := devspacecontext.NewContext(context.TODO(), nil, log.Discard).WithWorkingDir(dir)
+	imageConf := &latest.Image{Context: "context.tar"}
+
+	writeArchive("FROM alpine")
+	firstHash, err := PrepareContext(ctx, imageConf)
+	assert.NilError(t, err)
+
+	dockerfilePath, contextPath := GetDockerfileAndContext(ctx, imageConf)
+	assert.Equal(t, contextPath, ParseRemoteContext(ctx, imageConf).ContextPath())
+	content, err := os.ReadFile(dockerfilePath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "FROM alpine")
+
+	// preparing an unchanged archive returns the same hash
+	secondHash, err := PrepareContext(ctx, imageConf)
+	assert.NilError(t, err)
+	assert.Equal(t, firstHash, secondHash)
+
+	// a changed archive is extracted again
+	writeArchive("FROM ubuntu")
+	thirdHash, err := PrepareContext(ctx, imageConf)
+	assert.NilError(t, err)
+	assert.Assert(t, firstHash != thirdHash)
+	content, err = os.ReadFile(dockerfilePath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(content), "FROM ubuntu")
+}
+
+func TestPrepareRejectsSubdirOutsideRepository(t *testing.T) {
+	ctx := devspacecontext.NewContext(context.TODO(), nil, log.Discard).WithWorkingDir("/project")
+
+	for _, subdir := range []string{"..", "../other", "services/../../other", "/../../etc"} {
+		imageConf := &latest.Image{Context: "git+https://github.com/org/repo.git#main:" + subdir}
+		remoteContext := ParseRemoteContext(ctx, imageConf)
+
+		// the context never points outside of the cloned repository
+		assert.Equal(t, remoteContext.ContextPath(), remoteContext.LocalPath(), "Unexpected context path for subdir %s", subdir)
+
+		_, err := PrepareContext(ctx, imageConf)
+		assert.ErrorContains(t, err, "must be a directory within the repository", "Wrong or no error for subdir %s", subdir)
+	}
+}
@@ -0,0 +1,171 @@
+package helper
+
+import (
+	"context"
+	"net"
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	dockerclient "github.com/loft-sh/devspace/pkg/devspace/docker"
+	"github.com/moby/buildkit/session"
+	"github.com/moby/buildkit/session/secrets/secretsprovider"
+	"github.com/moby/buildkit/session/sshforward/sshprovider"
+	"github.com/pkg/errors"
+)
+
+// DefaultSSHID is the id of an ssh mount if no id is specified
+const DefaultSSHID = "default"
+
+// secretEnvPrefix is the prefix of the environment variables that pass secret values to the docker cli
+const secretEnvPrefix = "DEVSPACE_BUILD_SECRET_"
+
+var secretEnvUnsafeRegEx = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// ResolveSecrets returns the values of the build secrets of an image. The values are only kept in memory.
+func ResolveSecrets(ctx devspacecontext.Context, imageConf *latest.Image) (map[string][]byte, error) {
+	secrets := map[string][]byte{}
+	for id, secret := range imageConf.Secrets {
+		switch {
+		case secret.File != "":
+			data, err := os.ReadFile(ctx.ResolvePath(secret.File))
+			if err != nil {
+				return nil, errors.Wrapf(err, "read build secret %s", id)
+			}
+
+			secrets[id] = data
+		case secret.Env != "":
+			value := ctx.Environ().Get(secret.Env)
+			if !value.IsSet() {
+				return nil, errors.Errorf("environment variable %s of build secret %s is not set", secret.Env, id)
+			}
+
+			secrets[id] = []byte(value.String())
+		default:
+			secrets[id] = []byte(secret.Value)
+		}
+	}
+
+	return secrets, nil
+}
+
+// SecretArgs returns the --secret and --ssh flags for the docker and buildx cli as well as the
+// environment variables that need to be passed to the cli. Secret values are only passed as
+// environment variables, so they never show up in the arguments or the logs.
+func SecretArgs(ctx devspacecontext.Context, imageConf *latest.Image) ([]string, map[string]string) {
+	ids := []string{}
+	for id := range imageConf.Secrets {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	args := []string{}
+	environ := map[string]string{}
+	for _, id := range ids {
+		secret := imageConf.Secrets[id]
+		switch {
+		case secret.File != "":
+			args = append(args, "--secret", "id="+id+",src="+ctx.ResolvePath(secret.File))
+		case secret.Env != "":
+			args = append(args, "--secret", "id="+id+",env="+secret.Env)
+		default:
+			envName := secretEnvPrefix + secretEnvUnsafeRegEx.ReplaceAllString(strings.ToUpper(id), "_")
+			environ[envName] = secret.Value
+			args = append(args, "--secret", "id="+id+",env="+envName)
+		}
+	}
+
+	for _, agentConfig := range sshAgentConfigs(ctx, imageConf) {
+		if len(agentConfig.Paths) == 0 {
+			args = append(args, "--ssh", agentConfig.ID)
+		} else {
+			args = append(args, "--ssh", agentConfig.ID+"="+strings.Join(agentConfig.Paths, ","))
+		}
+	}
+
+	return args, environ
+}
+
+// SecretAttachables returns the buildkit session attachables that provide the build secrets and
+// ssh agents of an image
+func SecretAttachables(ctx devspacecontext.Context, imageConf *latest.Image) ([]session.Attachable, error) {
+	attachables := []session.Attachable{}
+	if len(imageConf.Secrets) > 0 {
+		secrets, err := ResolveSecrets(ctx, imageConf)
+		if err != nil {
+			return nil, err
+		}
+
+		attachables = append(attachables, secretsprovider.FromMap(secrets))
+	}
+	if len(imageConf.SSH) > 0 {
+		sshProvider, err := sshprovider.NewSSHAgentProvider(sshAgentConfigs(ctx, imageConf))
+		if err != nil {
+			return nil, errors.Wrap(err, "create ssh agent provider")
+		}
+
+		attachables = append(attachables, sshProvider)
+	}
+
+	return attachables, nil
+}
+
+// StartSecretSession starts a buildkit session within the docker daemon that provides the build secrets
+// and ssh agents of the image and configures the build options to use it. The returned function closes
+// the session. If the image has no secrets, no session is started.
+func StartSecretSession(ctx devspacecontext.Context, client dockerclient.Client, imageConf *latest.Image, buildOptions *types.ImageBuildOptions) (func(), error) {
+	attachables, err := SecretAttachables(ctx, imageConf)
+	if err != nil {
+		return nil, err
+	} else if len(attachables) == 0 {
+		return func() {}, nil
+	}
+
+	s, err := session.NewSession(ctx.Context(), "devspace", "")
+	if err != nil {
+		return nil, errors.Wrap(err, "create build session")
+	}
+	for _, attachable := range attachables {
+		s.Allow(attachable)
+	}
+
+	apiClient := client.DockerAPIClient()
+	go func() {
+		err := s.Run(ctx.Context(), func(ctx context.Context, proto string, meta map[string][]string) (net.Conn, error) {
+			return apiClient.DialHijack(ctx, "/session", proto, meta)
+		})
+		if err != nil {
+			ctx.Log().Debugf("Error running build session: %v", err)
+		}
+	}()
+
+	// secrets and ssh mounts are only supported by buildkit
+	buildOptions.SessionID = s.ID()
+	buildOptions.Version = types.BuilderBuildKit
+	return func() {
+		_ = s.Close()
+	}, nil
+}
+
+func sshAgentConfigs(ctx devspacecontext.Context, imageConf *latest.Image) []sshprovider.AgentConfig {
+	agentConfigs := []sshprovider.AgentConfig{}
+	for _, ssh := range imageConf.SSH {
+		agentConfig := sshprovider.AgentConfig{
+			ID: ssh.ID,
+		}
+		if agentConfig.ID == "" {
+			agentConfig.ID = DefaultSSHID
+		}
+		for _, key := range ssh.Keys {
+			agentConfig.Paths = append(agentConfig.Paths, ctx.ResolvePath(key))
+		}
+
+		agentConfigs = append(agentConfigs, agentConfig)
+	}
+
+	return agentConfigs
+}
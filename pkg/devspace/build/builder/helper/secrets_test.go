@@ -0,0 +1,52 @@
+package helper
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/env"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestSecretArgs(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "token.txt"), []byte("file-secret"), 0600)
+	assert.NilError(t, err)
+
+	ctx := devspacecontext.NewContext(context.TODO(), nil, log.Discard).WithWorkingDir(dir)
+	ctx = ctx.WithEnviron(env.NewVariableEnvProvider(ctx.Environ(), map[string]string{"NPM_TOKEN": "env-secret"}))
+	imageConf := &latest.Image{
+		Secrets: map[string]*latest.BuildSecret{
+			"file":      {File: "token.txt"},
+			"env":       {Env: "NPM_TOKEN"},
+			"pip-token": {Value: "value-secret"},
+		},
+		SSH: []*latest.BuildSSH{
+			{},
+			{ID: "github", Keys: []string{"id_rsa"}},
+		},
+	}
+
+	args, environ := SecretArgs(ctx, imageConf)
+	assert.DeepEqual(t, args, []string{
+		"--secret", "id=env,env=NPM_TOKEN",
+		"--secret", "id=file,src=" + filepath.Join(dir, "token.txt"),
+		"--secret", "id=pip-token,env=DEVSPACE_BUILD_SECRET_PIP_TOKEN",
+		"--ssh", "default",
+		"--ssh", "github=" + filepath.Join(dir, "id_rsa"),
+	})
+	assert.DeepEqual(t, environ, map[string]string{"DEVSPACE_BUILD_SECRET_PIP_TOKEN": "value-secret"})
+
+	secrets, err := ResolveSecrets(ctx, imageConf)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, secrets, map[string][]byte{
+		"file":      []byte("file-secret"),
+		"env":       []byte("env-secret"),
+		"pip-token": []byte("value-secret"),
+	})
+}
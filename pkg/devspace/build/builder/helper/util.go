@@ -104,6 +104,16 @@ func GetDockerfileAndContext(ctx devspacecontext.Context, imageConf *latest.Imag
 		contextPath = imageConf.Context
 	}
 
+	// remote contexts bring their own Dockerfile, so relative paths are resolved within the context
+	if remoteContext := ParseRemoteContext(ctx, imageConf); remoteContext != nil {
+		contextPath = remoteContext.ContextPath()
+		if !filepath.IsAbs(dockerfilePath) {
+			dockerfilePath = filepath.Join(contextPath, dockerfilePath)
+		}
+
+		return dockerfilePath, contextPath
+	}
+
 	return ctx.ResolvePath(dockerfilePath), ctx.ResolvePath(contextPath)
 }
 
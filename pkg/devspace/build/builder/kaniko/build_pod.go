@@ -4,10 +4,13 @@ import (
 	"fmt"
 	"path/filepath"
 
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/kaniko/util"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 
 	"github.com/docker/distribution/reference"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"gopkg.in/yaml.v3"
 	jsonyaml "sigs.k8s.io/yaml"
 
@@ -54,7 +57,7 @@ var defaultResources = &availableResources{
 	EphemeralStorage: resource.MustParse("10Gi"),
 }
 
-func (b *Builder) getBuildPod(ctx devspacecontext.Context, buildID string, options *types.ImageBuildOptions, dockerfilePath string) (*k8sv1.Pod, error) {
+func (b *Builder) getBuildPod(ctx devspacecontext.Context, buildID string, options *types.ImageBuildOptions, dockerfilePath string, platform string, tags []string) (*k8sv1.Pod, error) {
 	kanikoOptions := b.helper.ImageConf.Kaniko
 
 	registryURL, err := pullsecrets.GetRegistryFromImageName(b.FullImageName)
@@ -89,10 +92,28 @@ func (b *Builder) getBuildPod(ctx devspacecontext.Context, buildID string, optio
 	}
 
 	// specify destinations
-	for _, tag := range b.helper.ImageTags {
+	for _, tag := range tags {
 		kanikoArgs = append(kanikoArgs, "--destination="+b.helper.ImageName+":"+tag)
 	}
 
+	// set platform and make sure the pod runs on a node with a matching architecture
+	nodeSelector := kanikoOptions.NodeSelector
+	if platform != "" {
+		kanikoArgs = append(kanikoArgs, "--custom-platform="+platform)
+
+		parsedPlatform, err := v1.ParsePlatform(platform)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := nodeSelector[k8sv1.LabelArchStable]; !ok && parsedPlatform.Architecture != "" {
+			nodeSelector = map[string]string{}
+			for k, v := range kanikoOptions.NodeSelector {
+				nodeSelector[k] = v
+			}
+			nodeSelector[k8sv1.LabelArchStable] = parsedPlatform.Architecture
+		}
+	}
+
 	// set target
 	if options.Target != "" {
 		kanikoArgs = append(kanikoArgs, "--target="+options.Target)
@@ -124,6 +145,23 @@ func (b *Builder) getBuildPod(ctx devspacecontext.Context, buildID string, optio
 		}
 
 		kanikoArgs = append(kanikoArgs, "--cache=true", "--cache-repo="+ref.Name())
+	} else if cacheFrom, cacheTo := helper.ResolveCache(ctx, b.helper.ImageConf); cacheTo != nil || len(cacheFrom) > 0 {
+		// kaniko only supports a single cache repository
+		location := cacheTo
+		if location == nil {
+			location = cacheFrom[0]
+		}
+
+		if location.Type == latest.BuildCacheTypeLocal {
+			ctx.Log().Warnf("Skip local cache %s, because kaniko only supports registry caches", location.Path)
+		} else {
+			cacheRepository, err := helper.CacheRepository(location)
+			if err != nil {
+				return nil, err
+			}
+
+			kanikoArgs = append(kanikoArgs, "--cache=true", "--cache-repo="+cacheRepository)
+		}
 	}
 
 	// extra flags
@@ -165,6 +203,22 @@ func (b *Builder) getBuildPod(ctx devspacecontext.Context, buildID string, optio
 		})
 	}
 
+	if len(b.helper.ImageConf.Secrets) > 0 {
+		volumes = append(volumes, k8sv1.Volume{
+			Name: "build-secrets",
+			VolumeSource: k8sv1.VolumeSource{
+				Secret: &k8sv1.SecretVolumeSource{
+					SecretName: secretName(buildID),
+				},
+			},
+		})
+		volumeMounts = append(volumeMounts, k8sv1.VolumeMount{
+			Name:      "build-secrets",
+			ReadOnly:  true,
+			MountPath: secretsMountPath,
+		})
+	}
+
 	// add additional mounts
 	for i, mount := range kanikoOptions.AdditionalMounts {
 		volume := k8sv1.Volume{
@@ -255,7 +309,7 @@ func (b *Builder) getBuildPod(ctx devspacecontext.Context, buildID string, optio
 					VolumeMounts:    volumeMounts,
 				},
 			},
-			NodeSelector:       kanikoOptions.NodeSelector,
+			NodeSelector:       nodeSelector,
 			Tolerations:        kanikoOptions.Tolerations,
 			ServiceAccountName: kanikoOptions.ServiceAccount,
 			Volumes:            volumes,
@@ -139,16 +139,69 @@ func (b *Builder) BuildImage(ctx devspacecontext.Context, contextPath, dockerfil
 		defer os.RemoveAll(filepath.Dir(dockerfilePath))
 	}
 
+	if len(b.helper.ImageConf.SSH) > 0 {
+		ctx.Log().Warnf("Skip ssh mounts of image %s, because kaniko doesn't support them. Please use buildKit instead", b.helper.ImageName)
+	}
+
+	platforms := b.helper.Platforms
+	if len(platforms) <= 1 {
+		platform := ""
+		if len(platforms) == 1 {
+			platform = platforms[0]
+		}
+
+		return b.buildPlatform(ctx, contextPath, dockerfilePath, options, injectRestartHelper, platform, b.helper.ImageTags)
+	}
+
+	// kaniko can only build a single platform, so we build each platform separately and
+	// combine them into a manifest list afterwards
+	for _, platform := range platforms {
+		tags := []string{}
+		for _, tag := range b.helper.ImageTags {
+			tags = append(tags, helper.PlatformTag(tag, platform))
+		}
+
+		ctx.Log().Infof("Building image for platform %s...", platform)
+		err = b.buildPlatform(ctx, contextPath, dockerfilePath, options, injectRestartHelper, platform, tags)
+		if err != nil {
+			return errors.Wrapf(err, "build platform %s", platform)
+		}
+	}
+
+	ctx.Log().Info("Pushing manifest list...")
+	err = helper.CreateManifestList(ctx.Context(), b.helper.ImageName, b.helper.ImageTags, platforms, b.allowInsecureRegistry)
+	if err != nil {
+		return err
+	}
+
+	ctx.Log().Done("Pushed manifest list for platforms " + strings.Join(platforms, ", "))
+	return nil
+}
+
+// buildPlatform builds the image for a single platform within a kaniko pod
+func (b *Builder) buildPlatform(ctx devspacecontext.Context, contextPath, dockerfilePath string, options *types.ImageBuildOptions, injectRestartHelper bool, platform string, tags []string) error {
 	// Generate the build pod spec
 	randString := randutil.GenerateRandomString(12)
 	buildID := strings.ToLower(randString)
-	buildPod, err := b.getBuildPod(ctx, buildID, options, dockerfilePath)
+	buildPod, err := b.getBuildPod(ctx, buildID, options, dockerfilePath, platform, tags)
 	if err != nil {
 		return errors.Wrap(err, "get build pod")
 	}
 
+	// Create the build secrets before the pod
+	if len(b.helper.ImageConf.Secrets) > 0 {
+		err = b.createBuildSecret(ctx, buildID)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Delete the build pod when we are done or get interrupted during build
 	deleteBuildPod := func() {
+		if len(b.helper.ImageConf.Secrets) > 0 {
+			b.deleteBuildSecret(ctx, buildID)
+		}
+
 		gracePeriod := int64(3)
 		if buildPod.Name == "" {
 			return
@@ -0,0 +1,52 @@
+package kaniko
+
+import (
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/pkg/errors"
+	k8sv1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// The path where the build secrets are mounted within the kaniko pod, which is
+// the default target of RUN --mount=type=secret
+const secretsMountPath = "/run/secrets"
+
+// secretName returns the name of the kubernetes secret that holds the build secrets of a build
+func secretName(buildID string) string {
+	return "devspace-build-secrets-" + buildID
+}
+
+// createBuildSecret creates a kubernetes secret with the build secrets of the image. Kaniko doesn't
+// support secret mounts, so the secret is mounted into the build pod instead.
+func (b *Builder) createBuildSecret(ctx devspacecontext.Context, buildID string) error {
+	secrets, err := helper.ResolveSecrets(ctx, b.helper.ImageConf)
+	if err != nil {
+		return err
+	}
+
+	_, err = ctx.KubeClient().KubeClient().CoreV1().Secrets(b.BuildNamespace).Create(ctx.Context(), &k8sv1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: secretName(buildID),
+			Labels: map[string]string{
+				"devspace-build":    "true",
+				"devspace-build-id": buildID,
+			},
+		},
+		Data: secrets,
+	}, metav1.CreateOptions{})
+	if err != nil {
+		return errors.Wrap(err, "create build secret")
+	}
+
+	return nil
+}
+
+// deleteBuildSecret deletes the kubernetes secret with the build secrets of a build
+func (b *Builder) deleteBuildSecret(ctx devspacecontext.Context, buildID string) {
+	err := ctx.KubeClient().KubeClient().CoreV1().Secrets(b.BuildNamespace).Delete(ctx.Context(), secretName(buildID), metav1.DeleteOptions{})
+	if err != nil {
+		ctx.Log().Errorf("Failed to delete build secret: %v", err)
+	}
+}
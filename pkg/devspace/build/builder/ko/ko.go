@@ -0,0 +1,251 @@
+package ko
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/build/localregistry"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/env"
+	"github.com/loft-sh/devspace/pkg/util/hash"
+	logpkg "github.com/loft-sh/devspace/pkg/util/log"
+	command2 "github.com/loft-sh/utils/pkg/command"
+	dockerterm "github.com/moby/term"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"gopkg.in/yaml.v3"
+)
+
+// EngineName is the name of the building engine
+const EngineName = "ko"
+
+var (
+	_, stdout, _ = dockerterm.StdStreams()
+)
+
+// Builder holds the necessary information to build go applications with ko
+type Builder struct {
+	imageConf   *latest.Image
+	imageTags   []string
+	contextPath string
+
+	localRegistry             *localregistry.LocalRegistry
+	skipPush                  bool
+	skipPushOnLocalKubernetes bool
+}
+
+// NewBuilder creates a new ko Builder instance. If localRegistry is set, the image will be pushed
+// to the local registry instead of the registry of the image.
+func NewBuilder(ctx devspacecontext.Context, localRegistry *localregistry.LocalRegistry, imageConf *latest.Image, imageTags []string, skipPush, skipPushOnLocalKubernetes bool) (*Builder, error) {
+	_, contextPath := helper.GetDockerfileAndContext(ctx, imageConf)
+	return &Builder{
+		imageConf:                 imageConf,
+		imageTags:                 imageTags,
+		contextPath:               contextPath,
+		localRegistry:             localRegistry,
+		skipPush:                  skipPush,
+		skipPushOnLocalKubernetes: skipPushOnLocalKubernetes,
+	}, nil
+}
+
+// ShouldRebuild determines if an image has to be rebuilt. Instead of the docker context, only the
+// go source and module files are considered.
+func (b *Builder) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bool) (bool, error) {
+	// make sure a remote context is cloned or extracted
+	_, err := helper.PrepareContext(ctx, b.imageConf)
+	if err != nil {
+		return false, errors.Wrapf(err, "prepare context of image %s", b.imageConf.Name)
+	}
+
+	imageCache, _ := ctx.Config().LocalCache().GetImageCache(b.imageConf.Name)
+
+	// if rebuild strategy is always, we return here
+	if b.imageConf.RebuildStrategy == latest.RebuildStrategyAlways {
+		ctx.Log().Infof("Rebuild image %s because strategy is always rebuild", imageCache.ImageName)
+		return true, nil
+	}
+
+	// Hash image config
+	configStr, err := yaml.Marshal(*b.imageConf)
+	if err != nil {
+		return false, errors.Wrap(err, "marshal image config")
+	}
+	imageConfigHash := hash.String(string(configStr))
+
+	mustRebuild := forceRebuild || imageCache.Tag == "" || imageCache.ImageConfigHash != imageConfigHash
+	if imageCache.Tag == "" {
+		ctx.Log().Infof("Rebuild image %s because tag is missing", imageCache.ImageName)
+	} else if imageCache.ImageConfigHash != imageConfigHash {
+		ctx.Log().Infof("Rebuild image %s because image config has changed", imageCache.ImageName)
+	}
+
+	// Hash go sources
+	if b.imageConf.RebuildStrategy != latest.RebuildStrategyIgnoreContextChanges {
+		sourceHash, err := HashGoSources(b.contextPath)
+		if err != nil {
+			return false, errors.Wrapf(err, "hash go sources in %s", b.contextPath)
+		}
+
+		if !mustRebuild && imageCache.ContextHash != sourceHash {
+			ctx.Log().Infof("Rebuild image %s because go sources have changed", imageCache.ImageName)
+		}
+		mustRebuild = mustRebuild || imageCache.ContextHash != sourceHash
+		if mustRebuild {
+			imageCache.ContextHash = sourceHash
+		}
+	}
+
+	if mustRebuild {
+		imageCache.ImageConfigHash = imageConfigHash
+	}
+
+	ctx.Config().LocalCache().SetImageCache(b.imageConf.Name, imageCache)
+	return mustRebuild, nil
+}
+
+// Build implements the interface
+func (b *Builder) Build(ctx devspacecontext.Context) error {
+	ctx.Log().Infof("Building image '%s:%s' with engine '%s'", b.imageConf.Image, b.imageTags[0], EngineName)
+
+	// Determine output writer
+	var writer io.WriteCloser
+	if ctx.Log() == logpkg.GetInstance() {
+		writer = logpkg.WithNopCloser(stdout)
+	} else {
+		writer = ctx.Log().Writer(logrus.InfoLevel, false)
+	}
+	defer writer.Close()
+
+	// We skip pushing when it is a local kubernetes cluster
+	usingLocalKubernetes := ctx.KubeClient() != nil && kubectl.IsLocalKubernetes(ctx.KubeClient())
+	skipPush := b.skipPush || b.imageConf.SkipPush || (b.skipPushOnLocalKubernetes && usingLocalKubernetes)
+
+	// ko pushes to KO_DOCKER_REPO, which is either the image or the image within the local registry
+	repository := b.imageConf.Image
+	if b.localRegistry != nil {
+		var err error
+		repository, err = b.localRegistry.RewriteImage(b.imageConf.Image)
+		if err != nil {
+			return errors.Wrap(err, "rewrite image")
+		}
+
+		skipPush = false
+	}
+
+	extraEnv := map[string]string{
+		"KO_DOCKER_REPO": repository,
+	}
+	if b.imageConf.Ko != nil && b.imageConf.Ko.BaseImage != "" {
+		extraEnv["KO_DEFAULTBASEIMAGE"] = b.imageConf.Ko.BaseImage
+	}
+
+	command := []string{"ko"}
+	if b.imageConf.Ko != nil && len(b.imageConf.Ko.Command) > 0 {
+		command = b.imageConf.Ko.Command
+	}
+	args := append([]string{}, command[1:]...)
+	args = append(args, b.buildArgs(skipPush)...)
+
+	ctx.Log().Infof("Execute ko command with: %s %s", strings.Join(command, " "), strings.Join(args, " "))
+	err := command2.Command(ctx.Context(), b.contextPath, env.NewVariableEnvProvider(ctx.Environ(), extraEnv), writer, writer, nil, command[0], args...)
+	if err != nil {
+		return errors.Errorf("error building image: %v", err)
+	}
+
+	if b.localRegistry != nil {
+		ctx.Log().Info("Image pushed to local registry")
+	} else if skipPush && ctx.KubeClient() != nil && kubectl.GetKindContext(ctx.KubeClient().CurrentContext()) != "" {
+		// Load image if it is a kind-context
+		for _, tag := range b.imageTags {
+			err = command2.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, writer, nil, "kind", "load", "docker-image", "--name", kubectl.GetKindContext(ctx.KubeClient().CurrentContext()), b.imageConf.Image+":"+tag)
+			if err != nil {
+				ctx.Log().Info(errors.Errorf("error during image load to kind cluster: %v", err))
+			} else {
+				ctx.Log().Info("Image loaded to kind cluster")
+			}
+		}
+	} else if skipPush {
+		ctx.Log().Infof("Skip image push for %s", b.imageConf.Image)
+	}
+
+	ctx.Log().Done("Done processing image '" + b.imageConf.Image + "'")
+	return nil
+}
+
+// buildArgs returns the arguments for ko build
+func (b *Builder) buildArgs(skipPush bool) []string {
+	args := []string{"build", "--bare", "--tags", strings.Join(b.imageTags, ",")}
+	if skipPush {
+		// load the image into the local docker daemon
+		args = append(args, "--local")
+	}
+
+	main := "."
+	if b.imageConf.Ko != nil {
+		args = append(args, b.imageConf.Ko.Args...)
+		if b.imageConf.Ko.Main != "" {
+			main = b.imageConf.Ko.Main
+		}
+	}
+
+	return append(args, main)
+}
+
+// HashGoSources hashes the contents of all go source and module files within the given directory
+func HashGoSources(dir string) (string, error) {
+	files := []string{}
+	err := filepath.Walk(dir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		if info.IsDir() {
+			if path != dir && (strings.HasPrefix(info.Name(), ".") || info.Name() == "node_modules" || info.Name() == "testdata") {
+				return filepath.SkipDir
+			}
+
+			return nil
+		}
+
+		if isGoSourceFile(info.Name()) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return "", err
+	}
+
+	// hash the contents and paths of the files, so that touching a file doesn't trigger a rebuild
+	sort.Strings(files)
+	hashes := ""
+	for _, file := range files {
+		fileHash, err := hash.File(file)
+		if err != nil {
+			return "", errors.Wrap(err, "hash "+file)
+		}
+
+		relPath, err := filepath.Rel(dir, file)
+		if err != nil {
+			return "", err
+		}
+
+		hashes += filepath.ToSlash(relPath) + ":" + fileHash + ";"
+	}
+
+	return hash.String(hashes), nil
+}
+
+func isGoSourceFile(name string) bool {
+	if name == "go.mod" || name == "go.sum" || name == "go.work" || name == "go.work.sum" {
+		return true
+	}
+
+	return strings.HasSuffix(name, ".go") && !strings.HasSuffix(name, "_test.go")
+}
@@ -0,0 +1,68 @@
+package ko
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+func TestHashGoSources(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod":                 "module test",
+		"main.go":                "package main",
+		"main_test.go":           "package main",
+		"README.md":              "readme",
+		".git/config":            "git",
+		"pkg/util/util.go":       "package util",
+		"pkg/util/testdata/a.go": "package testdata",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		assert.NilError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		assert.NilError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+
+	before, err := HashGoSources(dir)
+	assert.NilError(t, err)
+
+	// changes to non go files don't change the hash
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("changed readme"), 0644))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "main_test.go"), []byte("package main_test"), 0644))
+	after, err := HashGoSources(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, before, after)
+
+	// touching a go file doesn't change the hash
+	future := time.Now().Add(time.Hour)
+	assert.NilError(t, os.Chtimes(filepath.Join(dir, "main.go"), future, future))
+	after, err = HashGoSources(dir)
+	assert.NilError(t, err)
+	assert.Equal(t, before, after)
+
+	// moving a go file changes the hash
+	assert.NilError(t, os.Rename(filepath.Join(dir, "pkg", "util", "util.go"), filepath.Join(dir, "pkg", "util.go")))
+	after, err = HashGoSources(dir)
+	assert.NilError(t, err)
+	assert.Assert(t, before != after)
+	assert.NilError(t, os.Rename(filepath.Join(dir, "pkg", "util.go"), filepath.Join(dir, "pkg", "util", "util.go")))
+
+	// changes to go files change the hash
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "pkg", "util", "util.go"), []byte("package util\n\nfunc A() {}"), 0644))
+	after, err = HashGoSources(dir)
+	assert.NilError(t, err)
+	assert.Assert(t, before != after)
+}
+
+func TestBuildArgs(t *testing.T) {
+	b := &Builder{
+		imageConf: &latest.Image{Ko: &latest.KoConfig{Main: "./cmd/server", Args: []string{"--sbom=none"}}},
+		imageTags: []string{"a", "b"},
+	}
+	assert.DeepEqual(t, b.buildArgs(false), []string{"build", "--bare", "--tags", "a,b", "--sbom=none", "./cmd/server"})
+	assert.DeepEqual(t, b.buildArgs(true), []string{"build", "--bare", "--tags", "a,b", "--local", "--sbom=none", "./cmd/server"})
+}
@@ -13,7 +13,9 @@ import (
 	v1 "github.com/google/go-containerregistry/pkg/v1"
 	"github.com/google/go-containerregistry/pkg/v1/daemon"
 	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
 	"github.com/loft-sh/devspace/pkg/devspace/build/localregistry"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	dockerclient "github.com/loft-sh/devspace/pkg/devspace/docker"
 	"github.com/pkg/errors"
@@ -24,7 +26,7 @@ import (
 	"github.com/moby/buildkit/session/upload/uploadprovider"
 )
 
-func RemoteBuild(ctx devspacecontext.Context, podName, namespace string, buildContext io.Reader, writer io.Writer, buildOptions *types.ImageBuildOptions) error {
+func RemoteBuild(ctx devspacecontext.Context, podName, namespace string, buildContext io.Reader, writer io.Writer, buildOptions *types.ImageBuildOptions, cacheFrom []*latest.BuildCacheLocation, cacheTo *latest.BuildCacheLocation, attachables []session.Attachable) error {
 	conn, err := ExecConn(ctx, namespace, podName, localregistry.BuildKitContainer, []string{"buildctl", "dial-stdio"})
 	if err != nil {
 		return errors.Wrap(err, "connect to buildkit pod")
@@ -53,7 +55,7 @@ func RemoteBuild(ctx devspacecontext.Context, podName, namespace string, buildCo
 			"target":   buildOptions.Target,
 			"context":  up.Add(buildContext),
 		},
-		Session: []session.Attachable{up, authprovider.NewDockerAuthProvider(dockerConfig)},
+		Session: append([]session.Attachable{up, authprovider.NewDockerAuthProvider(dockerConfig)}, attachables...),
 		Exports: []buildkit.ExportEntry{
 			{
 				Type: buildkit.ExporterImage,
@@ -72,6 +74,17 @@ func RemoteBuild(ctx devspacecontext.Context, podName, namespace string, buildCo
 		}
 		options.FrontendAttrs["build-arg:"+key] = *value
 	}
+	if buildOptions.Platform != "" {
+		options.FrontendAttrs["platform"] = buildOptions.Platform
+	}
+	for _, location := range cacheFrom {
+		cacheType, attrs := helper.BuildKitCacheAttrs(location, false)
+		options.CacheImports = append(options.CacheImports, buildkit.CacheOptionsEntry{Type: cacheType, Attrs: attrs})
+	}
+	if cacheTo != nil {
+		cacheType, attrs := helper.BuildKitCacheAttrs(cacheTo, true)
+		options.CacheExports = append(options.CacheExports, buildkit.CacheOptionsEntry{Type: cacheType, Attrs: attrs})
+	}
 
 	pw, err := NewPrinter(context.TODO(), writer)
 	if err != nil {
@@ -105,6 +118,13 @@ func LocalBuild(ctx devspacecontext.Context, contextPath, dockerfilePath string,
 	// make sure to use the correct proxy configuration
 	buildOptions.BuildArgs = dockerClient.ParseProxyConfig(buildOptions.BuildArgs)
 
+	// provide the secrets through a build session
+	closeSession, err := helper.StartSecretSession(ctx, dockerClient, b.helper.ImageConf, buildOptions)
+	if err != nil {
+		return err
+	}
+	defer closeSession()
+
 	response, err := dockerClient.ImageBuild(ctx.Context(), body, *buildOptions)
 	if err != nil {
 		return err
@@ -69,7 +69,13 @@ func (b *Builder) BuildImage(ctx devspacecontext.Context, contextPath string, do
 	}
 
 	// start the remote build
-	return RemoteBuild(ctx, builderPod.Name, builderPod.Namespace, body, writer, buildOptions)
+	cacheFrom, cacheTo := helper.ResolveCache(ctx, b.helper.ImageConf)
+	attachables, err := helper.SecretAttachables(ctx, b.helper.ImageConf)
+	if err != nil {
+		return err
+	}
+
+	return RemoteBuild(ctx, builderPod.Name, builderPod.Namespace, body, writer, buildOptions, cacheFrom, cacheTo, attachables)
 
 }
 
@@ -0,0 +1,252 @@
+package podman
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/pkg/archive"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	command2 "github.com/loft-sh/utils/pkg/command"
+	"github.com/pkg/errors"
+)
+
+const (
+	// EngineNamePodman is the name of the podman building engine
+	EngineNamePodman = "podman"
+	// EngineNameBuildah is the name of the buildah building engine
+	EngineNameBuildah = "buildah"
+)
+
+// Builder holds the necessary information to build and push images with podman or buildah
+type Builder struct {
+	helper                    *helper.BuildHelper
+	engineName                string
+	command                   []string
+	args                      []string
+	skipPush                  bool
+	skipPushOnLocalKubernetes bool
+}
+
+// NewBuilder creates a new podman or buildah Builder instance
+func NewBuilder(ctx devspacecontext.Context, imageConf *latest.Image, imageTags []string, skipPush, skipPushOnLocalKubernetes bool) (*Builder, error) {
+	engineName := EngineNamePodman
+	command := []string{"podman"}
+	args := []string{}
+	if imageConf.Buildah != nil {
+		engineName = EngineNameBuildah
+		command = []string{"buildah"}
+		if len(imageConf.Buildah.Command) > 0 {
+			command = imageConf.Buildah.Command
+		}
+		args = imageConf.Buildah.Args
+	} else if imageConf.Podman != nil {
+		if len(imageConf.Podman.Command) > 0 {
+			command = imageConf.Podman.Command
+		}
+		args = imageConf.Podman.Args
+	}
+
+	return &Builder{
+		helper:                    helper.NewBuildHelper(ctx, engineName, imageConf, imageTags),
+		engineName:                engineName,
+		command:                   command,
+		args:                      args,
+		skipPush:                  skipPush,
+		skipPushOnLocalKubernetes: skipPushOnLocalKubernetes,
+	}, nil
+}
+
+// Build implements the interface
+func (b *Builder) Build(ctx devspacecontext.Context) error {
+	return b.helper.Build(ctx, b)
+}
+
+// ShouldRebuild determines if an image has to be rebuilt
+func (b *Builder) ShouldRebuild(ctx devspacecontext.Context, forceRebuild bool) (bool, error) {
+	rebuild, err := b.helper.ShouldRebuild(ctx, forceRebuild)
+
+	// Check if image is present in local image storage
+	if !rebuild && err == nil && b.loadIntoCluster(ctx) {
+		imageCache, _ := ctx.Config().LocalCache().GetImageCache(b.helper.ImageConf.Name)
+		imageName := imageCache.ResolveImage() + ":" + imageCache.Tag
+		if !b.imageExists(ctx, imageName) {
+			ctx.Log().Infof("Rebuild image %s because it was not found in local %s storage", imageName, b.engineName)
+			return true, nil
+		}
+	}
+
+	return rebuild, err
+}
+
+// BuildImage builds an image with the podman or buildah cli
+// contextPath is the absolute path to the context path
+// dockerfilePath is the absolute path to the dockerfile WITHIN the contextPath
+func (b *Builder) BuildImage(ctx devspacecontext.Context, contextPath, dockerfilePath string, entrypoint []string, cmd []string) error {
+	// create the context stream, this takes care of the .dockerignore, entrypoint overrides,
+	// appended dockerfile instructions and the restart helper
+	body, writer, _, buildOptions, err := b.helper.CreateContextStream(contextPath, dockerfilePath, entrypoint, cmd, ctx.Log())
+	defer writer.Close()
+	if err != nil {
+		return err
+	}
+
+	// podman and buildah cannot read the build context from stdin, so we extract it into a temporary folder
+	tempDir, err := os.MkdirTemp("", "devspace-"+b.engineName+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	err = archive.Untar(body, tempDir, &archive.TarOptions{NoLchown: true})
+	if err != nil {
+		return errors.Wrap(err, "extract build context")
+	}
+
+	cacheFrom, cacheTo := helper.ResolveCache(ctx, b.helper.ImageConf)
+	args := b.buildArgs(*buildOptions, cacheFrom, cacheTo, tempDir)
+	ctx.Log().Infof("Execute %s command with: %s %s", b.engineName, strings.Join(b.command, " "), strings.Join(args, " "))
+	err = command2.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, writer, nil, b.command[0], append(append([]string{}, b.command[1:]...), args...)...)
+	if err != nil {
+		return errors.Errorf("error building image: %v", err)
+	}
+
+	// Check if we skip push
+	if b.loadIntoCluster(ctx) {
+		return b.loadImages(ctx, writer, buildOptions.Tags)
+	} else if !b.skipPush && !b.helper.ImageConf.SkipPush {
+		for _, tag := range buildOptions.Tags {
+			args := append(append([]string{}, b.command[1:]...), "push", tag)
+			err = command2.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, writer, nil, b.command[0], args...)
+			if err != nil {
+				return errors.Errorf("error during image push: %v", err)
+			}
+
+			ctx.Log().Info("Image pushed to registry (" + tag + ")")
+		}
+	} else {
+		ctx.Log().Infof("Skip image push for %s", b.helper.ImageName)
+	}
+
+	return nil
+}
+
+// buildArgs returns the arguments for podman build or buildah bud
+func (b *Builder) buildArgs(options types.ImageBuildOptions, cacheFrom []*latest.BuildCacheLocation, cacheTo *latest.BuildCacheLocation, contextDir string) []string {
+	args := []string{"build"}
+	if b.engineName == EngineNameBuildah {
+		args = []string{"bud"}
+	}
+
+	// sort the build args to pass them in the same order on every build
+	buildArgKeys := []string{}
+	for k, v := range options.BuildArgs {
+		if v != nil {
+			buildArgKeys = append(buildArgKeys, k)
+		}
+	}
+	sort.Strings(buildArgKeys)
+	for _, k := range buildArgKeys {
+		args = append(args, "--build-arg", k+"="+*options.BuildArgs[k])
+	}
+	if options.NetworkMode != "" {
+		args = append(args, "--network", options.NetworkMode)
+	}
+	for _, tag := range options.Tags {
+		args = append(args, "--tag", tag)
+	}
+	if options.Dockerfile != "" {
+		args = append(args, "--file", filepath.Join(contextDir, filepath.FromSlash(options.Dockerfile)))
+	}
+	if options.Target != "" {
+		args = append(args, "--target", options.Target)
+	}
+
+	// podman and buildah can only use repositories as cache
+	for _, location := range cacheFrom {
+		if location.Type == latest.BuildCacheTypeRegistry {
+			if repository, err := helper.CacheRepository(location); err == nil {
+				args = append(args, "--cache-from", repository)
+			}
+		}
+	}
+	if cacheTo != nil && cacheTo.Type == latest.BuildCacheTypeRegistry {
+		if repository, err := helper.CacheRepository(cacheTo); err == nil {
+			args = append(args, "--cache-to", repository)
+		}
+	}
+
+	args = append(args, b.args...)
+	return append(args, contextDir)
+}
+
+// loadIntoCluster returns true if the built images should be loaded into a local kind or minikube
+// cluster instead of being pushed
+func (b *Builder) loadIntoCluster(ctx devspacecontext.Context) bool {
+	if !b.skipPushOnLocalKubernetes || ctx.KubeClient() == nil {
+		return false
+	}
+
+	return kubectl.GetKindContext(ctx.KubeClient().CurrentContext()) != "" || kubectl.IsMinikubeKubernetes(ctx.KubeClient())
+}
+
+// loadImages saves the images into an archive and loads it into the kind or minikube cluster,
+// as both can't access the podman image storage directly
+func (b *Builder) loadImages(ctx devspacecontext.Context, writer io.Writer, tags []string) error {
+	tempDir, err := os.MkdirTemp("", "devspace-"+b.engineName+"-")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tempDir)
+
+	kindContext := kubectl.GetKindContext(ctx.KubeClient().CurrentContext())
+	for i, tag := range tags {
+		archivePath := filepath.Join(tempDir, fmt.Sprintf("image-%d.tar", i))
+		args := append(append([]string{}, b.command[1:]...), "save", "--format", "docker-archive", "--output", archivePath, tag)
+		if b.engineName == EngineNameBuildah {
+			args = append(append([]string{}, b.command[1:]...), "push", tag, "docker-archive:"+archivePath+":"+tag)
+		}
+		err = command2.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, writer, nil, b.command[0], args...)
+		if err != nil {
+			return errors.Errorf("error saving image %s: %v", tag, err)
+		}
+
+		if kindContext != "" {
+			err = command2.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, writer, nil, "kind", "load", "image-archive", "--name", kindContext, archivePath)
+			if err != nil {
+				ctx.Log().Info(errors.Errorf("error during image load to kind cluster: %v", err))
+				continue
+			}
+
+			ctx.Log().Info("Image loaded to kind cluster")
+		} else {
+			err = command2.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, writer, nil, "minikube", "image", "load", archivePath)
+			if err != nil {
+				ctx.Log().Info(errors.Errorf("error during image load to minikube cluster: %v", err))
+				continue
+			}
+
+			ctx.Log().Info("Image loaded to minikube cluster")
+		}
+	}
+
+	return nil
+}
+
+// imageExists checks if the image is present in the local image storage
+func (b *Builder) imageExists(ctx devspacecontext.Context, image string) bool {
+	args := append(append([]string{}, b.command[1:]...), "image", "exists", image)
+	if b.engineName == EngineNameBuildah {
+		args = append(append([]string{}, b.command[1:]...), "inspect", "--type", "image", image)
+	}
+
+	_, err := command2.CombinedOutput(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), b.command[0], args...)
+	return err == nil
+}
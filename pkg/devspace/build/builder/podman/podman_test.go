@@ -0,0 +1,33 @@
+package podman
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"gotest.tools/assert"
+)
+
+func TestBuildArgs(t *testing.T) {
+	options := types.ImageBuildOptions{
+		Tags:        []string{"test/test:abc"},
+		Dockerfile:  "Dockerfile",
+		BuildArgs:   map[string]*string{"D": ptr.String("e"), "A": ptr.String("b"), "C": nil, "B": ptr.String("c")},
+		Target:      "dev",
+		NetworkMode: "host",
+	}
+
+	b := &Builder{engineName: EngineNamePodman, args: []string{"--pull"}}
+	assert.DeepEqual(t, b.buildArgs(options, nil, nil, "ctx"), []string{
+		"build", "--build-arg", "A=b", "--build-arg", "B=c", "--build-arg", "D=e", "--network", "host", "--tag", "test/test:abc",
+		"--file", filepath.Join("ctx", "Dockerfile"), "--target", "dev", "--pull", "ctx",
+	})
+
+	b = &Builder{engineName: EngineNameBuildah}
+	cache := &latest.BuildCacheLocation{Type: latest.BuildCacheTypeRegistry, Ref: "test/test:buildcache"}
+	assert.DeepEqual(t, b.buildArgs(types.ImageBuildOptions{Tags: []string{"test/test:abc"}}, []*latest.BuildCacheLocation{cache}, cache, "ctx"), []string{
+		"bud", "--tag", "test/test:abc", "--cache-from", "docker.io/test/test", "--cache-to", "docker.io/test/test", "ctx",
+	})
+}
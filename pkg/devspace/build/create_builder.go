@@ -1,13 +1,17 @@
 package build
 
 import (
+	"context"
 	"fmt"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/buildkit"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/custom"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/docker"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/external"
 	"github.com/loft-sh/devspace/pkg/devspace/build/builder/kaniko"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/ko"
 	localregistry2 "github.com/loft-sh/devspace/pkg/devspace/build/builder/localregistry"
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/podman"
 	"github.com/loft-sh/devspace/pkg/devspace/build/localregistry"
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
@@ -34,11 +38,23 @@ func (c *controller) createBuilder(ctx devspacecontext.Context, imageConf *lates
 
 	if imageConf.Custom != nil {
 		bldr = custom.NewBuilder(imageConf, imageTags)
+	} else if imageConf.External != nil {
+		bldr = external.NewBuilder(imageConf, imageTags, options.SkipPush)
 	} else if imageConf.BuildKit != nil {
 		bldr, err = buildkit.NewBuilder(ctx, imageConf, imageTags, options.SkipPush, options.SkipPushOnLocalKubernetes)
 		if err != nil {
 			return nil, errors.Errorf("Error creating kaniko builder: %v", err)
 		}
+	} else if imageConf.Ko != nil {
+		bldr, err = ko.NewBuilder(ctx, nil, imageConf, imageTags, options.SkipPush, options.SkipPushOnLocalKubernetes)
+		if err != nil {
+			return nil, errors.Errorf("Error creating ko builder: %v", err)
+		}
+	} else if imageConf.Podman != nil || imageConf.Buildah != nil {
+		bldr, err = podman.NewBuilder(ctx, imageConf, imageTags, options.SkipPush, options.SkipPushOnLocalKubernetes)
+		if err != nil {
+			return nil, errors.Errorf("Error creating podman builder: %v", err)
+		}
 	} else if imageConf.Docker == nil && imageConf.Kaniko != nil {
 		if ctx.KubeClient() == nil {
 			// Create kubectl client if not specified
@@ -95,6 +111,10 @@ func localRegistryBuilder(ctx devspacecontext.Context, imageConf *latest.Image,
 	registryOptions := localregistry.NewDefaultOptions().
 		WithNamespace(ctx.KubeClient().Namespace()).
 		WithLocalRegistryConfig(ctx.Config().Config().LocalRegistry)
+	if imageConf.Ko != nil {
+		// ko builds locally and pushes to the registry via port forwarding
+		registryOptions = registryOptions.WithLocalBuild(true)
+	}
 
 	// Create and start a local registry if one isn't already running
 	localRegistry, err := localregistry.GetOrCreateLocalRegistry(ctx, registryOptions)
@@ -111,6 +131,16 @@ func localRegistryBuilder(ctx devspacecontext.Context, imageConf *latest.Image,
 	}
 	ctx.Config().LocalCache().SetImageCache(imageConf.Name, imageCache)
 
+	// ko pushes to the local registry by itself
+	if imageConf.Ko != nil {
+		err = localRegistry.EnsurePortForwarding(ctx.WithLogger(ctx.Log().WithPrefix("local-registry: ")).WithContext(context.Background()))
+		if err != nil {
+			return nil, errors.Wrap(err, "start local registry port forwarding")
+		}
+
+		return ko.NewBuilder(ctx, localRegistry, imageConf, imageTags, options.SkipPush, options.SkipPushOnLocalKubernetes)
+	}
+
 	// Create a local registry builder
 	bldr, err := localregistry2.NewBuilder(ctx, localRegistry, imageConf, imageTags, options.SkipPush, options.SkipPushOnLocalKubernetes)
 	if err != nil {
@@ -0,0 +1,150 @@
+package build
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/build/builder/helper"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/dockerfile"
+	"github.com/loft-sh/devspace/pkg/util/stringutil"
+	"github.com/pkg/errors"
+)
+
+var buildArgUnsafeRegEx = regexp.MustCompile(`[^A-Z0-9_]`)
+
+// DependencyBuildArg returns the name of the build arg that holds the image and tag
+// of the given image for images that depend on it, e.g. DEVSPACE_IMAGE_BASE
+func DependencyBuildArg(imageConfigName string) string {
+	return "DEVSPACE_IMAGE_" + buildArgUnsafeRegEx.ReplaceAllString(strings.ToUpper(imageConfigName), "_")
+}
+
+// resolveImageDependencies returns the names of the images each image depends on. Dependencies are
+// either defined explicitly via dependsOn or referenced within the FROM instructions of the Dockerfile.
+func resolveImageDependencies(ctx devspacecontext.Context, images map[string]*latest.Image) (map[string][]string, error) {
+	// strip the tags from the image names, so we can compare them with the FROM instructions
+	strippedImages := map[string]string{}
+	for name, imageConf := range images {
+		strippedImage, _, err := dockerfile.GetStrippedDockerImageName(imageConf.Image)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse image %s", imageConf.Image)
+		}
+
+		strippedImages[name] = strippedImage
+	}
+
+	dependencies := map[string][]string{}
+	for name, imageConf := range images {
+		imageDependencies := append([]string{}, imageConf.DependsOn...)
+
+		// custom, external and ko builds don't use a Dockerfile
+		if imageConf.Custom == nil && imageConf.External == nil && imageConf.Ko == nil {
+			// the Dockerfile of a remote context might not be available before the first build
+			dockerfilePath, _ := helper.GetDockerfileAndContext(ctx, imageConf)
+			fromImages, err := dockerfile.GetFromImages(dockerfilePath)
+			if err != nil && !os.IsNotExist(err) {
+				return nil, errors.Wrapf(err, "parse dockerfile of image %s", name)
+			}
+
+			for _, fromImage := range fromImages {
+				for otherName, strippedImage := range strippedImages {
+					if otherName == name || stringutil.Contains(imageDependencies, otherName) {
+						continue
+					}
+
+					if strings.Contains(fromImage, "$") {
+						if strings.Contains(fromImage, DependencyBuildArg(otherName)) {
+							imageDependencies = append(imageDependencies, otherName)
+						}
+						continue
+					}
+
+					strippedFromImage, _, err := dockerfile.GetStrippedDockerImageName(fromImage)
+					if err == nil && strippedFromImage == strippedImage {
+						imageDependencies = append(imageDependencies, otherName)
+					}
+				}
+			}
+		}
+
+		sort.Strings(imageDependencies)
+		dependencies[name] = imageDependencies
+	}
+
+	return dependencies, nil
+}
+
+// sortImages returns the given images in an order where each image comes after the images it
+// depends on. Dependencies that are not part of the given images are ignored.
+func sortImages(names []string, dependencies map[string][]string) ([]string, error) {
+	sortedNames := append([]string{}, names...)
+	sort.Strings(sortedNames)
+
+	var (
+		order   = []string{}
+		visited = map[string]bool{}
+		path    = []string{}
+		visit   func(name string) error
+	)
+	visit = func(name string) error {
+		for i, pathName := range path {
+			if pathName == name {
+				return errors.Errorf("cyclic image dependency found: %s", strings.Join(append(path[i:], name), " -> "))
+			}
+		}
+		if visited[name] {
+			return nil
+		}
+
+		path = append(path, name)
+		for _, dependency := range dependencies[name] {
+			if !stringutil.Contains(sortedNames, dependency) {
+				continue
+			}
+
+			err := visit(dependency)
+			if err != nil {
+				return err
+			}
+		}
+		path = path[:len(path)-1]
+
+		visited[name] = true
+		order = append(order, name)
+		return nil
+	}
+
+	for _, name := range sortedNames {
+		err := visit(name)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return order, nil
+}
+
+// injectDependencyBuildArgs passes the image and tag of the dependencies as build args to the image
+func injectDependencyBuildArgs(ctx devspacecontext.Context, imageConf *latest.Image, dependencies []string) {
+	for _, dependency := range dependencies {
+		imageCache, ok := ctx.Config().LocalCache().GetImageCache(dependency)
+		if !ok || imageCache.Tag == "" {
+			continue
+		}
+
+		// don't overwrite build args that were specified by the user
+		buildArg := DependencyBuildArg(dependency)
+		if _, ok := imageConf.BuildArgs[buildArg]; ok {
+			continue
+		}
+		if imageConf.BuildArgs == nil {
+			imageConf.BuildArgs = map[string]*string{}
+		}
+
+		value := imageCache.ResolveImage() + ":" + imageCache.Tag
+		imageConf.BuildArgs[buildArg] = &value
+	}
+}
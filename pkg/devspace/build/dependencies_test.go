@@ -0,0 +1,56 @@
+package build
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestResolveImageDependencies(t *testing.T) {
+	dir := t.TempDir()
+	err := os.WriteFile(filepath.Join(dir, "base.Dockerfile"), []byte("FROM alpine\n"), 0666)
+	assert.NilError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "Dockerfile"), []byte("FROM myregistry.com/base:latest\n"), 0666)
+	assert.NilError(t, err)
+	err = os.WriteFile(filepath.Join(dir, "tools.Dockerfile"), []byte("ARG DEVSPACE_IMAGE_MY_BASE\nFROM ${DEVSPACE_IMAGE_MY_BASE}\n"), 0666)
+	assert.NilError(t, err)
+
+	ctx := devspacecontext.NewContext(context.TODO(), nil, log.Discard).WithWorkingDir(dir)
+	dependencies, err := resolveImageDependencies(ctx, map[string]*latest.Image{
+		"my-base": {Image: "myregistry.com/base", Dockerfile: "base.Dockerfile"},
+		"backend": {Image: "myregistry.com/backend"},
+		"tools":   {Image: "myregistry.com/tools", Dockerfile: "tools.Dockerfile"},
+		"custom":  {Image: "myregistry.com/custom", DependsOn: []string{"tools"}, Custom: &latest.CustomConfig{Command: "build.sh"}},
+	})
+	assert.NilError(t, err)
+	assert.DeepEqual(t, dependencies, map[string][]string{
+		"my-base": {},
+		"backend": {"my-base"},
+		"tools":   {"my-base"},
+		"custom":  {"tools"},
+	})
+
+	order, err := sortImages([]string{"custom", "backend", "tools", "my-base"}, dependencies)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, order, []string{"my-base", "backend", "tools", "custom"})
+
+	// dependencies that are not built are ignored
+	order, err = sortImages([]string{"custom", "backend"}, dependencies)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, order, []string{"backend", "custom"})
+}
+
+func TestSortImagesCycle(t *testing.T) {
+	_, err := sortImages([]string{"a", "b", "c"}, map[string][]string{
+		"a": {"b"},
+		"b": {"c"},
+		"c": {"a"},
+	})
+	assert.Error(t, err, "cyclic image dependency found: a -> b -> c -> a")
+}
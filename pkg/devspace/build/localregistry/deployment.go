@@ -17,6 +17,9 @@ import (
 
 const BuildKitContainer = "buildkitd"
 
+// RegistryContainer is the name of the registry container in the local registry pod
+const RegistryContainer = "registry"
+
 func (r *LocalRegistry) ensureDeployment(ctx devspacecontext.Context) (*appsv1.Deployment, error) {
 	// Switching from a persistent registry, delete the statefulset.
 	_, err := ctx.KubeClient().KubeClient().AppsV1().StatefulSets(r.Namespace).Get(ctx.Context(), r.Name, metav1.GetOptions{})
@@ -193,11 +196,52 @@ func getContainers(registryImage, buildKitImage, volume string, port int32, loca
 	return append(buildKitContainer, buildContainers...)
 }
 
+// registryEnv is the environment of the registry container that garbage collection depends on
+var registryEnv = []corev1.EnvVar{
+	{
+		// allows deleting manifests during garbage collection
+		Name:  "REGISTRY_STORAGE_DELETE_ENABLED",
+		Value: "true",
+	},
+	{
+		// the inmemory blob descriptor cache would still serve blobs that were garbage collected
+		Name:  "REGISTRY_STORAGE_CACHE_BLOBDESCRIPTOR",
+		Value: "",
+	},
+}
+
+// missingRegistryEnv returns the names of the registry environment variables the registry container
+// of the pod is missing, e.g. because the registry was created by an older DevSpace version
+func missingRegistryEnv(pod *corev1.Pod) []string {
+	missing := []string{}
+	for _, container := range pod.Spec.Containers {
+		if container.Name != RegistryContainer {
+			continue
+		}
+
+		for _, expected := range registryEnv {
+			found := false
+			for _, env := range container.Env {
+				if env.Name == expected.Name && env.Value == expected.Value && env.ValueFrom == nil {
+					found = true
+					break
+				}
+			}
+			if !found {
+				missing = append(missing, expected.Name)
+			}
+		}
+	}
+
+	return missing
+}
+
 func getRegistryContainers(registryImage, volume string, port int32) []corev1.Container {
 	return []corev1.Container{
 		{
-			Name:  "registry",
+			Name:  RegistryContainer,
 			Image: registryImage,
+			Env:   append([]corev1.EnvVar{}, registryEnv...),
 			LivenessProbe: &corev1.Probe{
 				ProbeHandler: corev1.ProbeHandler{
 					HTTPGet: &corev1.HTTPGetAction{
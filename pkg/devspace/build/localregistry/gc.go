@@ -0,0 +1,214 @@
+package localregistry
+
+import (
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/remote/transport"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+)
+
+// registryConfigPath is the path of the configuration file within the registry image
+const registryConfigPath = "/etc/docker/registry/config.yml"
+
+// taggedManifest is a tag within a repository of the local registry
+type taggedManifest struct {
+	Tag     string
+	Digest  string
+	Created time.Time
+}
+
+// GarbageCollect deletes the manifests that are not retained anymore through the registry api and
+// removes the unreferenced blobs afterwards within the registry pod. Garbage collection should not
+// run while images are pushed to the registry, as blobs of an incomplete push might be removed.
+func (r *LocalRegistry) GarbageCollect(ctx devspacecontext.Context) error {
+	err := r.EnsurePortForwarding(ctx)
+	if err != nil {
+		return errors.Wrap(err, "ensure port forwarding")
+	}
+
+	// registries created by older versions neither allow deletes nor disable the blob cache
+	pod, err := r.SelectRegistryPod(ctx)
+	if err != nil {
+		return errors.Wrap(err, "select registry pod")
+	}
+	if missing := missingRegistryEnv(pod); len(missing) > 0 {
+		return r.recreateError(strings.Join(missing, ", "))
+	}
+
+	registry, err := name.NewRegistry(r.host)
+	if err != nil {
+		return err
+	}
+
+	repositories, err := remote.Catalog(ctx.Context(), registry)
+	if err != nil {
+		return errors.Wrap(err, "list repositories")
+	}
+
+	deleted := 0
+	now := time.Now()
+	for _, repositoryName := range repositories {
+		repository := registry.Repo(repositoryName)
+		manifests, err := r.listManifests(ctx, repository)
+		if err != nil {
+			return errors.Wrapf(err, "list tags of %s", repositoryName)
+		}
+
+		for _, digest := range expiredManifests(manifests, r.KeepTags, r.MaxAge, now) {
+			ctx.Log().Debugf("Delete %s@%s", repositoryName, digest)
+			err = remote.Delete(repository.Digest(digest), remote.WithContext(ctx.Context()))
+			if err != nil {
+				transportErr := &transport.Error{}
+				if errors.As(err, &transportErr) && transportErr.StatusCode == http.StatusMethodNotAllowed {
+					return r.recreateError("REGISTRY_STORAGE_DELETE_ENABLED")
+				}
+
+				return errors.Wrapf(err, "delete %s@%s", repositoryName, digest)
+			}
+
+			deleted++
+		}
+	}
+
+	// remove the blobs that are not referenced anymore
+	out, err := ctx.KubeClient().ExecBufferedCombined(ctx.Context(), pod, RegistryContainer, []string{"registry", "garbage-collect", "--delete-untagged", registryConfigPath}, nil)
+	if err != nil {
+		return errors.Errorf("error running garbage collection in registry pod: %v: %s", err, strings.TrimSpace(string(out)))
+	}
+
+	ctx.Log().Donef("Garbage collected local registry, deleted %d manifest(s)", deleted)
+	return nil
+}
+
+// recreateError tells the user to recreate a registry that doesn't support garbage collection
+func (r *LocalRegistry) recreateError(missing string) error {
+	kind := "deployment"
+	if r.StorageEnabled {
+		kind = "statefulset"
+	}
+
+	return errors.Errorf("local registry %s was created without garbage collection support (missing %s), please delete it via 'kubectl delete %s %s -n %s' so DevSpace recreates it", r.Name, missing, kind, r.Name, r.Namespace)
+}
+
+func (r *LocalRegistry) listManifests(ctx devspacecontext.Context, repository name.Repository) ([]taggedManifest, error) {
+	tags, err := remote.ListWithContext(ctx.Context(), repository)
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := []taggedManifest{}
+	for _, tag := range tags {
+		descriptor, err := remote.Get(repository.Tag(tag), remote.WithContext(ctx.Context()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "get manifest of tag %s", tag)
+		}
+
+		// tags without a creation date are only removed by the keepTags policy
+		created, err := createdAt(descriptor)
+		if err != nil {
+			ctx.Log().Debugf("Error retrieving creation date of %s:%s: %v", repository.Name(), tag, err)
+		}
+
+		manifests = append(manifests, taggedManifest{
+			Tag:     tag,
+			Digest:  descriptor.Digest.String(),
+			Created: created,
+		})
+	}
+
+	return manifests, nil
+}
+
+// createdAt returns the creation date of an image or of the first image within an index
+func createdAt(descriptor *remote.Descriptor) (time.Time, error) {
+	var (
+		image v1.Image
+		err   error
+	)
+	if descriptor.MediaType.IsIndex() {
+		index, err := descriptor.ImageIndex()
+		if err != nil {
+			return time.Time{}, err
+		}
+
+		indexManifest, err := index.IndexManifest()
+		if err != nil {
+			return time.Time{}, err
+		} else if len(indexManifest.Manifests) == 0 {
+			return time.Time{}, nil
+		}
+
+		image, err = index.Image(indexManifest.Manifests[0].Digest)
+		if err != nil {
+			return time.Time{}, err
+		}
+	} else {
+		image, err = descriptor.Image()
+		if err != nil {
+			return time.Time{}, err
+		}
+	}
+
+	configFile, err := image.ConfigFile()
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	return configFile.Created.Time, nil
+}
+
+// minCreated is the earliest plausible creation date of an image. Reproducible builds, e.g. with ko,
+// set the creation date to the unix epoch, so earlier dates are treated as unknown.
+var minCreated = time.Date(2013, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// expiredManifests returns the digests of the manifests that are not retained anymore. The newest keepTags
+// tags are kept, unless keepTags is negative, and tags older than maxAge are removed, unless maxAge is 0.
+// As deleting a manifest removes all of its tags, a manifest is only expired if none of its tags is retained.
+func expiredManifests(manifests []taggedManifest, keepTags int, maxAge time.Duration, now time.Time) []string {
+	sorted := make([]taggedManifest, 0, len(manifests))
+	for _, manifest := range manifests {
+		if manifest.Created.Before(minCreated) {
+			manifest.Created = time.Time{}
+		}
+
+		sorted = append(sorted, manifest)
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if !sorted[i].Created.Equal(sorted[j].Created) {
+			return sorted[i].Created.After(sorted[j].Created)
+		}
+
+		return sorted[i].Tag < sorted[j].Tag
+	})
+
+	retained := map[string]bool{}
+	expired := map[string]bool{}
+	for i, manifest := range sorted {
+		isExpired := keepTags >= 0 && i >= keepTags
+		if maxAge > 0 && !manifest.Created.IsZero() && now.Sub(manifest.Created) > maxAge {
+			isExpired = true
+		}
+
+		if isExpired {
+			expired[manifest.Digest] = true
+		} else {
+			retained[manifest.Digest] = true
+		}
+	}
+
+	digests := []string{}
+	for digest := range expired {
+		if !retained[digest] {
+			digests = append(digests, digest)
+		}
+	}
+	sort.Strings(digests)
+	return digests
+}
@@ -0,0 +1,81 @@
+package localregistry
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+type expiredManifestsTestCase struct {
+	name     string
+	keepTags int
+	maxAge   time.Duration
+	expected []string
+}
+
+func TestExpiredManifests(t *testing.T) {
+	now := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	manifests := []taggedManifest{
+		{Tag: "a", Digest: "sha256:1", Created: now.Add(-1 * time.Hour)},
+		{Tag: "b", Digest: "sha256:2", Created: now.Add(-48 * time.Hour)},
+		{Tag: "c", Digest: "sha256:3", Created: now.Add(-96 * time.Hour)},
+		// shares the manifest with tag a
+		{Tag: "d", Digest: "sha256:1", Created: now.Add(-1 * time.Hour)},
+		{Tag: "e", Digest: "sha256:4"},
+		// reproducible builds are created at the unix epoch
+		{Tag: "f", Digest: "sha256:5", Created: time.Unix(0, 0)},
+	}
+
+	testCases := []expiredManifestsTestCase{
+		{
+			name:     "Keep everything",
+			keepTags: -1,
+			expected: []string{},
+		},
+		{
+			name:     "Keep last two tags",
+			keepTags: 2,
+			expected: []string{"sha256:2", "sha256:3", "sha256:4", "sha256:5"},
+		},
+		{
+			name:     "Keep last three tags",
+			keepTags: 3,
+			expected: []string{"sha256:3", "sha256:4", "sha256:5"},
+		},
+		{
+			name:     "Max age keeps unknown creation dates",
+			keepTags: -1,
+			maxAge:   72 * time.Hour,
+			expected: []string{"sha256:3"},
+		},
+		{
+			name:     "Keep tags and max age",
+			keepTags: 4,
+			maxAge:   24 * time.Hour,
+			expected: []string{"sha256:2", "sha256:3", "sha256:4", "sha256:5"},
+		},
+	}
+
+	for _, testCase := range testCases {
+		expired := expiredManifests(manifests, testCase.keepTags, testCase.maxAge, now)
+		assert.DeepEqual(t, expired, testCase.expected)
+	}
+}
+
+func TestMissingRegistryEnv(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: getContainers(RegistryImage, BuildKitImage, "registry", int32(RegistryPort), false),
+		},
+	}
+	assert.DeepEqual(t, missingRegistryEnv(pod), []string{})
+
+	// registries created by older versions only have the delete env or none at all
+	pod.Spec.Containers[1].Env = []corev1.EnvVar{{Name: "REGISTRY_STORAGE_DELETE_ENABLED", Value: "true"}}
+	assert.DeepEqual(t, missingRegistryEnv(pod), []string{"REGISTRY_STORAGE_CACHE_BLOBDESCRIPTOR"})
+
+	pod.Spec.Containers[1].Env = nil
+	assert.DeepEqual(t, missingRegistryEnv(pod), []string{"REGISTRY_STORAGE_DELETE_ENABLED", "REGISTRY_STORAGE_CACHE_BLOBDESCRIPTOR"})
+}
@@ -3,6 +3,7 @@ package localregistry
 import (
 	"context"
 	"fmt"
+	"sort"
 	"sync"
 	"time"
 
@@ -33,6 +34,8 @@ type LocalRegistry struct {
 	Options
 	host        string
 	servicePort *corev1.ServicePort
+
+	portForwardingLock sync.Mutex
 }
 
 func GetOrCreateLocalRegistry(
@@ -87,6 +90,59 @@ func (r *LocalRegistry) Start(ctx devspacecontext.Context) error {
 		return errors.Wrap(err, "ensure service")
 	}
 
+	if err := r.connect(ctx); err != nil {
+		return err
+	}
+
+	if r.LocalBuild {
+		// In case of local builds, we'll need to start registry port forwarding
+		// in order to push images from local builds to cluster's registry
+		return r.EnsurePortForwarding(ctx)
+	}
+
+	return nil
+}
+
+// GetLocalRegistry returns the local registry with the given options without deploying it. If the
+// registry was not started within this process, DevSpace connects to the already deployed registry.
+func GetLocalRegistry(ctx devspacecontext.Context, options Options) (*LocalRegistry, error) {
+	localRegistriesLock.Lock()
+	defer localRegistriesLock.Unlock()
+
+	id := getID(options)
+	localRegistry := localRegistries[id]
+	if localRegistry == nil {
+		localRegistry = newLocalRegistry(options)
+		err := localRegistry.connect(ctx)
+		if err != nil {
+			return nil, err
+		}
+		localRegistries[id] = localRegistry
+	}
+
+	return localRegistry, nil
+}
+
+// GetStartedLocalRegistries returns the local registries that were started or connected to within this process
+func GetStartedLocalRegistries() []*LocalRegistry {
+	localRegistriesLock.Lock()
+	defer localRegistriesLock.Unlock()
+
+	ids := []string{}
+	for id := range localRegistries {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	registries := []*LocalRegistry{}
+	for _, id := range ids {
+		registries = append(registries, localRegistries[id])
+	}
+	return registries
+}
+
+// connect waits for the service and pod of an already deployed registry
+func (r *LocalRegistry) connect(ctx devspacecontext.Context) error {
 	// Wait for service to have a node port
 	ctx.Log().Debug("Wait for local registry node port to be assigned...")
 	var err error
@@ -98,6 +154,23 @@ func (r *LocalRegistry) Start(ctx devspacecontext.Context) error {
 	// Save registry host for rewriting images
 	r.host = fmt.Sprintf("localhost:%d", r.servicePort.NodePort)
 
+	// Select the registry pod
+	ctx.Log().Debug("Wait for running local registry pod...")
+	_, err = r.SelectRegistryPod(ctx)
+	if err != nil {
+		return errors.Wrap(err, "select registry pod")
+	}
+
+	return nil
+}
+
+// EnsurePortForwarding makes the local registry reachable on localhost, so that images
+// built on the local machine can be pushed to it. The port forwarding lives as long as the
+// context that is passed in.
+func (r *LocalRegistry) EnsurePortForwarding(ctx devspacecontext.Context) error {
+	r.portForwardingLock.Lock()
+	defer r.portForwardingLock.Unlock()
+
 	// Check if local registry is already available
 	ctx.Log().Debug("Check for running local registry")
 	isRegistryAvailable, err := r.ping(ctx.Context())
@@ -105,31 +178,25 @@ func (r *LocalRegistry) Start(ctx devspacecontext.Context) error {
 		return errors.Wrap(err, "ping local registry")
 	}
 
-	// Select the registry pod
-	ctx.Log().Debug("Wait for running local registry pod...")
-	imageRegistryPod, err := r.SelectRegistryPod(ctx)
-	if err != nil {
-		return errors.Wrap(err, "select registry pod")
-	}
-
-	if r.LocalBuild {
-		// In case of local builds, we'll need to start registry port forwarding
-		// in order to push images from local builds to cluster's registry
-		if !isRegistryAvailable {
-			// Start port forwarding
-			ctx.Log().Debug("Starting local registry port forwarding")
-			if err := r.startPortForwarding(ctx, imageRegistryPod); err != nil {
-				return errors.Wrap(err, "start port forwarding")
-			}
-		} else {
-			ctx.Log().Debug("Skip local registry port forwarding")
+	if !isRegistryAvailable {
+		imageRegistryPod, err := r.SelectRegistryPod(ctx)
+		if err != nil {
+			return errors.Wrap(err, "select registry pod")
 		}
 
-		// Wait for registry to be responsive
-		ctx.Log().Debug("Waiting for local registry to become ready...")
-		if err := r.waitForRegistry(ctx.Context()); err != nil {
-			return errors.Wrap(err, "wait for registry")
+		// Start port forwarding
+		ctx.Log().Debug("Starting local registry port forwarding")
+		if err := r.startPortForwarding(ctx, imageRegistryPod); err != nil {
+			return errors.Wrap(err, "start port forwarding")
 		}
+	} else {
+		ctx.Log().Debug("Skip local registry port forwarding")
+	}
+
+	// Wait for registry to be responsive
+	ctx.Log().Debug("Waiting for local registry to become ready...")
+	if err := r.waitForRegistry(ctx.Context()); err != nil {
+		return errors.Wrap(err, "wait for registry")
 	}
 
 	return nil
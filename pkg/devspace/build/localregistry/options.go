@@ -2,6 +2,7 @@ package localregistry
 
 import (
 	"path"
+	"time"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 )
@@ -24,6 +25,13 @@ type Options struct {
 	StorageEnabled   bool
 	StorageSize      string
 	StorageClassName string
+
+	// KeepTags is the number of most recent tags kept per repository during garbage collection, -1 keeps all tags
+	KeepTags int
+	// MaxAge is the age after which tags are removed during garbage collection, 0 keeps all tags
+	MaxAge time.Duration
+	// GCAfterBuild enables garbage collection after images were built
+	GCAfterBuild bool
 }
 
 func getID(o Options) string {
@@ -41,6 +49,9 @@ func NewDefaultOptions() Options {
 		StorageEnabled:   false,
 		StorageSize:      RegistryDefaultStorage,
 		StorageClassName: "",
+		KeepTags:         -1,
+		MaxAge:           0,
+		GCAfterBuild:     false,
 	}
 }
 
@@ -113,6 +124,22 @@ func (o Options) WithStorageSize(storageSize string) Options {
 	return newOptions
 }
 
+func (o Options) WithKeepTags(keepTags *int) Options {
+	newOptions := o
+	if keepTags != nil {
+		newOptions.KeepTags = *keepTags
+	}
+	return newOptions
+}
+
+func (o Options) WithMaxAge(maxAge time.Duration) Options {
+	newOptions := o
+	if maxAge != 0 {
+		newOptions.MaxAge = maxAge
+	}
+	return newOptions
+}
+
 func (o Options) WithLocalRegistryConfig(config *latest.LocalRegistryConfig) Options {
 	newOptions := o
 	if config != nil {
@@ -130,6 +157,15 @@ func (o Options) WithLocalRegistryConfig(config *latest.LocalRegistryConfig) Opt
 				WithStorageClassName(config.Persistence.StorageClassName).
 				WithStorageSize(config.Persistence.Size)
 		}
+
+		if config.Retention != nil {
+			// the max age is validated together with the config
+			maxAge, _ := time.ParseDuration(config.Retention.MaxAge)
+			newOptions = newOptions.
+				WithKeepTags(config.Retention.KeepTags).
+				WithMaxAge(maxAge)
+			newOptions.GCAfterBuild = config.Retention.GCAfterBuild
+		}
 	}
 	return newOptions
 }
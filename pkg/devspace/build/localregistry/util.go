@@ -65,6 +65,10 @@ func UseLocalRegistry(client kubectl.Client, config *latest.Config, imageConfig
 			return false
 		} else if imageConfig.Custom != nil {
 			return false
+		} else if imageConfig.External != nil {
+			return false
+		} else if imageConfig.Podman != nil || imageConfig.Buildah != nil {
+			return false
 		} else if imageConfig.BuildKit != nil && imageConfig.BuildKit.InCluster != nil {
 			return false
 		}
@@ -26,6 +26,7 @@ var Locations = []string{
 	"/dev/*/devImage",
 	"/dev/*/containers/*/replaceImage",
 	"/dev/*/containers/*/devImage",
+	"/dev/*/open/*/url",
 	"/dev/ports/*/imageSelector",
 	"/dev/sync/*/imageSelector",
 	"/dev/logs/*/selectors/*/imageSelector",
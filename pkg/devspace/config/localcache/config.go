@@ -6,6 +6,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/util/yamlutil"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	"github.com/loft-sh/devspace/pkg/util/encryption"
@@ -114,3 +115,9 @@ func cachePath(devSpaceConfigPath string) string {
 
 	return filepath.Join(fileDir, constants.DefaultCacheFolder, fmt.Sprintf("cache-%s", fileName))
 }
+
+// contextFilesPath returns the path where the hashed files of the build contexts are stored,
+// e.g. $PWD/.devspace/cache-contexts.json for $PWD/.devspace/cache.yaml
+func contextFilesPath(cachePath string) string {
+	return strings.TrimSuffix(cachePath, filepath.Ext(cachePath)) + "-contexts.json"
+}
@@ -2,10 +2,12 @@ package localcache
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
+	"github.com/loft-sh/devspace/pkg/util/hash"
 	yaml "gopkg.in/yaml.v3"
 	"gotest.tools/assert"
 )
@@ -166,3 +168,24 @@ func TestSave(t *testing.T) {
 		assert.Equal(t, string(fileContent), string(expectedAsYaml), "Unexpected config file in testCase %s", testCase.name)
 	}
 }
+
+func TestContextFiles(t *testing.T) {
+	dir := t.TempDir()
+	cache := New(filepath.Join(dir, constants.DefaultCacheFolder, "cache.yaml"))
+	assert.Equal(t, len(cache.GetContextFiles("context")), 0)
+
+	files := map[string]hash.FileEntry{
+		"Dockerfile": {Size: 10, ModTime: 1000, Hash: "abc"},
+	}
+	cache.SetContextFiles("context", files)
+	err := cache.Save()
+	assert.NilError(t, err)
+
+	// the context files are stored next to the cache
+	_, err = os.Stat(filepath.Join(dir, constants.DefaultCacheFolder, "cache-contexts.json"))
+	assert.NilError(t, err)
+
+	loaded, err := NewCacheLoader().Load(filepath.Join(dir, constants.DefaultConfigPath))
+	assert.NilError(t, err)
+	assert.DeepEqual(t, loaded.GetContextFiles("context"), files)
+}
@@ -2,6 +2,7 @@ package localcache
 
 import (
 	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -9,6 +10,7 @@ import (
 
 	"github.com/loft-sh/devspace/pkg/devspace/env"
 	"github.com/loft-sh/devspace/pkg/util/encryption"
+	"github.com/loft-sh/devspace/pkg/util/hash"
 	"gopkg.in/yaml.v3"
 )
 
@@ -23,6 +25,9 @@ type Cache interface {
 	GetData(key string) (string, bool)
 	SetData(key, value string)
 
+	GetContextFiles(key string) map[string]hash.FileEntry
+	SetContextFiles(key string, files map[string]hash.FileEntry)
+
 	GetVar(varName string) (string, bool)
 	SetVar(varName, value string)
 	ListVars() map[string]string
@@ -45,6 +50,11 @@ type LocalCache struct {
 	// Data is arbitrary key value cache
 	Data map[string]string `yaml:"data,omitempty"`
 
+	// contextFiles are the hashed files of the build contexts. Because these can get quite large
+	// they are stored in a separate file next to the cache and are only loaded when needed.
+	contextFiles        map[string]map[string]hash.FileEntry `yaml:"-" json:"-"`
+	contextFilesChanged bool                                 `yaml:"-" json:"-"`
+
 	// config path is the path where the cache was loaded from
 	cachePath   string     `yaml:"-" json:"-"`
 	accessMutex sync.Mutex `yaml:"-" json:"-"`
@@ -66,6 +76,12 @@ type ImageCache struct {
 
 	CustomFilesHash string `yaml:"customFilesHash,omitempty"`
 
+	// Platforms are the comma separated platforms the image was built for
+	Platforms string `yaml:"platforms,omitempty"`
+
+	// BuilderState is the opaque state an external builder returned after the last build
+	BuilderState string `yaml:"builderState,omitempty"`
+
 	ImageName              string `yaml:"imageName,omitempty"`
 	LocalRegistryImageName string `yaml:"localRegistryImageName,omitempty"`
 	Tag                    string `yaml:"tag,omitempty"`
@@ -139,6 +155,64 @@ func (l *LocalCache) SetData(key, value string) {
 	l.Data[key] = value
 }
 
+func (l *LocalCache) GetContextFiles(key string) map[string]hash.FileEntry {
+	l.accessMutex.Lock()
+	defer l.accessMutex.Unlock()
+
+	l.loadContextFiles()
+	return l.contextFiles[key]
+}
+
+func (l *LocalCache) SetContextFiles(key string, files map[string]hash.FileEntry) {
+	l.accessMutex.Lock()
+	defer l.accessMutex.Unlock()
+
+	l.loadContextFiles()
+	l.contextFiles[key] = files
+	l.contextFilesChanged = true
+}
+
+func (l *LocalCache) loadContextFiles() {
+	if l.contextFiles != nil {
+		return
+	}
+
+	l.contextFiles = map[string]map[string]hash.FileEntry{}
+	if l.cachePath == "" {
+		return
+	}
+
+	// if the file cannot be read, all files will be hashed again
+	data, err := os.ReadFile(contextFilesPath(l.cachePath))
+	if err == nil {
+		_ = json.Unmarshal(data, &l.contextFiles)
+	}
+}
+
+func (l *LocalCache) saveContextFiles() error {
+	if !l.contextFilesChanged {
+		return nil
+	}
+
+	data, err := json.Marshal(l.contextFiles)
+	if err != nil {
+		return err
+	}
+
+	err = os.MkdirAll(filepath.Dir(l.cachePath), 0755)
+	if err != nil {
+		return err
+	}
+
+	err = os.WriteFile(contextFilesPath(l.cachePath), data, 0666)
+	if err != nil {
+		return err
+	}
+
+	l.contextFilesChanged = false
+	return nil
+}
+
 func (l *LocalCache) GetVar(varName string) (string, bool) {
 	l.accessMutex.Lock()
 	defer l.accessMutex.Unlock()
@@ -193,6 +267,11 @@ func (l *LocalCache) Save() error {
 	l.accessMutex.Lock()
 	defer l.accessMutex.Unlock()
 
+	err := l.saveContextFiles()
+	if err != nil {
+		return err
+	}
+
 	data, err := yaml.Marshal(l)
 	if err != nil {
 		return err
@@ -112,6 +112,20 @@ type HelmCache struct {
 type KubectlCache struct {
 	Objects       []KubectlObject `yaml:"kubectlObjects,omitempty"`
 	ManifestsHash string          `yaml:"kubectlManifestsHash,omitempty"`
+
+	// Revisions are the last deployed manifest sets, oldest first
+	Revisions []KubectlRevision `yaml:"revisions,omitempty"`
+}
+
+type KubectlRevision struct {
+	Revision int `yaml:"revision"`
+
+	// Manifests are the gzip compressed and base64 encoded applied manifests
+	Manifests string          `yaml:"manifests"`
+	Objects   []KubectlObject `yaml:"objects,omitempty"`
+
+	// Deployed is the unix timestamp when the revision was deployed
+	Deployed int64 `yaml:"deployed,omitempty"`
 }
 
 type KubectlObject struct {
@@ -248,12 +248,21 @@ type Image struct {
 	Tags []string `yaml:"tags,omitempty" json:"tags,omitempty"`
 
 	// Dockerfile specifies a path (relative or absolute) to the dockerfile. Defaults
-	// to ./Dockerfile
-	Dockerfile string `yaml:"dockerfile,omitempty" json:"dockerfile,omitempty" jsonschema:"default=./Dockerfile" jsonschema_extras:"group=buildConfig" jsonschema_description:"Dockerfile specifies a path (relative or absolute) to the dockerfile. Defaults to ./Dockerfile."`
-
-	// Context is the context path to build with. Defaults to the current working directory
+	// to ./Dockerfile. For git and archive contexts, relative paths are resolved within the context.
+	Dockerfile string `yaml:"dockerfile,omitempty" json:"dockerfile,omitempty" jsonschema:"default=./Dockerfile" jsonschema_extras:"group=buildConfig" jsonschema_description:"Dockerfile specifies a path (relative or absolute) to the dockerfile. Defaults to ./Dockerfile. For git and archive contexts, relative paths are resolved within the context."`
+
+	// Context is the context path to build with. Defaults to the current working directory.
+	// Can also be a git repository in the form git+https://github.com/org/repo.git#ref:subdir
+	// or a local .tar, .tar.gz, .tgz, .tar.bz2 or .tar.xz archive. Remote contexts are cloned
+	// or extracted to ~/.devspace/contexts and are only rebuilt if the resolved commit or the
+	// archive changes.
 	Context string `yaml:"context,omitempty" json:"context,omitempty" jsonschema:"default=./" jsonschema_extras:"group=buildConfig"`
 
+	// DependsOn are the names of other images that need to be built before this image. DevSpace will also
+	// detect images that are referenced within FROM instructions of the Dockerfile automatically. The image
+	// and tag of each dependency is passed as build arg DEVSPACE_IMAGE_NAME, e.g. DEVSPACE_IMAGE_BASE
+	DependsOn []string `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty" jsonschema_extras:"group=buildConfig"`
+
 	// Entrypoint specifies an entrypoint that will be appended to the dockerfile during
 	// image build in memory. Example: ["sleep", "99999"]
 	Entrypoint []string `yaml:"entrypoint,omitempty" json:"entrypoint,omitempty" jsonschema_extras:"group=overwrites,group_name=In-Memory Overwrites"`
@@ -275,6 +284,24 @@ type Image struct {
 	// Network is the network that should get used to build the image
 	Network string `yaml:"network,omitempty" json:"network,omitempty" jsonschema_extras:"group=buildConfig"`
 
+	// Secrets are build secrets that can be used within the Dockerfile via RUN --mount=type=secret,id=NAME.
+	// The secret values are never written into the build context or the logs. Only works with buildKit, docker
+	// and kaniko. Kaniko doesn't support secret mounts, so the secrets are mounted to /run/secrets/NAME instead.
+	Secrets map[string]*BuildSecret `yaml:"secrets,omitempty" json:"secrets,omitempty" jsonschema_extras:"group=buildConfig"`
+
+	// SSH are ssh agents or keys that can be used within the Dockerfile via RUN --mount=type=ssh. Only works
+	// with buildKit and docker.
+	SSH []*BuildSSH `yaml:"ssh,omitempty" json:"ssh,omitempty" jsonschema_extras:"group=buildConfig"`
+
+	// Cache configures a layer cache that is shared between builds, e.g. between CI and local machines.
+	// An empty cache object will import from and export to a registry cache at IMAGE:buildcache.
+	Cache *BuildCacheConfig `yaml:"cache,omitempty" json:"cache,omitempty" jsonschema_extras:"group=buildConfig"`
+
+	// Platforms are the platforms the image should be built for, e.g. linux/amd64 and linux/arm64. If more
+	// than one platform is specified, a manifest list is pushed. Only works with buildKit, docker and kaniko,
+	// the docker builder uses docker buildx to build more than one platform. If empty, DevSpace will use the arch of the dev containers that use this image.
+	Platforms []string `yaml:"platforms,omitempty" json:"platforms,omitempty" jsonschema_extras:"group=buildConfig"`
+
 	// RebuildStrategy is used to determine when DevSpace should rebuild an image. By default, devspace will
 	// rebuild an image if one of the following conditions is true:
 	// - The dockerfile has changed
@@ -300,10 +327,25 @@ type Image struct {
 	// Kaniko if kaniko is specified, DevSpace will build the image in-cluster with kaniko
 	Kaniko *KanikoConfig `yaml:"kaniko,omitempty" json:"kaniko,omitempty" jsonschema_extras:"group=engines"`
 
+	// Podman if podman is specified, DevSpace will build the image with the podman cli. This works
+	// with rootless podman and doesn't require a docker daemon.
+	Podman *PodmanConfig `yaml:"podman,omitempty" json:"podman,omitempty" jsonschema_extras:"group=engines"`
+
+	// Buildah if buildah is specified, DevSpace will build the image with the buildah cli
+	Buildah *BuildahConfig `yaml:"buildah,omitempty" json:"buildah,omitempty" jsonschema_extras:"group=engines"`
+
+	// Ko if ko is specified, DevSpace will build the go application within the image context with ko into
+	// a distroless image. No Dockerfile is needed for this.
+	Ko *KoConfig `yaml:"ko,omitempty" json:"ko,omitempty" jsonschema_extras:"group=engines"`
+
 	// Custom if custom is specified, DevSpace will build the image with the help of
 	// a custom script.
 	Custom *CustomConfig `yaml:"custom,omitempty" json:"custom,omitempty" jsonschema_extras:"group=engines"`
 
+	// External if external is specified, DevSpace will build the image with a builder that implements
+	// the DevSpace external builder protocol, either provided by a plugin or by a local executable.
+	External *ExternalConfig `yaml:"external,omitempty" json:"external,omitempty" jsonschema_extras:"group=engines"`
+
 	// InjectRestartHelper will inject a small restart script into the container and wraps the entrypoint of that
 	// container, so that devspace is able to restart the complete container during sync.
 	// Please make sure you either have an Entrypoint defined in the devspace config or in the
@@ -320,6 +362,64 @@ type Image struct {
 	RestartHelperPath string `yaml:"restartHelperPath,omitempty" json:"restartHelperPath,omitempty" jsonschema:"-"`
 }
 
+// BuildSecret defines where the value of a build secret is taken from. Exactly one of file, env
+// or value must be specified.
+type BuildSecret struct {
+	// File is the path to a local file that contains the secret
+	File string `yaml:"file,omitempty" json:"file,omitempty"`
+
+	// Env is the name of a local environment variable that contains the secret
+	Env string `yaml:"env,omitempty" json:"env,omitempty"`
+
+	// Value is the secret itself, which is usually a DevSpace variable, e.g. ${NPM_TOKEN}
+	Value string `yaml:"value,omitempty" json:"value,omitempty"`
+}
+
+// BuildSSH defines an ssh agent or ssh keys that are forwarded to the build
+type BuildSSH struct {
+	// ID of the ssh mount that is referenced in RUN --mount=type=ssh,id=ID. Defaults to default
+	ID string `yaml:"id,omitempty" json:"id,omitempty"`
+
+	// Keys are paths to local private keys that are forwarded. If empty, the local ssh agent
+	// (SSH_AUTH_SOCK) is forwarded instead
+	Keys []string `yaml:"keys,omitempty" json:"keys,omitempty"`
+}
+
+// BuildCacheConfig defines where the layer cache of an image is imported from and exported to
+type BuildCacheConfig struct {
+	// From are the caches to import layers from. Defaults to the cache defined in to
+	From []*BuildCacheLocation `yaml:"from,omitempty" json:"from,omitempty"`
+
+	// To is the cache to export layers to after a build
+	To *BuildCacheLocation `yaml:"to,omitempty" json:"to,omitempty"`
+}
+
+// BuildCacheType is the type of a build cache
+type BuildCacheType string
+
+// List of values that the build cache type can take
+const (
+	BuildCacheTypeRegistry BuildCacheType = "registry"
+	BuildCacheTypeLocal    BuildCacheType = "local"
+	BuildCacheTypeInline   BuildCacheType = "inline"
+)
+
+// BuildCacheLocation defines a single build cache
+type BuildCacheLocation struct {
+	// Type of the cache, either registry, local or inline. Defaults to registry
+	Type BuildCacheType `yaml:"type,omitempty" json:"type,omitempty" jsonschema:"enum=registry,enum=local,enum=inline"`
+
+	// Ref is the image reference of a registry cache. Defaults to IMAGE:buildcache for registry caches
+	// and to the image itself for inline caches
+	Ref string `yaml:"ref,omitempty" json:"ref,omitempty"`
+
+	// Path is the local directory of a local cache
+	Path string `yaml:"path,omitempty" json:"path,omitempty"`
+
+	// Mode is the cache export mode, either min or max. Defaults to max for registry and local caches
+	Mode string `yaml:"mode,omitempty" json:"mode,omitempty" jsonschema:"enum=min,enum=max"`
+}
+
 // RebuildStrategy is the type of a image rebuild strategy
 type RebuildStrategy string
 
@@ -361,6 +461,40 @@ type BuildKitConfig struct {
 	Command []string `yaml:"command,omitempty" json:"command,omitempty"`
 }
 
+// PodmanConfig tells the DevSpace CLI to build with podman
+type PodmanConfig struct {
+	// Command to override the base command to build and push images. Defaults to ["podman"]. Use
+	// ["podman", "--remote"] to build via the podman socket API.
+	Command []string `yaml:"command,omitempty" json:"command,omitempty"`
+
+	// Args are additional arguments to call podman build with
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+}
+
+// BuildahConfig tells the DevSpace CLI to build with buildah
+type BuildahConfig struct {
+	// Command to override the base command to build and push images. Defaults to ["buildah"]
+	Command []string `yaml:"command,omitempty" json:"command,omitempty"`
+
+	// Args are additional arguments to call buildah bud with
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+}
+
+// KoConfig tells the DevSpace CLI to build with ko
+type KoConfig struct {
+	// Main is the go package of the application, relative to the image context. Defaults to "."
+	Main string `yaml:"main,omitempty" json:"main,omitempty"`
+
+	// BaseImage overrides the base image ko uses, which defaults to a distroless image
+	BaseImage string `yaml:"baseImage,omitempty" json:"baseImage,omitempty"`
+
+	// Command to override the base command to build images. Defaults to ["ko"]
+	Command []string `yaml:"command,omitempty" json:"command,omitempty"`
+
+	// Args are additional arguments to call ko build with
+	Args []string `yaml:"args,omitempty" json:"args,omitempty"`
+}
+
 // BuildKitInClusterConfig holds the buildkit builder config
 type BuildKitInClusterConfig struct {
 	// Name is the name of the builder to use. If omitted, DevSpace will try to create
@@ -594,6 +728,18 @@ type CustomConfigCommand struct {
 	OperatingSystem string `yaml:"os,omitempty" json:"os,omitempty"`
 }
 
+// ExternalConfig tells the DevSpace CLI to build with an external builder. The builder receives
+// the resolved image configuration as JSON on stdin and reports logs and build results as JSON on stdout.
+type ExternalConfig struct {
+	// Builder is the name of a builder that is provided by an installed DevSpace plugin
+	Builder string `yaml:"builder,omitempty" json:"builder,omitempty"`
+	// Command is the path to a local executable that implements the external builder protocol. Can be used
+	// instead of builder to develop or test a builder without packaging it as a plugin.
+	Command string `yaml:"command,omitempty" json:"command,omitempty"`
+	// Options are arbitrary builder specific options that are passed to the builder
+	Options map[string]interface{} `yaml:"options,omitempty" json:"options,omitempty"`
+}
+
 // LocalRegistryConfig holds the configuration of the local image registry
 type LocalRegistryConfig struct {
 	// Enabled enables the local registry for pushing images.
@@ -622,6 +768,21 @@ type LocalRegistryConfig struct {
 
 	// Persistence settings for the local registry
 	Persistence *LocalRegistryPersistence `yaml:"persistence,omitempty" json:"persistence,omitempty"`
+
+	// Retention settings that define which images are removed when the local registry is garbage collected
+	Retention *LocalRegistryRetention `yaml:"retention,omitempty" json:"retention,omitempty"`
+}
+
+// LocalRegistryRetention configures which images are kept in the local registry
+type LocalRegistryRetention struct {
+	// KeepTags is the number of most recent tags that are kept per repository. Default is to keep all tags
+	KeepTags *int `yaml:"keepTags,omitempty" json:"keepTags,omitempty"`
+
+	// MaxAge removes tags that were built longer ago than the given duration, e.g. `72h`. Default is to keep all tags
+	MaxAge string `yaml:"maxAge,omitempty" json:"maxAge,omitempty"`
+
+	// GCAfterBuild garbage collects the local registry every time after images were built
+	GCAfterBuild bool `yaml:"gcAfterBuild,omitempty" json:"gcAfterBuild,omitempty"`
 }
 
 // LocalRegistryPersistence configures persistence settings for the local registry
@@ -645,6 +806,8 @@ type DeploymentConfig struct {
 	Helm *HelmConfig `yaml:"helm,omitempty" json:"helm,omitempty"`
 	// Kubectl tells DevSpace to deploy this deployment via kubectl or kustomize
 	Kubectl *KubectlConfig `yaml:"kubectl,omitempty" json:"kubectl,omitempty"`
+	// Jsonnet tells DevSpace to evaluate jsonnet files and deploy the resulting manifests via kubectl
+	Jsonnet *JsonnetConfig `yaml:"jsonnet,omitempty" json:"jsonnet,omitempty"`
 
 	// UpdateImageTags lets you define if DevSpace should update the tags of the images defined in the
 	// images section with their most recent built tag.
@@ -652,6 +815,30 @@ type DeploymentConfig struct {
 
 	// Namespace where to deploy this deployment
 	Namespace string `yaml:"namespace,omitempty" json:"namespace,omitempty"`
+
+	// Wave defines the order in which deployments are deployed. Deployments with a lower wave are deployed and ready
+	// before deployments with a higher wave are deployed, which means their workloads are rolled out, their custom
+	// resource definitions are established and their namespaces are active. Purging happens in reverse order. Default is 0.
+	Wave int `yaml:"wave,omitempty" json:"wave,omitempty"`
+
+	// DependsOn are other deployments that have to be deployed and ready before this deployment is deployed.
+	// Dependencies are only considered if they are deployed together with this deployment.
+	DependsOn []string `yaml:"dependsOn,omitempty" json:"dependsOn,omitempty"`
+
+	// Wait tells DevSpace to wait after deploying until the workloads of this deployment are rolled out and ready
+	Wait *DeploymentWaitConfig `yaml:"wait,omitempty" json:"wait,omitempty"`
+}
+
+// DeploymentWaitConfig defines how DevSpace waits for the workloads of a deployment
+type DeploymentWaitConfig struct {
+	// Enabled can be used to enable waiting for the workloads. Defaults to true if the wait section is specified
+	Enabled *bool `yaml:"enabled,omitempty" json:"enabled,omitempty"`
+
+	// Timeout is how long to wait (in seconds) for the workloads to become ready. Default is 300 seconds.
+	Timeout int64 `yaml:"timeout,omitempty" json:"timeout,omitempty" jsonschema:"default=300"`
+
+	// DisableAnalyze prevents DevSpace from analyzing the workloads if they do not become ready
+	DisableAnalyze bool `yaml:"disableAnalyze,omitempty" json:"disableAnalyze,omitempty"`
 }
 
 // ComponentConfig holds the component information
@@ -840,7 +1027,10 @@ type HelmConfig struct {
 	Chart *ChartConfig `yaml:"chart,omitempty" json:"chart,omitempty" jsonschema:"required"`
 	// Values are additional values that should get passed to deploying this chart
 	Values map[string]interface{} `yaml:"values,omitempty" json:"values,omitempty"`
-	// ValuesFiles are additional files that hold values for deploying this chart
+	// ValuesFiles are additional files that hold values for deploying this chart. Files encrypted with sops are
+	// decrypted in memory with the age keys from SOPS_AGE_KEY, SOPS_AGE_KEY_FILE or the default sops keys file, the
+	// pgp keys from DEVSPACE_SOPS_PGP_KEY, DEVSPACE_SOPS_PGP_KEY_FILE or the gpg keyring, which requires gpg, and the
+	// other key types sops supports, like cloud KMS keys
 	ValuesFiles []string `yaml:"valuesFiles,omitempty" json:"valuesFiles,omitempty"`
 	// DisplayOutput allows you to display the helm output to the console
 	DisplayOutput bool `yaml:"displayOutput,omitempty" json:"output,omitempty"`
@@ -850,6 +1040,13 @@ type HelmConfig struct {
 	// TemplateArgs are additional arguments to pass to `helm template`
 	TemplateArgs []string `yaml:"templateArgs,omitempty" json:"templateArgs,omitempty"`
 
+	// Patches are additional changes to the rendered manifests of the chart. They are applied via a helm post renderer
+	// and can be used to modify charts that don't expose the needed values
+	Patches []*PatchTarget `yaml:"patches,omitempty" json:"patches,omitempty"`
+	// ReplaceImageTags replaces the images built by DevSpace within the rendered manifests of the chart via a helm
+	// post renderer, for charts that don't expose all images as values
+	ReplaceImageTags bool `yaml:"replaceImageTags,omitempty" json:"replaceImageTags,omitempty"`
+
 	// DisableDependencyUpdate disables helm dependencies update, default to false
 	DisableDependencyUpdate *bool `yaml:"disableDependencyUpdate,omitempty" json:"disableDependencyUpdate,omitempty"`
 }
@@ -874,7 +1071,8 @@ type ChartConfig struct {
 // KubectlConfig defines the specific kubectl options used during deployment
 type KubectlConfig struct {
 	// Manifests is a list of files or folders that will be deployed by DevSpace using kubectl
-	// or kustomize
+	// or kustomize. Files encrypted with sops are decrypted in memory, see valuesFiles of helm for the keys.
+	// The kustomize binary can't decrypt files, so encrypted resources require the built-in kustomize
 	Manifests []string `yaml:"manifests,omitempty" json:"manifests,omitempty" jsonschema:"required"`
 	// ApplyArgs are extra arguments for `kubectl apply`
 	ApplyArgs []string `yaml:"applyArgs,omitempty" json:"applyArgs,omitempty"`
@@ -882,20 +1080,68 @@ type KubectlConfig struct {
 	CreateArgs []string `yaml:"createArgs,omitempty" json:"createArgs,omitempty"`
 	// KubectlBinaryPath is the optional path where to find the kubectl binary
 	KubectlBinaryPath string `yaml:"kubectlBinaryPath,omitempty" json:"kubectlBinaryPath,omitempty"`
-
-	// InlineManifests is a block containing the manifest to deploy
+	// ServerSideApply applies the manifests in-process via server side apply with the field manager `devspace`
+	// instead of running `kubectl apply`. ApplyArgs are ignored if enabled
+	ServerSideApply bool `yaml:"serverSideApply,omitempty" json:"serverSideApply,omitempty"`
+	// Prune deletes objects that were deployed previously but are not part of the manifests anymore.
+	// Defaults to true if serverSideApply is enabled
+	Prune *bool `yaml:"prune,omitempty" json:"prune,omitempty"`
+	// RevisionHistoryLimit is the number of rendered manifest sets DevSpace keeps in the cluster to roll back to
+	// with `devspace rollback`. Set to 0 to disable the revision history. Default is 5. Like helm releases, the
+	// revisions are stored in the DevSpace remote cache secret of the namespace and include the data of Secrets
+	// and of files decrypted with sops
+	RevisionHistoryLimit *int `yaml:"revisionHistoryLimit,omitempty" json:"revisionHistoryLimit,omitempty" jsonschema:"default=5"`
+
+	// InlineManifests is a block containing the manifest to deploy. Manifests encrypted with sops are decrypted
+	// in memory, see valuesFiles of helm for the keys
 	InlineManifest string `yaml:"inlineManifest,omitempty" json:"inlineManifest,omitempty"`
-	// Kustomize can be used to enable kustomize instead of kubectl
+	// Kustomize can be used to enable kustomize instead of kubectl. Kustomizations are built with the built-in
+	// kustomize, remote git bases are cloned into the local DevSpace git cache
 	Kustomize *bool `yaml:"kustomize,omitempty" json:"kustomize,omitempty" jsonschema_extras:"group=kustomize,group_name=Kustomize"`
-	// KustomizeArgs are extra arguments for `kustomize build` which will be run before `kubectl apply`
+	// KustomizeArgs are extra arguments for `kustomize build` which will be run before `kubectl apply`. If the
+	// built-in kustomize doesn't support an argument, the kustomize binary is used instead
 	KustomizeArgs []string `yaml:"kustomizeArgs,omitempty" json:"kustomizeArgs,omitempty" jsonschema_extras:"group=kustomize"`
-	// KustomizeBinaryPath is the optional path where to find the kustomize binary
+	// KustomizeBinaryPath is the optional path where to find the kustomize binary. If set, the kustomize binary
+	// is used instead of the built-in kustomize
 	KustomizeBinaryPath string `yaml:"kustomizeBinaryPath,omitempty" json:"kustomizeBinaryPath,omitempty" jsonschema_extras:"group=kustomize"`
 
 	// Patches are additional changes to the pod spec that should be applied
 	Patches []*PatchTarget `yaml:"patches,omitempty" json:"patches,omitempty" jsonschema_extras:"group=modifications"`
 }
 
+// JsonnetConfig defines the options to deploy manifests that are rendered from jsonnet files
+type JsonnetConfig struct {
+	// Files are the jsonnet files to evaluate. Each file has to evaluate to a Kubernetes object, an array of objects
+	// or an object of objects. DevSpace variables are passed as external variables and the images with their most
+	// recent built tags as the external variable DEVSPACE_IMAGES
+	Files []string `yaml:"files,omitempty" json:"files,omitempty" jsonschema:"required"`
+	// JPaths are additional library search paths for imports
+	JPaths []string `yaml:"jpaths,omitempty" json:"jpaths,omitempty"`
+	// ExtVars are additional external variables. Strings are passed as external string variables, other values as code
+	ExtVars map[string]interface{} `yaml:"extVars,omitempty" json:"extVars,omitempty"`
+	// TLAs are top level arguments for the jsonnet files. Strings are passed as string arguments, other values as code
+	TLAs map[string]interface{} `yaml:"tlas,omitempty" json:"tlas,omitempty"`
+
+	// ApplyArgs are extra arguments for `kubectl apply`
+	ApplyArgs []string `yaml:"applyArgs,omitempty" json:"applyArgs,omitempty" jsonschema_extras:"group=apply,group_name=Apply"`
+	// KubectlBinaryPath is the optional path where to find the kubectl binary
+	KubectlBinaryPath string `yaml:"kubectlBinaryPath,omitempty" json:"kubectlBinaryPath,omitempty" jsonschema_extras:"group=apply"`
+	// ServerSideApply applies the manifests in-process via server side apply with the field manager `devspace`
+	// instead of running `kubectl apply`. ApplyArgs are ignored if enabled
+	ServerSideApply bool `yaml:"serverSideApply,omitempty" json:"serverSideApply,omitempty" jsonschema_extras:"group=apply"`
+	// Prune deletes objects that were deployed previously but are not part of the manifests anymore.
+	// Defaults to true if serverSideApply is enabled
+	Prune *bool `yaml:"prune,omitempty" json:"prune,omitempty" jsonschema_extras:"group=apply"`
+	// RevisionHistoryLimit is the number of rendered manifest sets DevSpace keeps in the cluster to roll back to
+	// with `devspace rollback`. Set to 0 to disable the revision history. Default is 5. Like helm releases, the
+	// revisions are stored in the DevSpace remote cache secret of the namespace and include the data of Secrets
+	// and of files decrypted with sops
+	RevisionHistoryLimit *int `yaml:"revisionHistoryLimit,omitempty" json:"revisionHistoryLimit,omitempty" jsonschema:"default=5" jsonschema_extras:"group=apply"`
+
+	// Patches are additional changes to the rendered manifests that should be applied
+	Patches []*PatchTarget `yaml:"patches,omitempty" json:"patches,omitempty" jsonschema_extras:"group=modifications,group_name=Modifications"`
+}
+
 // DevPod holds configurations for selecting a pod and starting dev services for that pod
 type DevPod struct {
 	// Name of the dev configuration
@@ -955,6 +1201,9 @@ type DevContainer struct {
 
 	// ReversePorts are port mappings to make local ports available inside the container
 	ReversePorts []*PortMapping `yaml:"reversePorts,omitempty" json:"reversePorts,omitempty" jsonschema_extras:"group=ports,group_name=Port Forwarding"`
+	// ReverseSockets are local unix sockets (or named pipes on Windows) that should be made available inside the container,
+	// such as the local Docker socket or an ssh-agent socket
+	ReverseSockets []*SocketMapping `yaml:"reverseSockets,omitempty" json:"reverseSockets,omitempty" jsonschema_extras:"group=ports"`
 
 	// Sync allows you to sync certain local paths with paths inside the container
 	Sync []*SyncConfig `yaml:"sync,omitempty" json:"sync,omitempty" jsonschema_extras:"group=sync,group_name=File Sync"`
@@ -1096,12 +1345,39 @@ type PortMapping struct {
 	// you port forward the remote port will be available at the local port.
 	// If you do reverse port forwarding, the local port will be available
 	// at the remote port in the container. If only port is specified, local and
-	// remote port are the same.
+	// remote port are the same. For port forwarding the port can be prefixed with auto:
+	// (e.g. auto:8080 or auto:8080:80), which makes DevSpace choose the next free local port
+	// if the preferred one is already in use. The chosen port is remembered across runs and can
+	// be referenced via the runtime variable ${runtime.dev.NAME.ports.8080}.
 	Port string `yaml:"port" json:"port"`
 
 	// BindAddress is the address DevSpace should listen on. Optional and defaults
 	// to localhost.
 	BindAddress string `yaml:"bindAddress,omitempty" json:"bindAddress,omitempty"`
+
+	// Inspect tells DevSpace to put a local HTTP reverse proxy in front of the forwarded port that records
+	// request and response metadata. Recorded requests can be viewed via `devspace logs --http` or the DevSpace UI.
+	// Only works for port forwarding and not for reverse port forwarding.
+	Inspect bool `yaml:"inspect,omitempty" json:"inspect,omitempty"`
+}
+
+// SocketMapping defines a local socket that is made available at a path inside the container
+type SocketMapping struct {
+	// Local is the path of the local unix socket, e.g. /var/run/docker.sock. On Windows
+	// a named pipe can be specified in the form npipe:////./pipe/docker_engine
+	Local string `yaml:"local" json:"local"`
+
+	// Remote is the path of the unix socket DevSpace should create inside the container
+	Remote string `yaml:"remote" json:"remote"`
+
+	// Mode is the octal file mode of the socket inside the container, e.g. 0660
+	Mode string `yaml:"mode,omitempty" json:"mode,omitempty"`
+
+	// Owner is the user name or id that should own the socket inside the container
+	Owner string `yaml:"owner,omitempty" json:"owner,omitempty"`
+
+	// Group is the group name or id that should own the socket inside the container
+	Group string `yaml:"group,omitempty" json:"group,omitempty"`
 }
 
 // OpenConfig defines what to open after services have been started
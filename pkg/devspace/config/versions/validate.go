@@ -3,7 +3,9 @@ package versions
 import (
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
+	"time"
 	"unicode"
 
 	"github.com/pkg/errors"
@@ -17,6 +19,13 @@ import (
 	"github.com/loft-sh/devspace/pkg/util/yamlutil"
 )
 
+var (
+	autoPortRegEx    = regexp.MustCompile(`^auto:[0-9]+(:[0-9]+)?$`)
+	socketModeRegEx  = regexp.MustCompile(`^0?[0-7]{3}$`)
+	platformRegEx    = regexp.MustCompile(`^[a-z0-9]+/[a-z0-9_]+(/[a-z0-9]+)?$`)
+	buildSecretRegEx = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`)
+)
+
 // ValidInitialSyncStrategy checks if strategy is valid
 func ValidInitialSyncStrategy(strategy latest.InitialSyncStrategy) bool {
 	return strategy == "" ||
@@ -64,6 +73,11 @@ func Validate(config *latest.Config) error {
 		return err
 	}
 
+	err = validateLocalRegistry(config)
+	if err != nil {
+		return err
+	}
+
 	err = validateDev(config)
 	if err != nil {
 		return err
@@ -256,8 +270,8 @@ func validateDeployments(config *latest.Config) error {
 		if encoding.IsUnsafeName(deployConfig.Name) {
 			return fmt.Errorf("deployments.%s has to match the following regex: %v", index, encoding.UnsafeNameRegEx.String())
 		}
-		if deployConfig.Helm == nil && deployConfig.Kubectl == nil {
-			return errors.Errorf("Please specify either helm or kubectl as deployment type in deployment %s", deployConfig.Name)
+		if deployConfig.Helm == nil && deployConfig.Kubectl == nil && deployConfig.Jsonnet == nil {
+			return errors.Errorf("Please specify either helm, kubectl or jsonnet as deployment type in deployment %s", deployConfig.Name)
 		}
 		if deployConfig.Kubectl != nil && deployConfig.Kubectl.Manifests == nil && deployConfig.Kubectl.InlineManifest == "" {
 			return errors.Errorf("deployments[%s].kubectl.manifests or deployments[%s].kubectl.InlineManifest is required", index, index)
@@ -268,21 +282,44 @@ func validateDeployments(config *latest.Config) error {
 		if deployConfig.Kubectl != nil && deployConfig.Helm != nil {
 			return errors.Errorf("deployments[%s].kubectl and deployments[%s].helm cannot be used together", index, index)
 		}
-		if deployConfig.Kubectl != nil && deployConfig.Kubectl.Patches != nil {
-			for patch := range deployConfig.Kubectl.Patches {
-				if deployConfig.Kubectl.Patches[patch].Target.Name == "" {
-					return errors.Errorf("deployments[%s].kubectl.patches[%d].target.name is required", index, patch)
-				}
-				if deployConfig.Kubectl.Patches[patch].Operation == "" {
-					return errors.Errorf("deployments[%s].kubectl.patches[%d].op is required", index, patch)
-				}
-				if deployConfig.Kubectl.Patches[patch].Path == "" {
-					return errors.Errorf("deployments[%s].kubectl.patches[%d].path is required", index, patch)
-				}
-				if deployConfig.Kubectl.Patches[patch].Operation != "remove" &&
-					deployConfig.Kubectl.Patches[patch].Value == nil {
-					return errors.Errorf("deployments[%s].kubectl.patches[%d].value is required", index, patch)
-				}
+		if deployConfig.Jsonnet != nil && (deployConfig.Kubectl != nil || deployConfig.Helm != nil) {
+			return errors.Errorf("deployments[%s].jsonnet cannot be used together with deployments[%s].kubectl or deployments[%s].helm", index, index, index)
+		}
+		if deployConfig.Jsonnet != nil && len(deployConfig.Jsonnet.Files) == 0 {
+			return errors.Errorf("deployments[%s].jsonnet.files is required", index)
+		}
+		if deployConfig.Kubectl != nil {
+			err := validateDeploymentPatches(deployConfig.Kubectl.Patches, "deployments["+index+"].kubectl")
+			if err != nil {
+				return err
+			}
+		}
+		if deployConfig.Helm != nil {
+			err := validateDeploymentPatches(deployConfig.Helm.Patches, "deployments["+index+"].helm")
+			if err != nil {
+				return err
+			}
+		}
+		if deployConfig.Jsonnet != nil {
+			err := validateDeploymentPatches(deployConfig.Jsonnet.Patches, "deployments["+index+"].jsonnet")
+			if err != nil {
+				return err
+			}
+		}
+		if deployConfig.Kubectl != nil && deployConfig.Kubectl.RevisionHistoryLimit != nil && *deployConfig.Kubectl.RevisionHistoryLimit < 0 {
+			return errors.Errorf("deployments[%s].kubectl.revisionHistoryLimit cannot be negative", index)
+		}
+		if deployConfig.Jsonnet != nil && deployConfig.Jsonnet.RevisionHistoryLimit != nil && *deployConfig.Jsonnet.RevisionHistoryLimit < 0 {
+			return errors.Errorf("deployments[%s].jsonnet.revisionHistoryLimit cannot be negative", index)
+		}
+		if deployConfig.Wait != nil && deployConfig.Wait.Timeout < 0 {
+			return errors.Errorf("deployments[%s].wait.timeout cannot be negative", index)
+		}
+		for _, dependency := range deployConfig.DependsOn {
+			if dependency == index {
+				return errors.Errorf("deployments[%s].dependsOn cannot contain the deployment itself", index)
+			} else if _, ok := config.Deployments[dependency]; !ok {
+				return errors.Errorf("deployments[%s].dependsOn: deployment %s does not exist", index, dependency)
 			}
 		}
 	}
@@ -290,6 +327,25 @@ func validateDeployments(config *latest.Config) error {
 	return nil
 }
 
+func validateDeploymentPatches(patches []*latest.PatchTarget, path string) error {
+	for idx, patch := range patches {
+		if patch.Target.Name == "" {
+			return errors.Errorf("%s.patches[%d].target.name is required", path, idx)
+		}
+		if patch.Operation == "" {
+			return errors.Errorf("%s.patches[%d].op is required", path, idx)
+		}
+		if patch.Path == "" {
+			return errors.Errorf("%s.patches[%d].path is required", path, idx)
+		}
+		if patch.Operation != "remove" && patch.Value == nil {
+			return errors.Errorf("%s.patches[%d].value is required", path, idx)
+		}
+	}
+
+	return nil
+}
+
 func ValidateComponentConfig(deployConfig *latest.DeploymentConfig, overwriteValues map[string]interface{}) error {
 	if deployConfig.Helm != nil && deployConfig.Helm.Chart == nil {
 		b, err := yaml.Marshal(overwriteValues)
@@ -339,12 +395,88 @@ func validateImages(config *latest.Config) error {
 		if imageConf.Custom != nil && imageConf.Custom.Command == "" && len(imageConf.Custom.Commands) == 0 {
 			return errors.Errorf("images.%s.build.custom.command or images.%s.build.custom.commands is required", imageConfigName, imageConfigName)
 		}
+		if imageConf.External != nil {
+			if imageConf.External.Builder == "" && imageConf.External.Command == "" {
+				return errors.Errorf("images.%s.external.builder or images.%s.external.command is required", imageConfigName, imageConfigName)
+			} else if imageConf.External.Builder != "" && imageConf.External.Command != "" {
+				return errors.Errorf("images.%s.external.builder and images.%s.external.command cannot be used together", imageConfigName, imageConfigName)
+			}
+		}
+		if strings.HasPrefix(imageConf.Context, "git+") && (strings.TrimPrefix(imageConf.Context, "git+") == "" || strings.HasPrefix(imageConf.Context, "git+#")) {
+			return errors.Errorf("images.%s.context needs a repository url after git+", imageConfigName)
+		}
+		for _, dependency := range imageConf.DependsOn {
+			if dependency == imageConfigName {
+				return errors.Errorf("images.%s.dependsOn cannot contain the image itself", imageConfigName)
+			} else if config.Images[dependency] == nil {
+				return errors.Errorf("images.%s.dependsOn references unknown image %s", imageConfigName, dependency)
+			}
+		}
 		if images[imageConf.Image] {
 			return errors.Errorf("multiple image definitions with the same image name are not allowed")
 		}
 		if imageConf.RebuildStrategy != "" && imageConf.RebuildStrategy != latest.RebuildStrategyDefault && imageConf.RebuildStrategy != latest.RebuildStrategyAlways && imageConf.RebuildStrategy != latest.RebuildStrategyIgnoreContextChanges {
 			return errors.Errorf("images.%s.rebuildStrategy %s is invalid. Please choose one of %v", imageConfigName, string(imageConf.RebuildStrategy), []latest.RebuildStrategy{latest.RebuildStrategyAlways, latest.RebuildStrategyIgnoreContextChanges})
 		}
+		if imageConf.Cache != nil {
+			locations := append([]*latest.BuildCacheLocation{}, imageConf.Cache.From...)
+			if imageConf.Cache.To != nil {
+				locations = append(locations, imageConf.Cache.To)
+			}
+			for _, location := range locations {
+				if location == nil {
+					return errors.Errorf("images.%s.cache contains an empty cache", imageConfigName)
+				}
+				if location.Type != "" && location.Type != latest.BuildCacheTypeRegistry && location.Type != latest.BuildCacheTypeLocal && location.Type != latest.BuildCacheTypeInline {
+					return errors.Errorf("images.%s.cache.type %s is invalid. Please choose one of %v", imageConfigName, location.Type, []latest.BuildCacheType{latest.BuildCacheTypeRegistry, latest.BuildCacheTypeLocal, latest.BuildCacheTypeInline})
+				}
+				if location.Type == latest.BuildCacheTypeLocal && location.Path == "" {
+					return errors.Errorf("images.%s.cache.path is required for local caches", imageConfigName)
+				}
+				if location.Mode != "" && location.Mode != "min" && location.Mode != "max" {
+					return errors.Errorf("images.%s.cache.mode %s is invalid. Please choose one of [min max]", imageConfigName, location.Mode)
+				}
+			}
+		}
+		for _, platform := range imageConf.Platforms {
+			if !platformRegEx.MatchString(platform) {
+				return errors.Errorf("images.%s.platforms: %s is not a valid platform, expected os/arch[/variant] e.g. linux/amd64", imageConfigName, platform)
+			}
+		}
+		for id, secret := range imageConf.Secrets {
+			if !buildSecretRegEx.MatchString(id) {
+				return errors.Errorf("images.%s.secrets.%s has to match the following regex: %v", imageConfigName, id, buildSecretRegEx.String())
+			}
+
+			sources := 0
+			if secret != nil {
+				for _, source := range []string{secret.File, secret.Env, secret.Value} {
+					if source != "" {
+						sources++
+					}
+				}
+			}
+			if sources != 1 {
+				return errors.Errorf("images.%s.secrets.%s needs exactly one of file, env or value", imageConfigName, id)
+			}
+		}
+		sshIDs := map[string]bool{}
+		for i, ssh := range imageConf.SSH {
+			if ssh == nil {
+				return errors.Errorf("images.%s.ssh[%d] is empty", imageConfigName, i)
+			}
+
+			id := ssh.ID
+			if id == "" {
+				id = "default"
+			}
+			if !buildSecretRegEx.MatchString(id) {
+				return errors.Errorf("images.%s.ssh[%d].id has to match the following regex: %v", imageConfigName, i, buildSecretRegEx.String())
+			} else if sshIDs[id] {
+				return errors.Errorf("images.%s.ssh[%d].id %s is used more than once", imageConfigName, i, id)
+			}
+			sshIDs[id] = true
+		}
 		if imageConf.Kaniko != nil && imageConf.Kaniko.EnvFrom != nil {
 			for _, v := range imageConf.Kaniko.EnvFrom {
 				o, err := yaml.Marshal(v)
@@ -364,6 +496,25 @@ func validateImages(config *latest.Config) error {
 	return nil
 }
 
+func validateLocalRegistry(config *latest.Config) error {
+	if config.LocalRegistry == nil || config.LocalRegistry.Retention == nil {
+		return nil
+	}
+
+	retention := config.LocalRegistry.Retention
+	if retention.KeepTags != nil && *retention.KeepTags < 0 {
+		return errors.Errorf("localRegistry.retention.keepTags cannot be negative")
+	}
+	if retention.MaxAge != "" {
+		_, err := time.ParseDuration(retention.MaxAge)
+		if err != nil {
+			return errors.Errorf("localRegistry.retention.maxAge %s is not a valid duration: %v", retention.MaxAge, err)
+		}
+	}
+
+	return nil
+}
+
 func validateDev(config *latest.Config) error {
 	for devPodName, devPod := range config.Dev {
 		devPodName = strings.TrimSpace(devPodName)
@@ -385,6 +536,12 @@ func validateDev(config *latest.Config) error {
 			return errors.Errorf("dev.%s: image selector and label selector cannot be used together", devPodName)
 		}
 
+		for index, port := range devPod.Ports {
+			if strings.HasPrefix(port.Port, "auto:") && !autoPortRegEx.MatchString(port.Port) {
+				return errors.Errorf("dev.%s.ports[%d].port '%s' is not valid, expected auto:LOCAL or auto:LOCAL:REMOTE", devPodName, index, port.Port)
+			}
+		}
+
 		err := validateDevContainer(fmt.Sprintf("dev.%s", devPodName), &devPod.DevContainer, devPod, false)
 		if err != nil {
 			return err
@@ -449,6 +606,23 @@ func validateDevContainer(path string, devContainer *latest.DevContainer, devPod
 		if port.Port == "" {
 			return errors.Errorf("%s.reversePorts[%d].port is required", path, index)
 		}
+		if port.Inspect {
+			return errors.Errorf("%s.reversePorts[%d].inspect is not supported for reverse port forwarding", path, index)
+		}
+		if strings.HasPrefix(port.Port, "auto:") {
+			return errors.Errorf("%s.reversePorts[%d].port auto is not supported for reverse port forwarding", path, index)
+		}
+	}
+	for index, socket := range devContainer.ReverseSockets {
+		if socket.Local == "" {
+			return errors.Errorf("%s.reverseSockets[%d].local is required", path, index)
+		}
+		if socket.Remote == "" {
+			return errors.Errorf("%s.reverseSockets[%d].remote is required", path, index)
+		}
+		if socket.Mode != "" && !socketModeRegEx.MatchString(socket.Mode) {
+			return errors.Errorf("%s.reverseSockets[%d].mode '%s' is not a valid octal file mode, e.g. 0660", path, index, socket.Mode)
+		}
 	}
 	for j, p := range devContainer.PersistPaths {
 		if p.Path == "" {
@@ -1,6 +1,7 @@
 package deploy
 
 import (
+	"bytes"
 	"fmt"
 	"io"
 	"sort"
@@ -11,7 +12,10 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/context/values"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/jsonnet"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/drift"
 	helmclient "github.com/loft-sh/devspace/pkg/devspace/helm"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
 	kubectlclient "github.com/loft-sh/devspace/pkg/devspace/kubectl"
@@ -27,6 +31,11 @@ type Options struct {
 
 	Render       bool `long:"render" description:"If true, prints the rendered manifests to the stdout instead of deploying them"`
 	RenderWriter io.Writer
+
+	Diff         bool `long:"diff" description:"If true, prints the difference between the rendered manifests and the cluster instead of deploying them"`
+	DiffExitCode bool `long:"diff-exit-code" description:"If true, exits with a non zero exit code if --diff found changes"`
+
+	DryRun bool `long:"dry-run" description:"If true, applies the manifests of kubectl deployments as server side dry run and prints which objects would be created, configured or pruned"`
 }
 
 type PurgeOptions struct {
@@ -37,6 +46,9 @@ type PurgeOptions struct {
 type Controller interface {
 	Deploy(ctx devspacecontext.Context, deployments []string, options *Options) error
 	Purge(ctx devspacecontext.Context, deployments []string, options *PurgeOptions) error
+	Diff(ctx devspacecontext.Context, deployments []string, options *Options) (bool, error)
+	Rollback(ctx devspacecontext.Context, deployments []string, revision int) error
+	Drift(ctx devspacecontext.Context, deployments []string) ([]*drift.Deployment, error)
 }
 
 type controller struct{}
@@ -58,6 +70,9 @@ func (c *controller) Deploy(ctx devspacecontext.Context, deployments []string, o
 		ctx.Log().Debugf("Skip deploy because of --skip-deploy")
 		return nil
 	}
+	if options.DryRun {
+		return c.dryRun(ctx, deployments)
+	}
 
 	if len(config.Deployments) > 0 {
 		// Execute before deployments deploy hook
@@ -67,25 +82,15 @@ func (c *controller) Deploy(ctx devspacecontext.Context, deployments []string, o
 		}
 
 		// get relevant deployments
-		var (
-			concurrentDeployments []*latest.DeploymentConfig
-			sequentialDeployments []*latest.DeploymentConfig
-		)
+		selectedDeployments := []*latest.DeploymentConfig{}
 		if len(deployments) == 0 {
 			for _, deployConfig := range config.Deployments {
-				if !options.Render && !options.Sequential {
-					concurrentDeployments = append(concurrentDeployments, deployConfig)
-				} else {
-					sequentialDeployments = append(sequentialDeployments, deployConfig)
-				}
+				selectedDeployments = append(selectedDeployments, deployConfig)
 			}
 
 			// make sure --all behaves the same every rung
-			sort.Slice(concurrentDeployments, func(i, j int) bool {
-				return concurrentDeployments[i].Name < concurrentDeployments[j].Name
-			})
-			sort.Slice(sequentialDeployments, func(i, j int) bool {
-				return sequentialDeployments[i].Name < sequentialDeployments[j].Name
+			sort.Slice(selectedDeployments, func(i, j int) bool {
+				return selectedDeployments[i].Name < selectedDeployments[j].Name
 			})
 		} else {
 			deploymentMap := config.Deployments
@@ -99,46 +104,22 @@ func (c *controller) Deploy(ctx devspacecontext.Context, deployments []string, o
 					return fmt.Errorf("couldn't find deployment %v", deployment)
 				}
 
-				if !options.Render && !options.Sequential {
-					concurrentDeployments = append(concurrentDeployments, deployConfig)
-				} else {
-					sequentialDeployments = append(sequentialDeployments, deployConfig)
-				}
+				selectedDeployments = append(selectedDeployments, deployConfig)
 			}
 		}
 
-		var (
-			errChan      = make(chan error)
-			deployedChan = make(chan bool)
-		)
-		for i, deployConfig := range concurrentDeployments {
-			go func(deployConfig *latest.DeploymentConfig, deployNumber int) {
-				wasDeployed, err := c.deployOne(ctx.WithLogger(ctx.Log().WithPrefix("deploy:"+deployConfig.Name+" ")), deployConfig, options)
-				if err != nil {
-					errChan <- err
-				} else {
-					deployedChan <- wasDeployed
-				}
-			}(deployConfig, i)
+		// deploy the deployments wave by wave and wait for each wave to become ready
+		// before the next one is deployed
+		waves, err := Waves(selectedDeployments)
+		if err != nil {
+			return err
 		}
-
-		if len(concurrentDeployments) > 0 {
-			ctx.Log().Debugf("Deploying %d deployments concurrently...", len(concurrentDeployments))
-
-			// Wait for concurrent deployments to complete before starting sequential deployments.
-			for i := 0; i < len(concurrentDeployments); i++ {
-				select {
-				case err := <-errChan:
-					return err
-				case <-deployedChan:
-					ctx.Log().Debugf("Deploying %d deployments concurrently", len(concurrentDeployments)-i-1)
-				}
+		for i, wave := range waves {
+			if len(waves) > 1 {
+				ctx.Log().Debugf("Deploying wave %d of %d...", i+1, len(waves))
 			}
-		}
 
-		for _, deployConfig := range sequentialDeployments {
-			logsDeploy := ctx.Log().WithPrefix("deploy:" + deployConfig.Name + " ")
-			_, err := c.deployOne(ctx.WithLogger(logsDeploy), deployConfig, options)
+			err = c.deployWave(ctx, wave, options, i < len(waves)-1)
 			if err != nil {
 				return err
 			}
@@ -159,48 +140,77 @@ func (c *controller) Deploy(ctx devspacecontext.Context, deployments []string, o
 	return nil
 }
 
-func (c *controller) deployOne(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig, options *Options) (bool, error) {
-	event := "deploy"
-	if options.Render {
-		event = "render"
+func (c *controller) deployWave(ctx devspacecontext.Context, deployments []*latest.DeploymentConfig, options *Options, waitForReady bool) error {
+	var (
+		concurrentDeployments []*latest.DeploymentConfig
+		sequentialDeployments []*latest.DeploymentConfig
+	)
+	for _, deployConfig := range deployments {
+		if !options.Render && !options.Sequential {
+			concurrentDeployments = append(concurrentDeployments, deployConfig)
+		} else {
+			sequentialDeployments = append(sequentialDeployments, deployConfig)
+		}
 	}
 
 	var (
-		deployClient deployer.Interface
-		err          error
-		method       string
+		errChan      = make(chan error)
+		deployedChan = make(chan bool)
 	)
+	for i, deployConfig := range concurrentDeployments {
+		go func(deployConfig *latest.DeploymentConfig, deployNumber int) {
+			wasDeployed, err := c.deployOne(ctx.WithLogger(ctx.Log().WithPrefix("deploy:"+deployConfig.Name+" ")), deployConfig, options, waitForReady)
+			if err != nil {
+				errChan <- err
+			} else {
+				deployedChan <- wasDeployed
+			}
+		}(deployConfig, i)
+	}
 
-	if !options.Render && deployConfig.Namespace != "" {
-		err = kubectlclient.EnsureNamespace(ctx.Context(), ctx.KubeClient(), deployConfig.Namespace, ctx.Log())
-		if err != nil {
-			return false, err
+	if len(concurrentDeployments) > 0 {
+		ctx.Log().Debugf("Deploying %d deployments concurrently...", len(concurrentDeployments))
+
+		// Wait for concurrent deployments to complete before starting sequential deployments.
+		for i := 0; i < len(concurrentDeployments); i++ {
+			select {
+			case err := <-errChan:
+				return err
+			case <-deployedChan:
+				ctx.Log().Debugf("Deploying %d deployments concurrently", len(concurrentDeployments)-i-1)
+			}
 		}
 	}
 
-	if deployConfig.Kubectl != nil {
-		deployClient, err = kubectl.New(ctx, deployConfig)
+	for _, deployConfig := range sequentialDeployments {
+		logsDeploy := ctx.Log().WithPrefix("deploy:" + deployConfig.Name + " ")
+		_, err := c.deployOne(ctx.WithLogger(logsDeploy), deployConfig, options, waitForReady)
 		if err != nil {
-			return true, errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+			return err
 		}
+	}
 
-		method = "kubectl"
-	} else if deployConfig.Helm != nil {
-		// Get helm client
-		helmClient, err := helmclient.NewClient(ctx.Log())
-		if err != nil {
-			return true, err
-		}
+	return nil
+}
 
-		deployClient, err = helm.New(helmClient, deployConfig)
+func (c *controller) deployOne(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig, options *Options, waitForReady bool) (bool, error) {
+	event := "deploy"
+	if options.Render {
+		event = "render"
+	}
+
+	if !options.Render && deployConfig.Namespace != "" {
+		err := kubectlclient.EnsureNamespace(ctx.Context(), ctx.KubeClient(), deployConfig.Namespace, ctx.Log())
 		if err != nil {
-			return true, errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+			return false, err
 		}
+	}
 
-		method = "helm"
-	} else {
-		return true, errors.Errorf("error deploying: deployment %s has no deployment method", deployConfig.Name)
+	deployClient, method, err := newDeployer(ctx, deployConfig)
+	if err != nil {
+		return true, err
 	}
+
 	// Execute before deployment deploy hook
 	err = hook.ExecuteHooks(ctx, map[string]interface{}{
 		"DEPLOY_NAME":   deployConfig.Name,
@@ -213,6 +223,9 @@ func (c *controller) deployOne(ctx devspacecontext.Context, deployConfig *latest
 	wasDeployed := false
 	if !options.Render {
 		wasDeployed, err = deployClient.Deploy(ctx, options.ForceDeploy)
+		if err == nil && wasDeployed {
+			err = waitForRollout(ctx, deployClient, deployConfig, waitForReady)
+		}
 	} else {
 		err = deployClient.Render(ctx, options.RenderWriter)
 	}
@@ -253,6 +266,171 @@ func (c *controller) deployOne(ctx devspacecontext.Context, deployConfig *latest
 	return false, nil
 }
 
+func newDeployer(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig) (deployer.Interface, string, error) {
+	if deployConfig.Kubectl != nil {
+		deployClient, err := kubectl.New(ctx, deployConfig)
+		if err != nil {
+			return nil, "", errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, "kubectl", nil
+	} else if deployConfig.Helm != nil {
+		// Get helm client
+		helmClient, err := helmclient.NewClient(ctx.Log())
+		if err != nil {
+			return nil, "", err
+		}
+
+		deployClient, err := helm.New(helmClient, deployConfig)
+		if err != nil {
+			return nil, "", errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, "helm", nil
+	} else if deployConfig.Jsonnet != nil {
+		deployClient, err := jsonnet.New(deployConfig)
+		if err != nil {
+			return nil, "", errors.Errorf("error deploying: deployment %s error: %v", deployConfig.Name, err)
+		}
+
+		return deployClient, "jsonnet", nil
+	}
+
+	return nil, "", errors.Errorf("error deploying: deployment %s has no deployment method", deployConfig.Name)
+}
+
+// Diff renders the deployments and prints the difference to the objects in the cluster. It returns
+// true if deploying would change any object.
+func (c *controller) Diff(ctx devspacecontext.Context, deployments []string, options *Options) (bool, error) {
+	config := ctx.Config().Config()
+	if len(deployments) == 0 {
+		for name := range config.Deployments {
+			deployments = append(deployments, name)
+		}
+		sort.Strings(deployments)
+	}
+
+	changed := false
+	for _, name := range deployments {
+		deployConfig, ok := config.Deployments[name]
+		if !ok {
+			return false, fmt.Errorf("couldn't find deployment %v", name)
+		}
+
+		deploymentChanged, err := c.diffOne(ctx.WithLogger(ctx.Log().WithPrefix("diff:"+name+" ")), deployConfig, options.RenderWriter)
+		if err != nil {
+			return false, err
+		}
+
+		changed = changed || deploymentChanged
+	}
+
+	return changed, nil
+}
+
+// Rollback rolls the deployments back to the given revision. If revision is 0, the deployments are
+// rolled back to their previous revision.
+func (c *controller) Rollback(ctx devspacecontext.Context, deployments []string, revision int) error {
+	config := ctx.Config().Config()
+	if len(deployments) == 0 {
+		for name := range config.Deployments {
+			deployments = append(deployments, name)
+		}
+		sort.Strings(deployments)
+	}
+	if revision != 0 && len(deployments) != 1 {
+		return errors.New("please specify a single deployment to roll back to a specific revision")
+	}
+
+	selectedDeployments := []*latest.DeploymentConfig{}
+	for _, name := range deployments {
+		deployConfig, ok := config.Deployments[name]
+		if !ok {
+			return fmt.Errorf("couldn't find deployment %v", name)
+		}
+
+		selectedDeployments = append(selectedDeployments, deployConfig)
+	}
+
+	waves, err := Waves(selectedDeployments)
+	if err != nil {
+		return err
+	}
+
+	// the remote cache is saved even if a rollback fails, so that the deployments that were
+	// already rolled back are deployed again by the next deploy
+	rollbackErr := c.rollbackWaves(ctx, waves, revision)
+	err = ctx.Config().RemoteCache().Save(ctx.Context(), ctx.KubeClient())
+	if rollbackErr != nil {
+		if err != nil {
+			return errors.Errorf("%v\nerror saving remote cache: %v", rollbackErr, err)
+		}
+
+		return rollbackErr
+	}
+
+	return err
+}
+
+func (c *controller) rollbackWaves(ctx devspacecontext.Context, waves [][]*latest.DeploymentConfig, revision int) error {
+	for _, wave := range waves {
+		for _, deployConfig := range wave {
+			ctx := ctx.WithLogger(ctx.Log().WithPrefix("rollback:" + deployConfig.Name + " "))
+			deployClient, _, err := newDeployer(ctx, deployConfig)
+			if err != nil {
+				return err
+			}
+
+			err = deployClient.Rollback(ctx, revision)
+			if err != nil {
+				return errors.Errorf("error rolling back %s: %v", deployConfig.Name, err)
+			}
+
+			ctx.Log().Donef("Successfully rolled back %s", ansi.Color(deployConfig.Name, "white+b"))
+		}
+	}
+
+	return nil
+}
+
+func (c *controller) diffOne(ctx devspacecontext.Context, deployConfig *latest.DeploymentConfig, out io.Writer) (bool, error) {
+	deployClient, _, err := newDeployer(ctx, deployConfig)
+	if err != nil {
+		return false, err
+	}
+
+	rendered := &bytes.Buffer{}
+	err = deployClient.Render(ctx, rendered)
+	if err != nil {
+		return false, errors.Errorf("error rendering %s: %v", deployConfig.Name, err)
+	}
+
+	namespace := deployConfig.Namespace
+	if namespace == "" {
+		namespace = ctx.KubeClient().Namespace()
+	}
+
+	objects, err := diff.Objects(ctx, rendered.String(), namespace)
+	if err != nil {
+		return false, errors.Errorf("error diffing %s: %v", deployConfig.Name, err)
+	}
+
+	changedObjects := 0
+	for _, object := range objects {
+		if object.Changed() {
+			changedObjects++
+		}
+	}
+	if changedObjects == 0 {
+		ctx.Log().Donef("Deployment %s is up to date", ansi.Color(deployConfig.Name, "white+b"))
+		return false, nil
+	}
+
+	ctx.Log().Infof("Deploying %s would change %d of %d object(s)", ansi.Color(deployConfig.Name, "white+b"), changedObjects, len(objects))
+	diff.Print(out, objects, true)
+	return true, nil
+}
+
 // Purge removes all deployments or a set of deployments from the cluster
 func (c *controller) Purge(ctx devspacecontext.Context, deployments []string, options *PurgeOptions) error {
 	if options == nil {
@@ -275,10 +453,8 @@ func (c *controller) Purge(ctx devspacecontext.Context, deployments []string, op
 	}
 
 	// Reverse them
-	deploymentCaches := ctx.Config().RemoteCache().ListDeployments()
-	for i := len(deploymentCaches) - 1; i >= 0; i-- {
-		// Deployment cache
-		deploymentCache := deploymentCaches[i]
+	deploymentCaches := purgeOrder(ctx, ctx.Config().RemoteCache().ListDeployments())
+	for _, deploymentCache := range deploymentCaches {
 
 		// Check if we should skip deleting deployment
 		if deployments != nil {
@@ -22,6 +22,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm/merge"
 	"github.com/loft-sh/devspace/pkg/devspace/helm"
 	hashpkg "github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/loft-sh/devspace/pkg/util/sops"
 	"github.com/loft-sh/devspace/pkg/util/yamlutil"
 	"github.com/pkg/errors"
 )
@@ -87,13 +88,11 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, forceDeploy bool) (bo
 	}
 
 	// Check deployment config for changes
-	configStr, err := yaml.Marshal(d.DeploymentConfig)
+	deploymentConfigHash, err := d.configHash()
 	if err != nil {
-		return false, errors.Wrap(err, "marshal deployment config")
+		return false, err
 	}
 
-	deploymentConfigHash := hashpkg.String(string(configStr))
-
 	// Get HelmClient if necessary
 	if d.Helm == nil {
 		d.Helm, err = helm.NewClient(ctx.Log())
@@ -121,7 +120,7 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, forceDeploy bool) (bo
 		helmCache = &remotecache.HelmCache{}
 	}
 
-	forceDeploy = forceDeploy || redeploy || deployCache.DeploymentConfigHash != deploymentConfigHash || helmCache.ValuesHash != deployValuesHash || helmCache.OverridesHash != helmOverridesHash || helmCache.ChartHash != hash
+	forceDeploy = forceDeploy || redeploy || d.imagesBuilt(ctx) || deployCache.DeploymentConfigHash != deploymentConfigHash || helmCache.ValuesHash != deployValuesHash || helmCache.OverridesHash != helmOverridesHash || helmCache.ChartHash != hash
 	if !forceDeploy {
 		releases, err := d.Helm.ListReleases(ctx, releaseNamespace)
 		if err != nil {
@@ -169,6 +168,16 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, forceDeploy bool) (bo
 	return false, nil
 }
 
+// configHash hashes the deployment config to detect changes since the last deploy
+func (d *DeployConfig) configHash() (string, error) {
+	configStr, err := yaml.Marshal(d.DeploymentConfig)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal deployment config")
+	}
+
+	return hashpkg.String(string(configStr)), nil
+}
+
 func (d *DeployConfig) internalDeploy(ctx devspacecontext.Context, overwriteValues map[string]interface{}, out io.Writer) (*types.Release, error) {
 	var releaseName string
 	if d.DeploymentConfig.Helm.ReleaseName != "" {
@@ -187,16 +196,41 @@ func (d *DeployConfig) internalDeploy(ctx devspacecontext.Context, overwriteValu
 			return nil, err
 		}
 
+		if d.postRender() {
+			str, err = d.postRenderManifests(ctx, str)
+			if err != nil {
+				return nil, errors.Wrap(err, "post render manifests")
+			}
+		}
+
 		_, _ = out.Write([]byte("\n" + str + "\n"))
 		return nil, nil
 	}
 
 	ctx.Log().Infof("Deploying chart %s (%s) with helm...", d.DeploymentConfig.Helm.Chart.Name, releaseName)
-	valuesOut, _ := yaml.Marshal(overwriteValues)
-	ctx.Log().Debugf("Deploying chart with values:\n %v\n", string(valuesOut))
+	if d.hasEncryptedValues(ctx) {
+		ctx.Log().Debugf("Not printing the chart values, because they contain values decrypted with sops")
+	} else {
+		valuesOut, _ := yaml.Marshal(overwriteValues)
+		ctx.Log().Debugf("Deploying chart with values:\n %v\n", string(valuesOut))
+	}
+
+	// Post render the chart with the patches and image tags
+	helmConfig := d.DeploymentConfig.Helm
+	if d.postRender() {
+		var (
+			stop func()
+			err  error
+		)
+		ctx, helmConfig, stop, err = d.withPostRenderer(ctx)
+		if err != nil {
+			return nil, err
+		}
+		defer stop()
+	}
 
 	// Deploy chart
-	appRelease, err := d.Helm.InstallChart(ctx, releaseName, releaseNamespace, overwriteValues, d.DeploymentConfig.Helm)
+	appRelease, err := d.Helm.InstallChart(ctx, releaseName, releaseNamespace, overwriteValues, helmConfig)
 	if err != nil {
 		return nil, errors.Errorf("unable to deploy helm chart: %v", err)
 	}
@@ -211,6 +245,18 @@ func (d *DeployConfig) internalDeploy(ctx devspacecontext.Context, overwriteValu
 	return appRelease, nil
 }
 
+// hasEncryptedValues returns true if one of the values files was encrypted with sops
+func (d *DeployConfig) hasEncryptedValues(ctx devspacecontext.Context) bool {
+	for _, valuesFile := range d.DeploymentConfig.Helm.ValuesFiles {
+		data, err := os.ReadFile(ctx.ResolvePath(valuesFile))
+		if err == nil && sops.IsEncrypted(data) {
+			return true
+		}
+	}
+
+	return false
+}
+
 func (d *DeployConfig) getDeploymentValues(ctx devspacecontext.Context) (bool, map[string]interface{}, error) {
 	var (
 		chartPath       = d.DeploymentConfig.Helm.Chart.Name
@@ -241,11 +287,16 @@ func (d *DeployConfig) getDeploymentValues(ctx devspacecontext.Context) (bool, m
 		for _, overridePath := range d.DeploymentConfig.Helm.ValuesFiles {
 			overwriteValuesPath := ctx.ResolvePath(overridePath)
 			overwriteValuesFromPath := map[string]interface{}{}
-			err = yamlutil.ReadYamlFromFile(overwriteValuesPath, overwriteValuesFromPath)
+			overwriteValuesBytes, err := sops.ReadFile(ctx.Context(), overwriteValuesPath, ctx.Environ())
 			if err != nil {
 				return false, nil, fmt.Errorf("error reading from chart dev overwrite values %s: %v", overwriteValuesPath, err)
 			}
 
+			err = yaml.Unmarshal(overwriteValuesBytes, overwriteValuesFromPath)
+			if err != nil {
+				return false, nil, fmt.Errorf("error parsing chart dev overwrite values %s: %v", overwriteValuesPath, err)
+			}
+
 			// Replace image names
 			if d.DeploymentConfig.UpdateImageTags == nil || *d.DeploymentConfig.UpdateImageTags {
 				redeploy, err := legacy.ReplaceImageNames(overwriteValuesFromPath, ctx.Config(), ctx.Dependencies(), nil)
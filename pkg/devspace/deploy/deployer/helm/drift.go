@@ -0,0 +1,41 @@
+package helm
+
+import (
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/pkg/errors"
+)
+
+// Deployed returns the manifests of the current revision of the helm release
+func (d *DeployConfig) Deployed(ctx devspacecontext.Context) (*deployer.DeployedResult, error) {
+	releaseName := d.DeploymentConfig.Name
+	if d.DeploymentConfig.Helm.ReleaseName != "" {
+		releaseName = d.DeploymentConfig.Helm.ReleaseName
+	}
+
+	deployCache, ok := ctx.Config().RemoteCache().GetDeployment(releaseName)
+	if !ok || deployCache.Helm == nil || deployCache.Helm.Release == "" {
+		return nil, nil
+	}
+
+	configHash, err := d.configHash()
+	if err != nil {
+		return nil, err
+	}
+
+	manifests, err := d.Helm.GetManifest(ctx, deployCache.Helm.Release, deployCache.Helm.ReleaseNamespace)
+	if err != nil {
+		return nil, errors.Wrapf(err, "get manifest of release %s", deployCache.Helm.Release)
+	}
+
+	namespace := deployCache.Helm.ReleaseNamespace
+	if namespace == "" {
+		namespace = ctx.KubeClient().Namespace()
+	}
+
+	return &deployer.DeployedResult{
+		Manifests:     manifests,
+		Namespace:     namespace,
+		ConfigChanged: deployCache.DeploymentConfigHash != configHash,
+	}, nil
+}
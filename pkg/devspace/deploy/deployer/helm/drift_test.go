@@ -0,0 +1,61 @@
+package helm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	fakehelm "github.com/loft-sh/devspace/pkg/devspace/helm/testing"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestDeployed(t *testing.T) {
+	deployer := &DeployConfig{
+		Helm: &fakehelm.Client{
+			Releases:  []*helmtypes.Release{{Name: "my-release", Namespace: "default", Revision: "1"}},
+			Manifests: map[string]string{"my-release": "kind: ConfigMap"},
+		},
+		DeploymentConfig: &latest.DeploymentConfig{
+			Name: "deploy1",
+			Helm: &latest.HelmConfig{ReleaseName: "my-release"},
+		},
+	}
+	configHash, err := deployer.configHash()
+	assert.NilError(t, err)
+
+	cache := remotecache.NewCache("testConfig", "testSecret")
+	cache.SetDeployment("my-release", remotecache.DeploymentCache{
+		Name:                 "my-release",
+		DeploymentConfigHash: configHash,
+		Helm:                 &remotecache.HelmCache{Release: "my-release", ReleaseNamespace: "default"},
+	})
+	conf := config.NewConfig(map[string]interface{}{},
+		map[string]interface{}{},
+		&latest.Config{},
+		localcache.New(constants.DefaultCacheFolder),
+		cache,
+		map[string]interface{}{},
+		constants.DefaultConfigPath)
+	devCtx := devspacecontext.NewContext(context.Background(), nil, log.Discard).WithKubeClient(&fakekube.Client{Client: fake.NewSimpleClientset()}).WithConfig(conf)
+
+	deployed, err := deployer.Deployed(devCtx)
+	assert.NilError(t, err)
+	assert.Equal(t, deployed.Manifests, "kind: ConfigMap")
+	assert.Equal(t, deployed.Namespace, "default")
+	assert.Equal(t, deployed.ConfigChanged, false)
+
+	// deployments that were never deployed have no manifests
+	deployer.DeploymentConfig = &latest.DeploymentConfig{Name: "deploy2", Helm: &latest.HelmConfig{}}
+	deployed, err = deployer.Deployed(devCtx)
+	assert.NilError(t, err)
+	assert.Assert(t, deployed == nil)
+}
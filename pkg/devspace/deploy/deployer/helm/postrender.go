@@ -0,0 +1,97 @@
+package helm
+
+import (
+	"strings"
+
+	buildtypes "github.com/loft-sh/devspace/pkg/devspace/build/types"
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/legacy"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/helm/postrender"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/manifests"
+	"github.com/pkg/errors"
+	jsonyaml "sigs.k8s.io/yaml"
+)
+
+// postRender returns true if the rendered manifests of the chart have to be post rendered
+func (d *DeployConfig) postRender() bool {
+	return len(d.DeploymentConfig.Helm.Patches) > 0 || d.replaceImageTags()
+}
+
+func (d *DeployConfig) replaceImageTags() bool {
+	return d.DeploymentConfig.Helm.ReplaceImageTags && (d.DeploymentConfig.UpdateImageTags == nil || *d.DeploymentConfig.UpdateImageTags)
+}
+
+// imagesBuilt returns true if images were built in this run, which might change the post rendered manifests
+func (d *DeployConfig) imagesBuilt(ctx devspacecontext.Context) bool {
+	if !d.replaceImageTags() {
+		return false
+	}
+
+	builtImagesInterface, ok := ctx.Config().GetRuntimeVariable(constants.BuiltImagesKey)
+	if !ok {
+		return false
+	}
+
+	builtImages, _ := builtImagesInterface.(map[string]buildtypes.ImageNameTag)
+	return len(builtImages) > 0
+}
+
+// postRenderManifests replaces the image tags and applies the patches to the manifests rendered by helm
+func (d *DeployConfig) postRenderManifests(ctx devspacecontext.Context, rendered string) (string, error) {
+	objects, err := manifests.Parse(rendered)
+	if err != nil {
+		return "", err
+	}
+
+	out := []string{}
+	for _, object := range objects {
+		if d.replaceImageTags() {
+			_, err := legacy.ReplaceImageNamesStringMap(object.Object, ctx.Config(), ctx.Dependencies(), map[string]bool{"image": true})
+			if err != nil {
+				return "", err
+			}
+		}
+
+		patched, err := manifests.Patch(object, d.DeploymentConfig.Helm.Patches, ctx.Log())
+		if err != nil {
+			// we're skipping a patch
+			ctx.Log().Warn(err)
+		} else {
+			object = patched
+		}
+
+		manifest, err := jsonyaml.Marshal(object)
+		if err != nil {
+			return "", errors.Wrap(err, "marshal yaml")
+		}
+
+		out = append(out, string(manifest))
+	}
+
+	return strings.Join(out, "---\n"), nil
+}
+
+// withPostRenderer starts a post render server and returns the context and helm config to run `helm upgrade` with.
+// The returned function stops the server.
+func (d *DeployConfig) withPostRenderer(ctx devspacecontext.Context) (devspacecontext.Context, *latest.HelmConfig, func(), error) {
+	server, err := postrender.Start(func(rendered string) (string, error) {
+		return d.postRenderManifests(ctx, rendered)
+	})
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	args, err := server.Args()
+	if err != nil {
+		_ = server.Close()
+		return nil, nil, nil, err
+	}
+
+	helmConfig := *d.DeploymentConfig.Helm
+	helmConfig.UpgradeArgs = append(args, d.DeploymentConfig.Helm.UpgradeArgs...)
+	return ctx.WithEnviron(server.Environ(ctx.Environ())), &helmConfig, func() {
+		_ = server.Close()
+	}, nil
+}
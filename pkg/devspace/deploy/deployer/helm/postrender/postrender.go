@@ -0,0 +1,138 @@
+package postrender
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/expression"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/env"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/expand"
+)
+
+const (
+	// Command is the hidden devspace command helm executes as post renderer
+	Command = "helm-post-render"
+
+	// AddressEnv holds the address of the post render server for the post renderer
+	AddressEnv = "DEVSPACE_HELM_POST_RENDER_ADDRESS"
+	// TokenEnv holds the token the post renderer authenticates with
+	TokenEnv = "DEVSPACE_HELM_POST_RENDER_TOKEN"
+)
+
+// Func post renders the manifests rendered by helm
+type Func func(manifests string) (string, error)
+
+// Server receives the manifests from the post renderer helm executes and post renders them
+// within the running DevSpace process, which holds the config, cache and built images
+type Server struct {
+	listener net.Listener
+	server   *http.Server
+	token    string
+}
+
+// Start starts a new post render server on a random local port
+func Start(fn Func) (*Server, error) {
+	token := make([]byte, 32)
+	_, err := rand.Read(token)
+	if err != nil {
+		return nil, errors.Wrap(err, "generate token")
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, errors.Wrap(err, "start post render server")
+	}
+
+	s := &Server{
+		listener: listener,
+		token:    hex.EncodeToString(token),
+	}
+	s.server = &http.Server{
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodPost || r.Header.Get("Authorization") != "Bearer "+s.token {
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+
+			manifests, err := io.ReadAll(r.Body)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+
+			out, err := fn(string(manifests))
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusInternalServerError)
+				return
+			}
+
+			_, _ = w.Write([]byte(out))
+		}),
+	}
+	go func() {
+		_ = s.server.Serve(listener)
+	}()
+
+	return s, nil
+}
+
+// Args returns the helm arguments to use the current devspace binary as post renderer
+func (s *Server) Args() ([]string, error) {
+	bin, err := os.Executable()
+	if err != nil {
+		return nil, errors.Wrap(err, "find devspace binary")
+	}
+
+	return []string{"--post-renderer", bin, "--post-renderer-args", Command}, nil
+}
+
+// Environ returns the environment helm has to be executed with, so that the post renderer can reach the server
+func (s *Server) Environ(base expand.Environ) expand.Environ {
+	return env.NewVariableEnvProvider(base, map[string]string{
+		AddressEnv: s.listener.Addr().String(),
+		TokenEnv:   s.token,
+
+		// the post renderer doesn't need the config
+		expression.DevSpaceSkipPreloadEnv: "true",
+	})
+}
+
+// Close stops the server
+func (s *Server) Close() error {
+	return s.server.Close()
+}
+
+// Run sends the manifests read from in to the post render server and writes the post rendered manifests to out
+func Run(ctx context.Context, in io.Reader, out io.Writer) error {
+	address, token := os.Getenv(AddressEnv), os.Getenv(TokenEnv)
+	if address == "" || token == "" {
+		return errors.Errorf("%s can only be used by DevSpace as helm post renderer", Command)
+	}
+
+	request, err := http.NewRequestWithContext(ctx, http.MethodPost, "http://"+address, in)
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+
+	response, err := http.DefaultClient.Do(request)
+	if err != nil {
+		return errors.Wrap(err, "post render manifests")
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		message, _ := io.ReadAll(response.Body)
+		return errors.Errorf("post render manifests: %s", strings.TrimSpace(string(message)))
+	}
+
+	_, err = io.Copy(out, response.Body)
+	return err
+}
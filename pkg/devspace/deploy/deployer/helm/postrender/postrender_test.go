@@ -0,0 +1,39 @@
+package postrender
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+	"mvdan.cc/sh/v3/expand"
+)
+
+func TestPostRender(t *testing.T) {
+	server, err := Start(func(manifests string) (string, error) {
+		if manifests == "" {
+			return "", errors.New("no manifests")
+		}
+
+		return strings.ToUpper(manifests), nil
+	})
+	assert.NilError(t, err)
+	defer server.Close()
+
+	environ := server.Environ(expand.ListEnviron())
+	t.Setenv(AddressEnv, environ.Get(AddressEnv).String())
+	t.Setenv(TokenEnv, environ.Get(TokenEnv).String())
+
+	out := &strings.Builder{}
+	err = Run(context.Background(), strings.NewReader("kind: ConfigMap"), out)
+	assert.NilError(t, err)
+	assert.Equal(t, out.String(), "KIND: CONFIGMAP")
+
+	err = Run(context.Background(), strings.NewReader(""), out)
+	assert.Error(t, err, "post render manifests: no manifests")
+
+	t.Setenv(TokenEnv, "other")
+	err = Run(context.Background(), strings.NewReader("kind: ConfigMap"), out)
+	assert.Error(t, err, "post render manifests: unauthorized")
+}
@@ -0,0 +1,84 @@
+package helm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestPostRenderManifests(t *testing.T) {
+	cache := localcache.New(constants.DefaultCacheFolder)
+	cache.SetImageCache("app", localcache.ImageCache{ImageName: "myimage", Tag: "abc"})
+	conf := config.NewConfig(map[string]interface{}{},
+		map[string]interface{}{},
+		&latest.Config{Images: map[string]*latest.Image{"app": {Image: "myimage"}}},
+		cache,
+		remotecache.NewCache("testConfig", "testSecret"),
+		map[string]interface{}{},
+		constants.DefaultConfigPath)
+	devCtx := devspacecontext.NewContext(context.Background(), nil, log.Discard).WithConfig(conf)
+
+	deployer := &DeployConfig{
+		DeploymentConfig: &latest.DeploymentConfig{
+			Name: "deploy1",
+			Helm: &latest.HelmConfig{
+				ReplaceImageTags: true,
+				Patches: []*latest.PatchTarget{
+					{
+						Target:      latest.Target{Kind: "Deployment", Name: "app"},
+						PatchConfig: latest.PatchConfig{Operation: "replace", Path: "spec.replicas", Value: 2},
+					},
+				},
+			},
+		},
+	}
+	assert.Equal(t, deployer.postRender(), true)
+
+	out, err := deployer.postRenderManifests(devCtx, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: app
+        image: myimage
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`)
+	assert.NilError(t, err)
+	assert.Equal(t, out, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: app
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - image: myimage:abc
+        name: app
+---
+apiVersion: v1
+kind: Service
+metadata:
+  name: app
+`)
+
+	// image tags are only replaced if enabled
+	deployer.DeploymentConfig.Helm = &latest.HelmConfig{}
+	assert.Equal(t, deployer.postRender(), false)
+}
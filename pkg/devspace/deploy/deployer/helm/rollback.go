@@ -0,0 +1,52 @@
+package helm
+
+import (
+	"strconv"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+)
+
+// Rollback rolls the helm release of the deployment back to the given release revision. If revision
+// is 0, the release is rolled back to the previous revision.
+func (d *DeployConfig) Rollback(ctx devspacecontext.Context, revision int) error {
+	releaseName := d.DeploymentConfig.Name
+	if d.DeploymentConfig.Helm.ReleaseName != "" {
+		releaseName = d.DeploymentConfig.Helm.ReleaseName
+	}
+
+	deployCache, ok := ctx.Config().RemoteCache().GetDeployment(releaseName)
+	if !ok || deployCache.Helm == nil || deployCache.Helm.Release == "" {
+		return errors.Errorf("deployment %s was not deployed yet", d.DeploymentConfig.Name)
+	}
+
+	revisionStr := ""
+	if revision > 0 {
+		revisionStr = strconv.Itoa(revision)
+		ctx.Log().Infof("Rolling back release %s to revision %d...", deployCache.Helm.Release, revision)
+	} else {
+		ctx.Log().Infof("Rolling back release %s to the previous revision...", deployCache.Helm.Release)
+	}
+
+	err := d.Helm.RollbackRelease(ctx, deployCache.Helm.Release, deployCache.Helm.ReleaseNamespace, revisionStr)
+	if err != nil {
+		return err
+	}
+
+	// remember the new release revision
+	releases, err := d.Helm.ListReleases(ctx, deployCache.Helm.ReleaseNamespace)
+	if err != nil {
+		return err
+	}
+	for _, release := range releases {
+		if release.Name == deployCache.Helm.Release {
+			deployCache.Helm.ReleaseRevision = release.Revision
+			break
+		}
+	}
+
+	// make sure the next deploy upgrades the release to the current config again
+	deployCache.DeploymentConfigHash = ""
+	ctx.Config().RemoteCache().SetDeployment(releaseName, deployCache)
+	return nil
+}
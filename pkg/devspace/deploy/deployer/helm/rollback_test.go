@@ -0,0 +1,68 @@
+package helm
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	fakehelm "github.com/loft-sh/devspace/pkg/devspace/helm/testing"
+	helmtypes "github.com/loft-sh/devspace/pkg/devspace/helm/types"
+	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestRollback(t *testing.T) {
+	cache := remotecache.NewCache("testConfig", "testSecret")
+	cache.SetDeployment("my-release", remotecache.DeploymentCache{
+		Name:                 "my-release",
+		DeploymentConfigHash: "hash",
+		Helm: &remotecache.HelmCache{
+			Release:          "my-release",
+			ReleaseNamespace: "default",
+			ReleaseRevision:  "3",
+		},
+	})
+
+	deployer := &DeployConfig{
+		Helm: &fakehelm.Client{
+			Releases: []*helmtypes.Release{
+				{Name: "my-release", Namespace: "default", Revision: "3"},
+			},
+		},
+		DeploymentConfig: &latest.DeploymentConfig{
+			Name: "deploy1",
+			Helm: &latest.HelmConfig{
+				ReleaseName: "my-release",
+			},
+		},
+	}
+
+	conf := config.NewConfig(map[string]interface{}{},
+		map[string]interface{}{},
+		&latest.Config{},
+		localcache.New(constants.DefaultCacheFolder),
+		cache,
+		map[string]interface{}{},
+		constants.DefaultConfigPath)
+	devCtx := devspacecontext.NewContext(context.Background(), nil, log.Discard).WithKubeClient(&fakekube.Client{Client: fake.NewSimpleClientset()}).WithConfig(conf)
+
+	err := deployer.Rollback(devCtx, 2)
+	assert.NilError(t, err)
+
+	deployCache, ok := cache.GetDeployment("my-release")
+	assert.Equal(t, ok, true)
+	assert.Equal(t, deployCache.Helm.ReleaseRevision, "4")
+	assert.Equal(t, deployCache.DeploymentConfigHash, "")
+
+	// deployments that were never deployed cannot be rolled back
+	deployer.DeploymentConfig = &latest.DeploymentConfig{Name: "deploy2", Helm: &latest.HelmConfig{}}
+	err = deployer.Rollback(devCtx, 0)
+	assert.Error(t, err, "deployment deploy2 was not deployed yet")
+}
@@ -10,6 +10,29 @@ type Interface interface {
 	Status(ctx devspacecontext.Context) (*StatusResult, error)
 	Deploy(ctx devspacecontext.Context, forceDeploy bool) (bool, error)
 	Render(ctx devspacecontext.Context, out io.Writer) error
+	Rollback(ctx devspacecontext.Context, revision int) error
+}
+
+// DryRunInterface is implemented by deployers that can report the changes of a deploy without applying them
+type DryRunInterface interface {
+	DryRun(ctx devspacecontext.Context) error
+}
+
+// DriftInterface is implemented by deployers that can return what they deployed last, which is
+// compared with the cluster to detect drift
+type DriftInterface interface {
+	// Deployed returns the manifests of the last deploy or nil if the deployment was not deployed yet
+	Deployed(ctx devspacecontext.Context) (*DeployedResult, error)
+}
+
+// DeployedResult holds the manifests of the last deploy of a deployment
+type DeployedResult struct {
+	// Manifests are the rendered manifests of the last deploy
+	Manifests string
+	// Namespace is the namespace of namespaced objects without a namespace
+	Namespace string
+	// ConfigChanged is true if the deployment config changed since the last deploy
+	ConfigChanged bool
 }
 
 // StatusResult holds the status of a deployment
@@ -0,0 +1,175 @@
+package jsonnet
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	gojsonnet "github.com/google/go-jsonnet"
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/legacy"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// ImagesExtVar is the external variable that holds the images of the config with their most recent built tags
+const ImagesExtVar = "DEVSPACE_IMAGES"
+
+// Evaluate evaluates the jsonnet files and returns the resulting objects as yaml manifests
+func Evaluate(ctx devspacecontext.Context, jsonnetConfig *latest.JsonnetConfig) (string, error) {
+	vm := gojsonnet.MakeVM()
+
+	jpaths := []string{}
+	for _, jpath := range jsonnetConfig.JPaths {
+		jpaths = append(jpaths, ctx.ResolvePath(jpath))
+	}
+	vm.Importer(&gojsonnet.FileImporter{JPaths: jpaths})
+
+	// DevSpace variables
+	for name, value := range ctx.Config().Variables() {
+		err := setVar(vm.ExtVar, vm.ExtCode, name, value)
+		if err != nil {
+			return "", errors.Wrapf(err, "external variable %s", name)
+		}
+	}
+
+	// images with their tags
+	images, err := resolveImages(ctx)
+	if err != nil {
+		return "", err
+	}
+	err = setVar(vm.ExtVar, vm.ExtCode, ImagesExtVar, images)
+	if err != nil {
+		return "", errors.Wrapf(err, "external variable %s", ImagesExtVar)
+	}
+
+	for name, value := range jsonnetConfig.ExtVars {
+		err := setVar(vm.ExtVar, vm.ExtCode, name, value)
+		if err != nil {
+			return "", errors.Wrapf(err, "external variable %s", name)
+		}
+	}
+	for name, value := range jsonnetConfig.TLAs {
+		err := setVar(vm.TLAVar, vm.TLACode, name, value)
+		if err != nil {
+			return "", errors.Wrapf(err, "top level argument %s", name)
+		}
+	}
+
+	manifests := []string{}
+	for _, file := range jsonnetConfig.Files {
+		out, err := vm.EvaluateFile(ctx.ResolvePath(file))
+		if err != nil {
+			return "", errors.Wrapf(err, "evaluate %s", file)
+		}
+
+		var value interface{}
+		err = json.Unmarshal([]byte(out), &value)
+		if err != nil {
+			return "", errors.Wrapf(err, "parse output of %s", file)
+		}
+
+		objects, err := toObjects(value, "")
+		if err != nil {
+			return "", errors.Wrapf(err, "output of %s", file)
+		}
+
+		for _, object := range objects {
+			manifest, err := yaml.Marshal(object)
+			if err != nil {
+				return "", errors.Wrap(err, "marshal yaml")
+			}
+
+			manifests = append(manifests, string(manifest))
+		}
+	}
+
+	return strings.Join(manifests, "---\n"), nil
+}
+
+// setVar passes strings as string variables and all other values as json code
+func setVar(setString, setCode func(string, string), name string, value interface{}) error {
+	if str, ok := value.(string); ok {
+		setString(name, str)
+		return nil
+	}
+
+	code, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+
+	setCode(name, string(code))
+	return nil
+}
+
+// resolveImages returns the image and tag of all images in the config
+func resolveImages(ctx devspacecontext.Context) (map[string]interface{}, error) {
+	images := map[string]interface{}{}
+	for name := range ctx.Config().Config().Images {
+		image, err := legacy.ResolveImageHelpers("image("+name+")", ctx.Config(), ctx.Dependencies())
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolve image %s", name)
+		}
+
+		tag, err := legacy.ResolveImageHelpers("tag("+name+")", ctx.Config(), ctx.Dependencies())
+		if err != nil {
+			return nil, errors.Wrapf(err, "resolve tag of image %s", name)
+		}
+
+		images[name] = map[string]interface{}{
+			"image": image,
+			"tag":   tag,
+		}
+	}
+
+	return images, nil
+}
+
+// toObjects collects the Kubernetes objects from an evaluated value, which can be an object,
+// an array of values or an object of values
+func toObjects(value interface{}, path string) ([]map[string]interface{}, error) {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		_, hasKind := v["kind"]
+		_, hasAPIVersion := v["apiVersion"]
+		if hasKind && hasAPIVersion {
+			return []map[string]interface{}{v}, nil
+		}
+
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		objects := []map[string]interface{}{}
+		for _, key := range keys {
+			childObjects, err := toObjects(v[key], path+"."+key)
+			if err != nil {
+				return nil, err
+			}
+
+			objects = append(objects, childObjects...)
+		}
+		return objects, nil
+	case []interface{}:
+		objects := []map[string]interface{}{}
+		for i, element := range v {
+			childObjects, err := toObjects(element, fmt.Sprintf("%s[%d]", path, i))
+			if err != nil {
+				return nil, err
+			}
+
+			objects = append(objects, childObjects...)
+		}
+		return objects, nil
+	}
+
+	if path == "" {
+		path = "."
+	}
+	return nil, errors.Errorf("value at %s is not a Kubernetes object", path)
+}
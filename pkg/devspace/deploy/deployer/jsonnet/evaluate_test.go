@@ -0,0 +1,93 @@
+package jsonnet
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestEvaluate(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "lib"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "lib", "labels.libsonnet"), []byte(`{ app: std.extVar('APP') }`), 0644))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "app.jsonnet"), []byte(`
+local labels = import 'labels.libsonnet';
+function(replicas) {
+  service: {
+    apiVersion: 'v1',
+    kind: 'Service',
+    metadata: { name: std.extVar('APP'), labels: labels },
+  },
+  deployment: {
+    apiVersion: 'apps/v1',
+    kind: 'Deployment',
+    metadata: { name: std.extVar('APP') },
+    spec: {
+      replicas: replicas,
+      template: { spec: { containers: [{
+        name: 'app',
+        image: std.extVar('DEVSPACE_IMAGES').app.image + ':' + std.extVar('DEVSPACE_IMAGES').app.tag,
+      }] } },
+    },
+  },
+}
+`), 0644))
+
+	cache := localcache.New(constants.DefaultCacheFolder)
+	cache.SetImageCache("app", localcache.ImageCache{ImageName: "myimage", Tag: "abc"})
+	conf := config.NewConfig(map[string]interface{}{},
+		map[string]interface{}{},
+		&latest.Config{Images: map[string]*latest.Image{"app": {Image: "myimage"}}},
+		cache,
+		remotecache.NewCache("testConfig", "testSecret"),
+		map[string]interface{}{"APP": "my-app"},
+		constants.DefaultConfigPath)
+	devCtx := devspacecontext.NewContext(context.Background(), nil, log.Discard).WithConfig(conf).WithWorkingDir(dir)
+
+	manifests, err := Evaluate(devCtx, &latest.JsonnetConfig{
+		Files:  []string{"app.jsonnet"},
+		JPaths: []string{"lib"},
+		TLAs:   map[string]interface{}{"replicas": 2},
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, manifests, `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: my-app
+spec:
+  replicas: 2
+  template:
+    spec:
+      containers:
+      - image: myimage:abc
+        name: app
+---
+apiVersion: v1
+kind: Service
+metadata:
+  labels:
+    app: my-app
+  name: my-app
+`)
+}
+
+func TestToObjects(t *testing.T) {
+	configMap := map[string]interface{}{"apiVersion": "v1", "kind": "ConfigMap"}
+
+	objects, err := toObjects([]interface{}{configMap, map[string]interface{}{"nested": configMap}}, "")
+	assert.NilError(t, err)
+	assert.Equal(t, len(objects), 2)
+
+	_, err = toObjects(map[string]interface{}{"items": []interface{}{"value"}}, "")
+	assert.Error(t, err, "value at .items[0] is not a Kubernetes object")
+}
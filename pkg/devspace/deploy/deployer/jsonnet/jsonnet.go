@@ -0,0 +1,129 @@
+package jsonnet
+
+import (
+	"io"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer/kubectl"
+	"github.com/pkg/errors"
+)
+
+// DeployConfig holds the necessary information for jsonnet deployments. The rendered manifests
+// are deployed with the kubectl deployer.
+type DeployConfig struct {
+	DeploymentConfig *latest.DeploymentConfig
+
+	// deployed is the kubectl deployer of the last Deploy, which returns the applied manifests
+	// without evaluating the jsonnet files again
+	deployed deployer.Interface
+}
+
+// New creates a new deploy config for jsonnet
+func New(deployConfig *latest.DeploymentConfig) (deployer.Interface, error) {
+	if deployConfig.Jsonnet == nil {
+		return nil, errors.New("error creating jsonnet deploy config: jsonnet is nil")
+	} else if len(deployConfig.Jsonnet.Files) == 0 {
+		return nil, errors.New("no files defined for jsonnet deploy")
+	}
+
+	return &DeployConfig{
+		DeploymentConfig: deployConfig,
+	}, nil
+}
+
+// Status returns the status of the deployment
+func (d *DeployConfig) Status(ctx devspacecontext.Context) (*deployer.StatusResult, error) {
+	files := strings.Join(d.DeploymentConfig.Jsonnet.Files, ",")
+	if len(files) > 20 {
+		files = files[:20] + "..."
+	}
+
+	return &deployer.StatusResult{
+		Name:   d.DeploymentConfig.Name,
+		Type:   "Jsonnet",
+		Target: files,
+		Status: "N/A",
+	}, nil
+}
+
+// Deploy evaluates the jsonnet files and applies the result via kubectl
+func (d *DeployConfig) Deploy(ctx devspacecontext.Context, forceDeploy bool) (bool, error) {
+	kubectlDeployer, err := d.kubectlDeployer(ctx)
+	if err != nil {
+		return false, err
+	}
+
+	d.deployed = kubectlDeployer
+	return kubectlDeployer.Deploy(ctx, forceDeploy)
+}
+
+// Render writes the evaluated manifests to the out stream
+func (d *DeployConfig) Render(ctx devspacecontext.Context, out io.Writer) error {
+	kubectlDeployer, err := d.kubectlDeployer(ctx)
+	if err != nil {
+		return err
+	}
+
+	return kubectlDeployer.Render(ctx, out)
+}
+
+// Rollback re-applies the manifests of a previous revision
+func (d *DeployConfig) Rollback(ctx devspacecontext.Context, revision int) error {
+	kubectlDeployer, err := d.kubectlDeployer(ctx)
+	if err != nil {
+		return err
+	}
+
+	return kubectlDeployer.Rollback(ctx, revision)
+}
+
+// DryRun applies the evaluated manifests as server side dry run
+func (d *DeployConfig) DryRun(ctx devspacecontext.Context) error {
+	kubectlDeployer, err := d.kubectlDeployer(ctx)
+	if err != nil {
+		return err
+	}
+
+	return kubectlDeployer.(deployer.DryRunInterface).DryRun(ctx)
+}
+
+// Deployed returns the manifests of the last deploy
+func (d *DeployConfig) Deployed(ctx devspacecontext.Context) (*deployer.DeployedResult, error) {
+	if d.deployed != nil {
+		return d.deployed.(deployer.DriftInterface).Deployed(ctx)
+	}
+
+	kubectlDeployer, err := d.kubectlDeployer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return kubectlDeployer.(deployer.DriftInterface).Deployed(ctx)
+}
+
+// kubectlDeployer evaluates the jsonnet files and creates a kubectl deployer for the result
+func (d *DeployConfig) kubectlDeployer(ctx devspacecontext.Context) (deployer.Interface, error) {
+	manifests, err := Evaluate(ctx, d.DeploymentConfig.Jsonnet)
+	if err != nil {
+		return nil, err
+	} else if manifests == "" {
+		return nil, errors.Errorf("jsonnet files of deployment %s did not evaluate to any object", d.DeploymentConfig.Name)
+	}
+
+	jsonnetConfig := d.DeploymentConfig.Jsonnet
+	deployConfig := *d.DeploymentConfig
+	deployConfig.Kubectl = &latest.KubectlConfig{
+		InlineManifest:       manifests,
+		ApplyArgs:            jsonnetConfig.ApplyArgs,
+		KubectlBinaryPath:    jsonnetConfig.KubectlBinaryPath,
+		ServerSideApply:      jsonnetConfig.ServerSideApply,
+		Prune:                jsonnetConfig.Prune,
+		RevisionHistoryLimit: jsonnetConfig.RevisionHistoryLimit,
+		Patches:              jsonnetConfig.Patches,
+	}
+
+	return kubectl.New(ctx, &deployConfig)
+}
@@ -0,0 +1,222 @@
+package kubectl
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/manifests"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+)
+
+// FieldManager is the field manager DevSpace uses for server side apply
+const FieldManager = "devspace"
+
+// serverSideApplier applies objects in-process via server side apply
+type serverSideApplier struct {
+	client dynamic.Interface
+	mapper *restmapper.DeferredDiscoveryRESTMapper
+}
+
+func newServerSideApplier(ctx devspacecontext.Context) (*serverSideApplier, error) {
+	client, err := dynamic.NewForConfig(ctx.KubeClient().RestConfig())
+	if err != nil {
+		return nil, errors.Wrap(err, "create dynamic client")
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(ctx.KubeClient().RestConfig())
+	if err != nil {
+		return nil, errors.Wrap(err, "create discovery client")
+	}
+
+	return &serverSideApplier{
+		client: client,
+		mapper: restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient)),
+	}, nil
+}
+
+// resource returns the resource client for the object. Cluster scoped objects are stripped of their namespace.
+func (s *serverSideApplier) resource(obj *unstructured.Unstructured) (dynamic.ResourceInterface, error) {
+	gvk := obj.GroupVersionKind()
+	mapping, err := s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	if meta.IsNoMatchError(err) {
+		// the kind might have been created by a previously applied custom resource definition
+		s.mapper.Reset()
+		mapping, err = s.mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if mapping.Scope.Name() != meta.RESTScopeNameNamespace {
+		obj.SetNamespace("")
+		return s.client.Resource(mapping.Resource), nil
+	}
+
+	return s.client.Resource(mapping.Resource).Namespace(obj.GetNamespace()), nil
+}
+
+// apply applies the objects one after another and logs for each object if it was created, configured or unchanged.
+// If dryRun is true, the objects are only applied as server side dry run.
+func (s *serverSideApplier) apply(ctx devspacecontext.Context, objects []*unstructured.Unstructured, dryRun bool) error {
+	suffix := ""
+	if dryRun {
+		suffix = " (server dry run)"
+	}
+
+	force := true
+	for _, obj := range objects {
+		resource, err := s.resource(obj)
+		if err != nil {
+			if dryRun && meta.IsNoMatchError(err) {
+				ctx.Log().Infof("%s created (unknown kind, server dry run)", objectID(obj))
+				continue
+			}
+
+			return errors.Wrapf(err, "find resource of %s", objectID(obj))
+		}
+
+		live, err := resource.Get(ctx.Context(), obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return errors.Wrapf(err, "get %s", objectID(obj))
+			}
+
+			live = nil
+		}
+
+		data, err := json.Marshal(obj.Object)
+		if err != nil {
+			return errors.Wrap(err, "marshal object")
+		}
+
+		options := metav1.PatchOptions{
+			FieldManager: FieldManager,
+			Force:        &force,
+		}
+		if dryRun {
+			options.DryRun = []string{metav1.DryRunAll}
+		}
+
+		applied, err := resource.Patch(ctx.Context(), obj.GetName(), types.ApplyPatchType, data, options)
+		if err != nil {
+			return errors.Wrapf(err, "apply %s", objectID(obj))
+		}
+
+		action := "configured"
+		if live == nil {
+			action = "created"
+		} else if !objectChanged(live, applied) {
+			action = "unchanged"
+		}
+
+		ctx.Log().Infof("%s %s%s", objectID(obj), action, suffix)
+	}
+
+	return nil
+}
+
+// serverSideApply parses the manifests and applies them via server side apply
+func (d *DeployConfig) serverSideApply(ctx devspacecontext.Context, manifestsStr string, dryRun bool) error {
+	objects, err := manifests.Parse(manifestsStr)
+	if err != nil {
+		return err
+	}
+
+	applier, err := newServerSideApplier(ctx)
+	if err != nil {
+		return err
+	}
+
+	return applier.apply(ctx, objects, dryRun)
+}
+
+// prune returns true if objects that were removed from the manifests should be deleted
+func (d *DeployConfig) prune() bool {
+	if d.DeploymentConfig.Kubectl.Prune != nil {
+		return *d.DeploymentConfig.Kubectl.Prune
+	}
+
+	return d.DeploymentConfig.Kubectl.ServerSideApply
+}
+
+// DryRun applies the manifests as server side dry run and reports which objects would be created,
+// configured or pruned by a deploy
+func (d *DeployConfig) DryRun(ctx devspacecontext.Context) error {
+	rendered := &strings.Builder{}
+	err := d.Render(ctx, rendered)
+	if err != nil {
+		return err
+	}
+
+	objects, err := manifests.Parse(rendered.String())
+	if err != nil {
+		return err
+	}
+
+	// the object references have to be collected before applying, because applying
+	// removes the namespace of cluster scoped objects
+	kubeObjects := []remotecache.KubectlObject{}
+	for _, obj := range objects {
+		kubeObjects = append(kubeObjects, remotecache.KubectlObject{
+			APIVersion: obj.GetAPIVersion(),
+			Kind:       obj.GetKind(),
+			Name:       obj.GetName(),
+			Namespace:  obj.GetNamespace(),
+		})
+	}
+
+	applier, err := newServerSideApplier(ctx)
+	if err != nil {
+		return err
+	}
+
+	err = applier.apply(ctx, objects, true)
+	if err != nil {
+		return err
+	}
+
+	if d.prune() {
+		deployCache, ok := ctx.Config().RemoteCache().GetDeployment(d.DeploymentConfig.Name)
+		if ok && deployCache.Kubectl != nil {
+			for _, obj := range pruneObjects(deployCache.Kubectl.Objects, kubeObjects) {
+				ctx.Log().Infof("%s pruned (server dry run)", kubectlObjectID(obj))
+			}
+		}
+	}
+
+	return nil
+}
+
+// objectChanged checks if applying changed the live object while ignoring fields that change on every write
+func objectChanged(live, applied *unstructured.Unstructured) bool {
+	strip := func(obj *unstructured.Unstructured) map[string]interface{} {
+		copied := obj.DeepCopy()
+		unstructured.RemoveNestedField(copied.Object, "metadata", "managedFields")
+		unstructured.RemoveNestedField(copied.Object, "metadata", "resourceVersion")
+		unstructured.RemoveNestedField(copied.Object, "metadata", "generation")
+		return copied.Object
+	}
+
+	return !reflect.DeepEqual(strip(live), strip(applied))
+}
+
+func objectID(obj *unstructured.Unstructured) string {
+	return strings.ToLower(obj.GroupVersionKind().GroupKind().String()) + "/" + obj.GetName()
+}
+
+// kubectlObjectID returns the same id as objectID for a cached object
+func kubectlObjectID(obj remotecache.KubectlObject) string {
+	return strings.ToLower(schema.FromAPIVersionAndKind(obj.APIVersion, obj.Kind).GroupKind().String()) + "/" + obj.Name
+}
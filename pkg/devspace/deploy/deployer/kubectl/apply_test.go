@@ -0,0 +1,62 @@
+package kubectl
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestObjectChanged(t *testing.T) {
+	live := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "ConfigMap",
+		"metadata": map[string]interface{}{
+			"name":            "test",
+			"resourceVersion": "1",
+			"managedFields":   []interface{}{map[string]interface{}{"manager": "devspace", "time": "2022-01-01T00:00:00Z"}},
+		},
+		"data": map[string]interface{}{"key": "value"},
+	}}
+
+	applied := live.DeepCopy()
+	applied.SetResourceVersion("2")
+	applied.Object["metadata"].(map[string]interface{})["managedFields"] = []interface{}{map[string]interface{}{"manager": "devspace", "time": "2022-01-02T00:00:00Z"}}
+	assert.Equal(t, objectChanged(live, applied), false)
+
+	applied.Object["data"] = map[string]interface{}{"key": "other"}
+	assert.Equal(t, objectChanged(live, applied), true)
+}
+
+func TestPrune(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	testCases := []struct {
+		kubectlConfig *latest.KubectlConfig
+		expected      bool
+	}{
+		{
+			kubectlConfig: &latest.KubectlConfig{},
+			expected:      false,
+		},
+		{
+			kubectlConfig: &latest.KubectlConfig{ServerSideApply: true},
+			expected:      true,
+		},
+		{
+			kubectlConfig: &latest.KubectlConfig{ServerSideApply: true, Prune: &disabled},
+			expected:      false,
+		},
+		{
+			kubectlConfig: &latest.KubectlConfig{Prune: &enabled},
+			expected:      true,
+		},
+	}
+
+	for idx, testCase := range testCases {
+		deployer := &DeployConfig{DeploymentConfig: &latest.DeploymentConfig{Kubectl: testCase.kubectlConfig}}
+		assert.Equal(t, deployer.prune(), testCase.expected, "Unexpected result in testCase %d", idx)
+	}
+}
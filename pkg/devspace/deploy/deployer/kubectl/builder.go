@@ -58,7 +58,15 @@ func (k *kustomizeBuilder) Build(ctx context.Context, environ expand.Environ, di
 		return nil, err
 	}
 
-	return stringToUnstructuredArray(string(output))
+	// the kustomize binary can't decrypt sops encrypted resources, so make sure they are not deployed encrypted
+	objects, err := stringToUnstructuredArray(string(output))
+	for _, object := range objects {
+		if _, ok := object.Object["sops"]; ok {
+			return nil, errors.Errorf("%s %s is encrypted with sops, which is only supported by the built-in kustomize, please remove kustomizeBinaryPath and use kustomizeArgs the built-in kustomize supports", object.GetKind(), object.GetName())
+		}
+	}
+
+	return objects, err
 }
 
 type kubectlBuilder struct {
@@ -1,6 +1,7 @@
 package kubectl
 
 import (
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
 )
@@ -11,7 +12,12 @@ func Delete(ctx devspacecontext.Context, deploymentName string) error {
 		return nil
 	}
 
-	for _, resource := range deploymentCache.Kubectl.Objects {
+	deleteObjects(ctx, deploymentCache.Kubectl.Objects)
+	return nil
+}
+
+func deleteObjects(ctx devspacecontext.Context, objects []remotecache.KubectlObject) {
+	for _, resource := range objects {
 		_, err := ctx.KubeClient().GenericRequest(ctx.Context(), &kubectl.GenericRequestOptions{
 			Kind:       resource.Kind,
 			APIVersion: resource.APIVersion,
@@ -23,5 +29,4 @@ func Delete(ctx devspacecontext.Context, deploymentName string) error {
 			ctx.Log().Errorf("error deleting %s %s: %v", resource.Kind, resource.Name, err)
 		}
 	}
-	return nil
 }
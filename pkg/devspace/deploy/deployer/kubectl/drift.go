@@ -0,0 +1,60 @@
+package kubectl
+
+import (
+	"strings"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	jsonyaml "sigs.k8s.io/yaml"
+)
+
+// Deployed returns the manifests applied by the last Deploy of this deployer or of the latest revision.
+// Without a revision history only the object references are known, so only deleted objects can be detected.
+func (d *DeployConfig) Deployed(ctx devspacecontext.Context) (*deployer.DeployedResult, error) {
+	deployCache, ok := ctx.Config().RemoteCache().GetDeployment(d.DeploymentConfig.Name)
+	if !ok || deployCache.Kubectl == nil {
+		return nil, nil
+	}
+
+	configHash, err := d.configHash()
+	if err != nil {
+		return nil, err
+	}
+
+	manifests := ""
+	if d.appliedManifests != "" {
+		// the manifests were just applied, e.g. before waiting for the rollout
+		manifests = d.appliedManifests
+	} else if len(deployCache.Kubectl.Revisions) > 0 {
+		manifests, err = decompressManifests(deployCache.Kubectl.Revisions[len(deployCache.Kubectl.Revisions)-1].Manifests)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		references := []string{}
+		for _, object := range deployCache.Kubectl.Objects {
+			reference := &unstructured.Unstructured{}
+			reference.SetAPIVersion(object.APIVersion)
+			reference.SetKind(object.Kind)
+			reference.SetName(object.Name)
+			reference.SetNamespace(object.Namespace)
+
+			out, err := jsonyaml.Marshal(reference)
+			if err != nil {
+				return nil, errors.Wrap(err, "marshal yaml")
+			}
+
+			references = append(references, string(out))
+		}
+
+		manifests = strings.Join(references, "\n---\n")
+	}
+
+	return &deployer.DeployedResult{
+		Manifests:     manifests,
+		Namespace:     d.Namespace,
+		ConfigChanged: deployCache.DeploymentConfigHash != configHash,
+	}, nil
+}
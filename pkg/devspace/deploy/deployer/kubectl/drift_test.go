@@ -0,0 +1,69 @@
+package kubectl
+
+import (
+	"context"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestDeployed(t *testing.T) {
+	cache := remotecache.NewCache("testConfig", "testSecret")
+	conf := config.NewConfig(map[string]interface{}{},
+		map[string]interface{}{},
+		&latest.Config{},
+		localcache.New(constants.DefaultCacheFolder),
+		cache,
+		map[string]interface{}{},
+		constants.DefaultConfigPath)
+	devCtx := devspacecontext.NewContext(context.Background(), nil, log.Discard).WithConfig(conf)
+
+	deployer := &DeployConfig{
+		Namespace:        "default",
+		DeploymentConfig: &latest.DeploymentConfig{Name: "deploy1", Kubectl: &latest.KubectlConfig{}},
+	}
+
+	// not deployed yet
+	deployed, err := deployer.Deployed(devCtx)
+	assert.NilError(t, err)
+	assert.Assert(t, deployed == nil)
+
+	// without revisions only the object references are known
+	configHash, err := deployer.configHash()
+	assert.NilError(t, err)
+	objects := []remotecache.KubectlObject{{APIVersion: "v1", Kind: "ConfigMap", Name: "test", Namespace: "default"}}
+	cache.SetDeployment("deploy1", remotecache.DeploymentCache{
+		Name:                 "deploy1",
+		DeploymentConfigHash: configHash,
+		Kubectl:              &remotecache.KubectlCache{Objects: objects},
+	})
+	deployed, err = deployer.Deployed(devCtx)
+	assert.NilError(t, err)
+	assert.Equal(t, deployed.ConfigChanged, false)
+	assert.Equal(t, deployed.Manifests, "apiVersion: v1\nkind: ConfigMap\nmetadata:\n  name: test\n  namespace: default\n")
+
+	// the latest revision holds the deployed manifests
+	revisions, err := addRevision(nil, "kind: ConfigMap", objects, 5)
+	assert.NilError(t, err)
+	cache.SetDeployment("deploy1", remotecache.DeploymentCache{
+		Name:    "deploy1",
+		Kubectl: &remotecache.KubectlCache{Objects: objects, Revisions: revisions},
+	})
+	deployed, err = deployer.Deployed(devCtx)
+	assert.NilError(t, err)
+	assert.Equal(t, deployed.ConfigChanged, true)
+	assert.Equal(t, deployed.Manifests, "kind: ConfigMap")
+
+	// the manifests applied by the deployer are returned without reading the revisions
+	deployer.appliedManifests = "kind: Secret"
+	deployed, err = deployer.Deployed(devCtx)
+	assert.NilError(t, err)
+	assert.Equal(t, deployed.Manifests, "kind: Secret")
+}
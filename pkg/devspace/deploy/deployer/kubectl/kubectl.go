@@ -2,13 +2,12 @@ package kubectl
 
 import (
 	"bytes"
-	"context"
 	"io"
 	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
-	"github.com/loft-sh/devspace/pkg/devspace/config/loader/patch"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/legacy"
 	"github.com/loft-sh/devspace/pkg/devspace/config/loader/variable/runtime"
 	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
@@ -16,7 +15,9 @@ import (
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/context/values"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/manifests"
 	"github.com/loft-sh/devspace/pkg/util/hash"
+	"github.com/loft-sh/devspace/pkg/util/sops"
 	"github.com/loft-sh/devspace/pkg/util/stringutil"
 	"github.com/loft-sh/utils/pkg/command"
 	"github.com/loft-sh/utils/pkg/downloader"
@@ -24,7 +25,6 @@ import (
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
-	"mvdan.cc/sh/v3/expand"
 	jsonyaml "sigs.k8s.io/yaml"
 )
 
@@ -41,6 +41,10 @@ type DeployConfig struct {
 	Manifests      []string
 
 	DeploymentConfig *latest.DeploymentConfig
+
+	// appliedManifests are the manifests applied by Deploy, which Deployed returns without
+	// reading them from the remote cache
+	appliedManifests string
 }
 
 // New creates a new deploy config for kubectl
@@ -116,6 +120,21 @@ func (d *DeployConfig) Render(ctx devspacecontext.Context, out io.Writer) error
 		_, _ = out.Write([]byte("\n---\n"))
 	}
 
+	if d.InlineManifest != "" {
+		resolvedInlineManifest, err := runtime.NewRuntimeResolver(ctx.WorkingDir(), false).FillRuntimeVariablesAsString(ctx.Context(), d.InlineManifest, ctx.Config(), ctx.Dependencies())
+		if err != nil {
+			return err
+		}
+
+		_, replacedManifest, _, err := d.getReplacedManifest(ctx, true, resolvedInlineManifest)
+		if err != nil {
+			return errors.Errorf("%v\nPlease make sure `kubectl apply` does work locally with the inline manifest", err)
+		}
+
+		_, _ = out.Write([]byte(replacedManifest))
+		_, _ = out.Write([]byte("\n---\n"))
+	}
+
 	return nil
 }
 
@@ -158,13 +177,11 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, _ bool) (bool, error)
 	}
 
 	// Hash the deployment config
-	configStr, err := jsonyaml.Marshal(d.DeploymentConfig)
+	deploymentConfigHash, err := d.configHash()
 	if err != nil {
-		return false, errors.Wrap(err, "marshal deployment config")
+		return false, err
 	}
 
-	deploymentConfigHash := hash.String(string(configStr))
-
 	// We force the redeploy of kubectl deployments for now, because we don't know if they are already currently deployed or not,
 	// so it is better to force deploy them, which usually takes almost no time and is better than taking the risk of skipping a needed deployment
 	// forceDeploy = forceDeploy || deployCache.KubectlManifestsHash != manifestsHash || deployCache.DeploymentConfigHash != deploymentConfigHash
@@ -173,12 +190,16 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, _ bool) (bool, error)
 	ctx.Log().Info("Applying manifests with kubectl...")
 	wasDeployed := false
 	kubeObjects := []remotecache.KubectlObject{}
+	appliedManifests := []string{}
 
 	for _, manifest := range d.Manifests {
-		wasDeployed, kubeObjects, err = d.applyManifest(ctx, kubeObjects, forceDeploy, false, manifest)
+		var appliedManifest string
+		wasDeployed, appliedManifest, kubeObjects, err = d.applyManifest(ctx, kubeObjects, forceDeploy, false, manifest)
 		if err != nil {
 			return false, err
 		}
+
+		appliedManifests = append(appliedManifests, appliedManifest)
 	}
 
 	// Special case for inline manifests
@@ -189,15 +210,38 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, _ bool) (bool, error)
 			return false, err
 		}
 		// proceed with regular apply
-		wasDeployed, kubeObjects, err = d.applyManifest(ctx, kubeObjects, forceDeploy, true, resolvedInlineManifest)
+		var appliedManifest string
+		wasDeployed, appliedManifest, kubeObjects, err = d.applyManifest(ctx, kubeObjects, forceDeploy, true, resolvedInlineManifest)
 		if err != nil {
 			return false, err
 		}
+
+		appliedManifests = append(appliedManifests, appliedManifest)
+	}
+
+	var revisions []remotecache.KubectlRevision
+	if deployCache.Kubectl != nil {
+		revisions = deployCache.Kubectl.Revisions
+
+		// delete the objects that were removed from the manifests
+		if d.prune() {
+			removed := pruneObjects(deployCache.Kubectl.Objects, kubeObjects)
+			if len(removed) > 0 {
+				ctx.Log().Infof("Pruning %d object(s) that were removed from the manifests...", len(removed))
+				deleteObjects(ctx, removed)
+			}
+		}
+	}
+	d.appliedManifests = strings.Join(appliedManifests, "\n---\n")
+	revisions, err = addRevision(revisions, d.appliedManifests, kubeObjects, d.revisionHistoryLimit())
+	if err != nil {
+		return false, err
 	}
 
 	deployCache.Kubectl = &remotecache.KubectlCache{
 		Objects:       kubeObjects,
 		ManifestsHash: manifestsHash,
+		Revisions:     revisions,
 	}
 	deployCache.DeploymentConfigHash = deploymentConfigHash
 	if rootName, ok := values.RootNameFrom(ctx.Context()); ok && !stringutil.Contains(deployCache.Projects, rootName) {
@@ -207,30 +251,54 @@ func (d *DeployConfig) Deploy(ctx devspacecontext.Context, _ bool) (bool, error)
 	return wasDeployed, nil
 }
 
-func (d *DeployConfig) applyManifest(ctx devspacecontext.Context, kubeObjects []remotecache.KubectlObject, forceDeploy, inline bool, manifest string) (bool, []remotecache.KubectlObject, error) {
+func (d *DeployConfig) applyManifest(ctx devspacecontext.Context, kubeObjects []remotecache.KubectlObject, forceDeploy, inline bool, manifest string) (bool, string, []remotecache.KubectlObject, error) {
 	shouldRedeploy, replacedManifest, parsedObjects, err := d.getReplacedManifest(ctx, inline, manifest)
 	if err != nil {
-		return false, nil, errors.Errorf("%v\nPlease make sure `kubectl apply` does work locally with manifest `%s`", err, manifest)
+		return false, "", nil, errors.Errorf("%v\nPlease make sure `kubectl apply` does work locally with manifest `%s`", err, manifest)
 	}
-	writer := ctx.Log().Writer(logrus.InfoLevel, false)
-	defer writer.Close()
 
 	kubeObjects = append(kubeObjects, parsedObjects...)
 	if shouldRedeploy || forceDeploy {
-		args := d.getCmdArgs("apply", "--force")
-		args = append(args, d.DeploymentConfig.Kubectl.ApplyArgs...)
-
-		stdErrBuffer := &bytes.Buffer{}
-		err = command.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, io.MultiWriter(writer, stdErrBuffer), strings.NewReader(replacedManifest), d.CmdPath, args...)
+		err = d.apply(ctx, replacedManifest)
 		if err != nil {
-			return false, nil, errors.Errorf("%v %v\nPlease make sure the command `kubectl apply` does work locally with manifest `%s`", stdErrBuffer.String(), err, manifest)
+			return false, "", nil, errors.Errorf("%v\nPlease make sure the command `kubectl apply` does work locally with manifest `%s`", err, manifest)
 		}
-
 	} else {
 		ctx.Log().Infof("Skipping manifest %s", manifest)
 	}
 
-	return true, kubeObjects, nil
+	return true, replacedManifest, kubeObjects, nil
+}
+
+// apply runs kubectl apply with the given manifests or applies them via server side apply
+func (d *DeployConfig) apply(ctx devspacecontext.Context, manifests string) error {
+	if d.DeploymentConfig.Kubectl.ServerSideApply {
+		return d.serverSideApply(ctx, manifests, false)
+	}
+
+	writer := ctx.Log().Writer(logrus.InfoLevel, false)
+	defer writer.Close()
+
+	args := d.getCmdArgs("apply", "--force")
+	args = append(args, d.DeploymentConfig.Kubectl.ApplyArgs...)
+
+	stdErrBuffer := &bytes.Buffer{}
+	err := command.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), writer, io.MultiWriter(writer, stdErrBuffer), strings.NewReader(manifests), d.CmdPath, args...)
+	if err != nil {
+		return errors.Errorf("%v %v", stdErrBuffer.String(), err)
+	}
+
+	return nil
+}
+
+// configHash hashes the deployment config to detect changes since the last deploy
+func (d *DeployConfig) configHash() (string, error) {
+	configStr, err := jsonyaml.Marshal(d.DeploymentConfig)
+	if err != nil {
+		return "", errors.Wrap(err, "marshal deployment config")
+	}
+
+	return hash.String(string(configStr)), nil
 }
 
 func (d *DeployConfig) getReplacedManifest(ctx devspacecontext.Context, inline bool, manifest string) (bool, string, []remotecache.KubectlObject, error) {
@@ -243,6 +311,15 @@ func (d *DeployConfig) getReplacedManifest(ctx devspacecontext.Context, inline b
 			return false, "", nil, err
 		}
 	} else {
+		if sops.IsEncrypted([]byte(manifest)) {
+			decrypted, err := sops.Decrypt(ctx.Context(), []byte(manifest), ctx.Environ())
+			if err != nil {
+				return false, "", nil, errors.Wrap(err, "decrypt inline manifest")
+			}
+
+			manifest = string(decrypted)
+		}
+
 		objects, err = stringToUnstructuredArray(manifest)
 		if err != nil {
 			return false, "", nil, err
@@ -281,7 +358,7 @@ func (d *DeployConfig) getReplacedManifest(ctx devspacecontext.Context, inline b
 			}
 		}
 
-		resource, err := d.applyDeployPatches(ctx, resource)
+		resource, err := manifests.Patch(resource, d.DeploymentConfig.Kubectl.Patches, ctx.Log())
 		if err != nil {
 			// we're skipping a patch
 			ctx.Log().Warn(err)
@@ -315,15 +392,85 @@ func (d *DeployConfig) getCmdArgs(method string, additionalArgs ...string) []str
 
 func (d *DeployConfig) buildManifests(ctx devspacecontext.Context, manifest string) ([]*unstructured.Unstructured, error) {
 	// Check if we should use kustomize or kubectl
-	kustomizePath := "kustomize"
-	if d.DeploymentConfig.Kubectl.KustomizeBinaryPath != "" {
-		kustomizePath = d.DeploymentConfig.Kubectl.KustomizeBinaryPath
+	if d.DeploymentConfig.Kubectl.Kustomize != nil && *d.DeploymentConfig.Kubectl.Kustomize {
+		if d.DeploymentConfig.Kubectl.KustomizeBinaryPath != "" {
+			return NewKustomizeBuilder(d.DeploymentConfig.Kubectl.KustomizeBinaryPath, d.DeploymentConfig, ctx.Log()).Build(ctx.Context(), ctx.Environ(), ctx.WorkingDir(), manifest)
+		}
+
+		return NewKustomizeAPIBuilder(d.DeploymentConfig, ctx.Log()).Build(ctx.Context(), ctx.Environ(), ctx.WorkingDir(), manifest)
+	}
+
+	// sops encrypted files are decrypted in memory instead of passing them to kubectl
+	pattern := d.manifestPattern(manifest)
+	files, err := manifestFiles(ctx.ResolvePath(pattern))
+	if err != nil {
+		return nil, err
+	}
+
+	encrypted := map[string][]byte{}
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		if err != nil {
+			return nil, err
+		} else if sops.IsEncrypted(data) {
+			encrypted[file] = data
+		}
+	}
+	if len(encrypted) == 0 {
+		return d.buildWithKubectl(ctx, manifest)
+	}
+
+	// only if a file is encrypted, the files are built in order, consecutive plain files are rendered by kubectl together
+	objects := []*unstructured.Unstructured{}
+	plainFiles := []string{}
+	buildPlainFiles := func() error {
+		if len(plainFiles) == 0 {
+			return nil
+		}
+
+		built, err := d.buildWithKubectl(ctx, strings.Join(plainFiles, ","))
+		if err != nil {
+			return err
+		}
+
+		objects = append(objects, built...)
+		plainFiles = []string{}
+		return nil
 	}
+	for _, file := range files {
+		data, ok := encrypted[file]
+		if !ok {
+			plainFiles = append(plainFiles, file)
+			continue
+		}
+
+		err = buildPlainFiles()
+		if err != nil {
+			return nil, err
+		}
 
-	if d.DeploymentConfig.Kubectl.Kustomize != nil && *d.DeploymentConfig.Kubectl.Kustomize && d.isKustomizeInstalled(ctx.Context(), ctx.WorkingDir(), kustomizePath) {
-		return NewKustomizeBuilder(kustomizePath, d.DeploymentConfig, ctx.Log()).Build(ctx.Context(), ctx.Environ(), ctx.WorkingDir(), manifest)
+		decrypted, err := sops.Decrypt(ctx.Context(), data, ctx.Environ())
+		if err != nil {
+			return nil, errors.Wrapf(err, "decrypt %s", file)
+		}
+
+		built, err := stringToUnstructuredArray(string(decrypted))
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse %s", file)
+		}
+
+		objects = append(objects, built...)
 	}
 
+	err = buildPlainFiles()
+	if err != nil {
+		return nil, err
+	}
+
+	return objects, nil
+}
+
+func (d *DeployConfig) buildWithKubectl(ctx devspacecontext.Context, manifest string) ([]*unstructured.Unstructured, error) {
 	raw, err := ctx.KubeClient().KubeConfigLoader().LoadRawConfig()
 	if err != nil {
 		return nil, errors.Errorf("get raw config")
@@ -337,64 +484,67 @@ func (d *DeployConfig) buildManifests(ctx devspacecontext.Context, manifest stri
 	return NewKubectlBuilder(d.CmdPath, d.DeploymentConfig, *copied).Build(ctx.Context(), ctx.Environ(), ctx.WorkingDir(), manifest)
 }
 
-func (d *DeployConfig) isKustomizeInstalled(ctx context.Context, dir, path string) bool {
-	err := command.Command(ctx, dir, expand.ListEnviron(os.Environ()...), nil, nil, nil, path, "version")
-	return err == nil
+// manifestPattern returns the manifest as configured, because New strips the globs kubectl doesn't understand
+func (d *DeployConfig) manifestPattern(manifest string) string {
+	for _, pattern := range d.DeploymentConfig.Kubectl.Manifests {
+		if strings.ReplaceAll(pattern, "*", "") == manifest {
+			return pattern
+		}
+	}
+
+	return manifest
 }
 
-func (d *DeployConfig) applyDeployPatches(ctx devspacecontext.Context, resource *unstructured.Unstructured) (*unstructured.Unstructured, error) {
-	out, err := jsonyaml.Marshal(resource)
-	if err != nil {
-		return resource, err
+// manifestFiles returns the files kubectl would read for the given path, glob or directory.
+// Like kubectl, directories are not read recursively and only yaml and json files are used.
+func manifestFiles(pattern string) ([]string, error) {
+	if strings.HasPrefix(pattern, "http://") || strings.HasPrefix(pattern, "https://") {
+		return nil, nil
 	}
 
-	patches := patch.Patch{}
-	for idx, kubepatch := range d.DeploymentConfig.Kubectl.Patches {
-		newPatch := patch.Operation{
-			Op:   patch.Op(kubepatch.Operation),
-			Path: patch.OpPath(patch.TransformPath(kubepatch.Path)),
+	paths := []string{pattern}
+	if strings.Contains(pattern, "*") {
+		var err error
+		paths, err = filepath.Glob(pattern)
+		if err != nil {
+			return nil, errors.Wrapf(err, "expand %s", pattern)
 		}
+	}
 
-		if kubepatch.Target.Name != resource.GetName() {
+	files := []string{}
+	for _, path := range paths {
+		stat, err := os.Stat(path)
+		if err != nil {
+			// let kubectl report missing files
 			continue
-		}
-
-		// non-mandatory field, check only if defined
-		if kubepatch.Target.Kind != "" && resource.GetKind() != kubepatch.Target.Kind {
-			ctx.Log().Debugf("skipping patch, resource kind match: %s - %s", kubepatch.Target.Kind, resource.GetKind())
+		} else if !stat.IsDir() {
+			if path == pattern || isManifestFile(path) {
+				files = append(files, path)
+			}
 			continue
-		}
-
-		// non-mandatory field, check only if defined
-		if kubepatch.Target.APIVersion != "" && resource.GetAPIVersion() != kubepatch.Target.APIVersion {
-			ctx.Log().Debugf("skipping patch, resource api mismatch: %s - %s", kubepatch.Target.APIVersion, resource.GetAPIVersion())
+		} else if path != pattern {
 			continue
 		}
 
-		if kubepatch.Value != nil {
-			value, err := patch.NewNode(&kubepatch.Value)
-			if err != nil {
-				return resource, errors.Errorf("value %d is invalid", idx)
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			if !entry.IsDir() && isManifestFile(entry.Name()) {
+				files = append(files, filepath.Join(path, entry.Name()))
 			}
-			newPatch.Value = value
 		}
-
-		// TODO Maybe log here that we're indeed applying a patch?
-		ctx.Log().Debugf("applying patch: %s.%s", kubepatch.Target.Name, kubepatch.Path)
-		patches = append(patches, newPatch)
 	}
 
-	out, err = patches.Apply(out)
-	if err != nil {
-		return resource, errors.Wrap(err, "apply patches")
-	}
+	return files, nil
+}
 
-	// transform resource back to unstructured
-	var result unstructured.Unstructured
-	err = jsonyaml.Unmarshal(out, &result)
-	if err != nil {
-		return nil, err
+func isManifestFile(path string) bool {
+	switch filepath.Ext(path) {
+	case ".json", ".yaml", ".yml":
+		return true
 	}
 
-	return &result, nil
+	return false
 }
@@ -4,6 +4,9 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config"
@@ -18,6 +21,8 @@ import (
 	fakekube "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
 	log "github.com/loft-sh/devspace/pkg/util/log/testing"
 	"github.com/loft-sh/devspace/pkg/util/ptr"
+	"github.com/loft-sh/devspace/pkg/util/sops"
+	"mvdan.cc/sh/v3/expand"
 
 	yaml "gopkg.in/yaml.v3"
 	"gotest.tools/assert"
@@ -522,3 +527,121 @@ func TestGetReplacedManifest(t *testing.T) {
 		assert.Equal(t, replacedManifest, testCase.expectedManifest, "Unexpected replaced manifest in testCase %s", testCase.name)
 	}
 }
+
+// encryptedSecret was encrypted with sops 3.13.3 and the encrypted_regex ^(data|stringData)$,
+// the key was generated for these tests only
+const encryptedSecret = `apiVersion: v1
+kind: Secret
+metadata:
+    name: database
+stringData:
+    password: ENC[AES256_GCM,data:6BkPhiSf,iv:GKIJqyWtcTtsdbxnpeEsJabGWxHsuNhM29Ror8uqFIM=,tag:AhxcOTK4Gc+2bpoqg68o4g==,type:str]
+sops:
+    age:
+        - enc: |
+            -----BEGIN AGE ENCRYPTED FILE-----
+            YWdlLWVuY3J5cHRpb24ub3JnL3YxCi0+IFgyNTUxOSB0M0p5WU90eWd5MC9JeFBj
+            SmsvcGREeTQ2N0JWN0Rqb0xjOHRjUzk0bkVNCjNzMFFscFpTYlV2Z2hKMmsxTDNU
+            QmYzSzIybnJHWDFLZmdNUTlJcFhpSG8KLS0tIFIveXV1SXozL0ROZGJBZWlIMjA3
+            L2E1WFRMZ2RZdGxNWEJCRm0wUkJ5alUKGIG4bICGUTZlf7r9Xs68JvgdEFmYrGWG
+            ISINKWqwV2nwdK7O4wUCLecOZfU4AWRPhtkOEOPuvwxMlqR76/0Z+w==
+            -----END AGE ENCRYPTED FILE-----
+          recipient: age10y52aahtn0v5r6qg5fspceruncwdsvfysj6tv9n22pdx0jrfksjsc45zzx
+    encrypted_regex: ^(data|stringData)$
+    lastmodified: "2026-10-18T19:13:51Z"
+    mac: ENC[AES256_GCM,data:/iHNhGI125mIHKpd5O96WUi37REHeG3pXcEFU7XSzV/BZiRMn+E9Lx7S0W2OkzbRAzlhKEOEDMLUqWRnCUIIRhAbVVlt8fcdv0YvTHbSvkiTCVe96phy8DbpQ7YojLO9qg15/82x2N3pnaTSTa/5zb/YyDWetuRiICKJUBy8HCA=,iv:7L7ImWfLPRLIbwEGi8y5dB4na/vIC9mHYPUPZW4FIrU=,tag:1h9LI7HKiYQrssNOIVegHQ==,type:str]
+    version: 3.13.3
+`
+
+const secretAgeKey = `AGE-SECRET-KEY-1GREYRFTNKPSK7545W5G0TTVK6JV8SVKHRCT7NN27GUJM2HCZLDCQFC8PFP`
+
+type manifestFilesTestCase struct {
+	name    string
+	pattern string
+
+	expectedFiles []string
+}
+
+func TestManifestFiles(t *testing.T) {
+	dir := t.TempDir()
+	for _, file := range []string{"a.yaml", "b.yml", "c.json", "README.md", "sub/d.yaml"} {
+		assert.NilError(t, os.MkdirAll(filepath.Dir(filepath.Join(dir, file)), 0755))
+		assert.NilError(t, os.WriteFile(filepath.Join(dir, file), []byte("{}"), 0644))
+	}
+
+	testCases := []manifestFilesTestCase{
+		{
+			name:          "Single file",
+			pattern:       "README.md",
+			expectedFiles: []string{"README.md"},
+		},
+		{
+			name:          "Directory",
+			pattern:       ".",
+			expectedFiles: []string{"a.yaml", "b.yml", "c.json"},
+		},
+		{
+			name:          "Glob",
+			pattern:       "*",
+			expectedFiles: []string{"a.yaml", "b.yml", "c.json"},
+		},
+		{
+			name:          "Glob with extension",
+			pattern:       "sub/*.yaml",
+			expectedFiles: []string{"sub/d.yaml"},
+		},
+		{
+			name:          "Missing file",
+			pattern:       "missing.yaml",
+			expectedFiles: []string{},
+		},
+	}
+
+	for _, testCase := range testCases {
+		files, err := manifestFiles(filepath.Join(dir, testCase.pattern))
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+
+		expectedFiles := []string{}
+		for _, file := range testCase.expectedFiles {
+			expectedFiles = append(expectedFiles, filepath.Join(dir, file))
+		}
+		assert.DeepEqual(t, files, expectedFiles)
+	}
+}
+
+func TestBuildManifestsDecryptsSops(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "kube"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "kube", "database.yaml"), []byte(encryptedSecret), 0644))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "kube", "cache.yaml"), []byte(strings.Replace(encryptedSecret, "name: database", "name: cache", 1)), 0644))
+	devCtx := devspacecontext.NewContext(context.Background(), nil, log.NewFakeLogger()).
+		WithWorkingDir(dir).
+		WithEnviron(expand.ListEnviron(sops.AgeKeyEnv + "=" + secretAgeKey))
+
+	for _, pattern := range []string{"kube", "kube/*", "kube/*.yaml"} {
+		deployer, err := New(devCtx, &latest.DeploymentConfig{
+			Name:    "test",
+			Kubectl: &latest.KubectlConfig{Manifests: []string{pattern}, KubectlBinaryPath: "kubectl"},
+		})
+		assert.NilError(t, err)
+
+		// the cache secret was modified after encryption and must not be deployed
+		_, err = deployer.(*DeployConfig).buildManifests(devCtx, deployer.(*DeployConfig).Manifests[0])
+		assert.ErrorContains(t, err, "mac mismatch", "Wrong or no error for pattern %s", pattern)
+	}
+
+	assert.NilError(t, os.Remove(filepath.Join(dir, "kube", "cache.yaml")))
+	deployer, err := New(devCtx, &latest.DeploymentConfig{
+		Name:    "test",
+		Kubectl: &latest.KubectlConfig{Manifests: []string{"kube/*.yaml"}, KubectlBinaryPath: "kubectl"},
+	})
+	assert.NilError(t, err)
+
+	objects, err := deployer.(*DeployConfig).buildManifests(devCtx, deployer.(*DeployConfig).Manifests[0])
+	assert.NilError(t, err)
+	assert.Equal(t, len(objects), 1)
+	assert.Equal(t, objects[0].GetName(), "database")
+	assert.DeepEqual(t, objects[0].Object["stringData"], map[string]interface{}{"password": "s3cr3t"})
+	_, ok := objects[0].Object["sops"]
+	assert.Assert(t, !ok)
+}
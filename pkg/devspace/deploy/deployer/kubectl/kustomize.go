@@ -0,0 +1,347 @@
+package kubectl
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	dependencyutil "github.com/loft-sh/devspace/pkg/devspace/dependency/util"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/sops"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"mvdan.cc/sh/v3/expand"
+	"sigs.k8s.io/kustomize/api/konfig"
+	"sigs.k8s.io/kustomize/api/krusty"
+	"sigs.k8s.io/kustomize/api/types"
+	"sigs.k8s.io/kustomize/kyaml/filesys"
+	"sigs.k8s.io/yaml"
+)
+
+// defaultKustomizePath is the kustomize binary used for arguments the built-in kustomize doesn't support
+const defaultKustomizePath = "kustomize"
+
+// unsupportedArgError is returned for arguments of `kustomize build` the built-in kustomize doesn't support
+type unsupportedArgError struct {
+	arg string
+}
+
+func (e *unsupportedArgError) Error() string {
+	return fmt.Sprintf("kustomize argument %s is not supported by the built-in kustomize", e.arg)
+}
+
+type kustomizeAPIBuilder struct {
+	config *latest.DeploymentConfig
+	log    log.Logger
+}
+
+// NewKustomizeAPIBuilder creates a new manifest builder that builds kustomizations in-process
+func NewKustomizeAPIBuilder(config *latest.DeploymentConfig, log log.Logger) Builder {
+	return &kustomizeAPIBuilder{
+		config: config,
+		log:    log,
+	}
+}
+
+func (k *kustomizeAPIBuilder) Build(ctx context.Context, environ expand.Environ, dir, manifest string) ([]*unstructured.Unstructured, error) {
+	options, err := kustomizeOptions(k.config.Kubectl.KustomizeArgs)
+	if err != nil {
+		// arguments the built-in kustomize doesn't understand are passed to the kustomize binary instead
+		unsupportedErr := &unsupportedArgError{}
+		if !errors.As(err, &unsupportedErr) {
+			return nil, err
+		} else if _, lookErr := exec.LookPath(defaultKustomizePath); lookErr != nil {
+			return nil, errors.Errorf("%v and the kustomize binary was not found, please install kustomize or set kustomizeBinaryPath", err)
+		}
+
+		k.log.Debugf("%v, falling back to the kustomize binary", err)
+		return NewKustomizeBuilder(defaultKustomizePath, k.config, k.log).Build(ctx, environ, dir, manifest)
+	}
+
+	path := manifest
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(dir, path)
+	}
+
+	k.log.Infof("Render manifests with built-in kustomize %s", manifest)
+	fSys := &remoteBaseFileSystem{
+		FileSystem: filesys.MakeFsOnDisk(),
+		ctx:        ctx,
+		environ:    environ,
+		dir:        dir,
+		log:        k.log,
+	}
+	resources, err := krusty.MakeKustomizer(options).Run(fSys, path)
+	if err != nil {
+		return nil, err
+	}
+
+	out, err := resources.AsYaml()
+	if err != nil {
+		return nil, err
+	}
+
+	return stringToUnstructuredArray(string(out))
+}
+
+// kustomizeOptions converts the arguments of `kustomize build` into options for the built-in kustomize
+func kustomizeOptions(args []string) (*krusty.Options, error) {
+	options := krusty.MakeDefaultOptions()
+	for i := 0; i < len(args); i++ {
+		name, value, hasValue := strings.Cut(args[i], "=")
+		stringValue := func() (string, error) {
+			if hasValue {
+				return value, nil
+			} else if i+1 < len(args) {
+				i++
+				return args[i], nil
+			}
+
+			return "", errors.Errorf("kustomize argument %s requires a value", name)
+		}
+		boolValue := func() (bool, error) {
+			if !hasValue {
+				return true, nil
+			}
+
+			return strconv.ParseBool(value)
+		}
+
+		switch name {
+		case "--load-restrictor", "--load_restrictor":
+			restrictor, err := stringValue()
+			if err != nil {
+				return nil, err
+			}
+
+			switch restrictor {
+			case types.LoadRestrictionsNone.String(), "none":
+				options.LoadRestrictions = types.LoadRestrictionsNone
+			case types.LoadRestrictionsRootOnly.String(), "rootOnly":
+				options.LoadRestrictions = types.LoadRestrictionsRootOnly
+			default:
+				return nil, errors.Errorf("unknown kustomize load restrictor %s", restrictor)
+			}
+		case "--reorder":
+			reorder, err := stringValue()
+			if err != nil {
+				return nil, err
+			}
+
+			switch krusty.ReorderOption(reorder) {
+			case krusty.ReorderOptionLegacy, krusty.ReorderOptionNone:
+				options.Reorder = krusty.ReorderOption(reorder)
+			default:
+				return nil, errors.Errorf("unknown kustomize reorder option %s", reorder)
+			}
+		case "--enable-helm":
+			enabled, err := boolValue()
+			if err != nil {
+				return nil, err
+			}
+
+			options.PluginConfig.HelmConfig.Enabled = enabled
+			if options.PluginConfig.HelmConfig.Command == "" {
+				options.PluginConfig.HelmConfig.Command = "helm"
+			}
+		case "--helm-command":
+			command, err := stringValue()
+			if err != nil {
+				return nil, err
+			}
+
+			options.PluginConfig.HelmConfig.Command = command
+		case "--enable-managedby-label":
+			enabled, err := boolValue()
+			if err != nil {
+				return nil, err
+			}
+
+			options.AddManagedbyLabel = enabled
+		default:
+			return nil, &unsupportedArgError{arg: args[i]}
+		}
+	}
+
+	return options, nil
+}
+
+// remoteBaseFileSystem rewrites remote git bases in kustomization files to the local git
+// cache of DevSpace, so that remote bases are only cloned once and are also available offline.
+// Resources encrypted with sops are decrypted in memory.
+type remoteBaseFileSystem struct {
+	filesys.FileSystem
+
+	ctx     context.Context
+	environ expand.Environ
+	dir     string
+	log     log.Logger
+}
+
+func (r *remoteBaseFileSystem) ReadFile(path string) ([]byte, error) {
+	out, err := r.FileSystem.ReadFile(path)
+	if err != nil {
+		return nil, err
+	} else if !isKustomizationFile(path) {
+		if !sops.IsEncrypted(out) {
+			return out, nil
+		}
+
+		decrypted, err := sops.Decrypt(r.ctx, out, r.environ)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decrypt %s", path)
+		}
+
+		return decrypted, nil
+	}
+
+	kustomization := map[string]interface{}{}
+	err = yaml.Unmarshal(out, &kustomization)
+	if err != nil {
+		// let kustomize report the error
+		return out, nil
+	}
+
+	changed := false
+	for _, field := range []string{"resources", "bases", "components"} {
+		entries, ok := kustomization[field].([]interface{})
+		if !ok {
+			continue
+		}
+
+		for i, entry := range entries {
+			entryStr, ok := entry.(string)
+			if !ok || !isRemoteBase(entryStr) {
+				continue
+			}
+
+			localPath, err := r.download(entryStr)
+			if err != nil {
+				return nil, errors.Wrapf(err, "download remote base %s", entryStr)
+			}
+
+			entries[i] = localPath
+			changed = true
+		}
+	}
+	if !changed {
+		return out, nil
+	}
+
+	return yaml.Marshal(kustomization)
+}
+
+// download clones the remote base into the git cache and returns the local path of the base
+func (r *remoteBaseFileSystem) download(remoteBase string) (string, error) {
+	repository, subPath, ref := parseRemoteBase(remoteBase)
+	source := &latest.SourceConfig{
+		Git: repository,
+	}
+	if commitRegEx.MatchString(ref) {
+		source.Revision = ref
+	} else {
+		source.Branch = ref
+	}
+
+	configPath, err := dependencyutil.DownloadDependency(r.ctx, r.dir, source, r.log)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(filepath.Dir(configPath), filepath.FromSlash(subPath)), nil
+}
+
+var commitRegEx = regexp.MustCompile(`^[0-9a-f]{7,40}$`)
+
+func isKustomizationFile(path string) bool {
+	base := filepath.Base(path)
+	for _, name := range konfig.RecognizedKustomizationFileNames() {
+		if base == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+// isRemoteBase checks if the kustomization entry references a git repository
+func isRemoteBase(entry string) bool {
+	if strings.HasPrefix(entry, "git::") || strings.HasPrefix(entry, "git@") || strings.HasPrefix(entry, "ssh://") {
+		return true
+	}
+
+	if strings.HasPrefix(entry, "https://") || strings.HasPrefix(entry, "http://") {
+		// remote files are downloaded by kustomize itself
+		path, _, _ := strings.Cut(entry, "?")
+		ext := filepath.Ext(path)
+		return ext != ".yaml" && ext != ".yml" && ext != ".json"
+	}
+
+	for _, host := range []string{"github.com/", "gitlab.com/", "bitbucket.org/"} {
+		if strings.HasPrefix(entry, host) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// parseRemoteBase splits a remote base such as https://github.com/org/repo//path?ref=v1 into
+// the repository url, the path within the repository and the git ref
+func parseRemoteBase(entry string) (string, string, string) {
+	entry = strings.TrimPrefix(entry, "git::")
+
+	// parse the ref
+	ref := ""
+	entry, query, _ := strings.Cut(entry, "?")
+	for _, param := range strings.Split(query, "&") {
+		key, value, _ := strings.Cut(param, "=")
+		if key == "ref" || key == "version" {
+			ref = value
+		}
+	}
+
+	scheme := ""
+	if idx := strings.Index(entry, "://"); idx >= 0 {
+		scheme = entry[:idx+3]
+		entry = entry[idx+3:]
+	}
+	scpLike := scheme == "" && strings.HasPrefix(entry, "git@")
+
+	repository, subPath := "", ""
+	if idx := strings.Index(entry, "//"); idx >= 0 {
+		repository, subPath = entry[:idx], entry[idx+2:]
+	} else if idx := strings.Index(entry, ".git/"); idx >= 0 {
+		repository, subPath = entry[:idx+4], entry[idx+5:]
+	} else if strings.HasSuffix(entry, ".git") {
+		repository = entry
+	} else {
+		// the first two path segments after the host are the organization and the repository
+		separator := "/"
+		if scpLike {
+			separator = ":"
+		}
+
+		host, path, _ := strings.Cut(entry, separator)
+		segments := strings.SplitN(path, "/", 3)
+		if len(segments) < 2 {
+			repository = entry
+		} else {
+			repository = host + separator + segments[0] + "/" + segments[1]
+			if len(segments) == 3 {
+				subPath = segments[2]
+			}
+		}
+	}
+
+	if scheme == "" && !scpLike {
+		scheme = "https://"
+	}
+
+	return scheme + repository, strings.Trim(subPath, "/"), ref
+}
@@ -0,0 +1,170 @@
+package kubectl
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/loft-sh/devspace/pkg/util/sops"
+	"gotest.tools/assert"
+	"mvdan.cc/sh/v3/expand"
+	"sigs.k8s.io/kustomize/api/types"
+)
+
+func TestKustomizeOptions(t *testing.T) {
+	options, err := kustomizeOptions([]string{"--load-restrictor", "LoadRestrictionsNone", "--enable-helm", "--reorder=none"})
+	assert.NilError(t, err)
+	assert.Equal(t, options.LoadRestrictions, types.LoadRestrictionsNone)
+	assert.Equal(t, options.PluginConfig.HelmConfig.Enabled, true)
+	assert.Equal(t, options.PluginConfig.HelmConfig.Command, "helm")
+	assert.Equal(t, string(options.Reorder), "none")
+
+	_, err = kustomizeOptions([]string{"--load-restrictor"})
+	assert.Error(t, err, "kustomize argument --load-restrictor requires a value")
+
+	_, err = kustomizeOptions([]string{"--output=out.yaml"})
+	assert.Error(t, err, "kustomize argument --output=out.yaml is not supported by the built-in kustomize")
+}
+
+func TestParseRemoteBase(t *testing.T) {
+	testCases := []struct {
+		entry      string
+		isRemote   bool
+		repository string
+		subPath    string
+		ref        string
+	}{
+		{
+			entry:      "https://github.com/org/repo//deploy/base?ref=v1.0.0",
+			isRemote:   true,
+			repository: "https://github.com/org/repo",
+			subPath:    "deploy/base",
+			ref:        "v1.0.0",
+		},
+		{
+			entry:      "github.com/org/repo/deploy/base?ref=0a1b2c3",
+			isRemote:   true,
+			repository: "https://github.com/org/repo",
+			subPath:    "deploy/base",
+			ref:        "0a1b2c3",
+		},
+		{
+			entry:      "git@github.com:org/repo.git/base",
+			isRemote:   true,
+			repository: "git@github.com:org/repo.git",
+			subPath:    "base",
+		},
+		{
+			entry:      "git::ssh://git@example.com/org/repo.git",
+			isRemote:   true,
+			repository: "ssh://git@example.com/org/repo.git",
+		},
+		{
+			entry: "https://example.com/manifests/deployment.yaml",
+		},
+		{
+			entry: "../base",
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, isRemoteBase(testCase.entry), testCase.isRemote, "Unexpected remote detection of %s", testCase.entry)
+		if !testCase.isRemote {
+			continue
+		}
+
+		repository, subPath, ref := parseRemoteBase(testCase.entry)
+		assert.Equal(t, repository, testCase.repository, "Unexpected repository of %s", testCase.entry)
+		assert.Equal(t, subPath, testCase.subPath, "Unexpected sub path of %s", testCase.entry)
+		assert.Equal(t, ref, testCase.ref, "Unexpected ref of %s", testCase.entry)
+	}
+}
+
+func TestKustomizeAPIBuild(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "kustomize"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "kustomize", "kustomization.yaml"), []byte(`namePrefix: dev-
+resources:
+- configmap.yaml
+`), 0644))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "kustomize", "configmap.yaml"), []byte(`apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: test
+data:
+  key: value
+`), 0644))
+
+	builder := NewKustomizeAPIBuilder(&latest.DeploymentConfig{Kubectl: &latest.KubectlConfig{}}, log.Discard)
+	objects, err := builder.Build(context.Background(), nil, dir, "kustomize")
+	assert.NilError(t, err)
+	assert.Equal(t, len(objects), 1)
+	assert.Equal(t, objects[0].GetName(), "dev-test")
+	assert.Equal(t, objects[0].GetKind(), "ConfigMap")
+}
+
+func TestKustomizeAPIBuildFallback(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("fake kustomize binary is a shell script")
+	}
+
+	// fake kustomize binary that prints its arguments into a config map
+	binDir := t.TempDir()
+	assert.NilError(t, os.WriteFile(filepath.Join(binDir, "kustomize"), []byte(`#!/bin/sh
+echo "apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: binary
+data:
+  args: $*"
+`), 0755))
+
+	config := &latest.DeploymentConfig{Kubectl: &latest.KubectlConfig{KustomizeArgs: []string{"--enable-alpha-plugins"}}}
+	builder := NewKustomizeAPIBuilder(config, log.Discard)
+
+	t.Setenv("PATH", t.TempDir())
+	_, err := builder.Build(context.Background(), nil, t.TempDir(), "kustomize")
+	assert.Error(t, err, "kustomize argument --enable-alpha-plugins is not supported by the built-in kustomize and the kustomize binary was not found, please install kustomize or set kustomizeBinaryPath")
+
+	t.Setenv("PATH", binDir)
+	objects, err := builder.Build(context.Background(), expand.ListEnviron("PATH="+binDir), t.TempDir(), "kustomize")
+	assert.NilError(t, err)
+	assert.Equal(t, len(objects), 1)
+	assert.Equal(t, objects[0].GetName(), "binary")
+	assert.DeepEqual(t, objects[0].Object["data"], map[string]interface{}{"args": "build kustomize --enable-alpha-plugins"})
+}
+
+func TestKustomizeBuildSops(t *testing.T) {
+	dir := t.TempDir()
+	assert.NilError(t, os.MkdirAll(filepath.Join(dir, "kustomize"), 0755))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "kustomize", "kustomization.yaml"), []byte(`namePrefix: dev-
+resources:
+- secret.yaml
+`), 0644))
+	assert.NilError(t, os.WriteFile(filepath.Join(dir, "kustomize", "secret.yaml"), []byte(encryptedSecret), 0644))
+
+	builder := NewKustomizeAPIBuilder(&latest.DeploymentConfig{Kubectl: &latest.KubectlConfig{}}, log.Discard)
+	_, err := builder.Build(context.Background(), expand.ListEnviron("XDG_CONFIG_HOME="+t.TempDir()), dir, "kustomize")
+	assert.ErrorContains(t, err, "failed to load age identities")
+
+	objects, err := builder.Build(context.Background(), expand.ListEnviron(sops.AgeKeyEnv+"="+secretAgeKey), dir, "kustomize")
+	assert.NilError(t, err)
+	assert.Equal(t, len(objects), 1)
+	assert.Equal(t, objects[0].GetName(), "dev-database")
+	assert.DeepEqual(t, objects[0].Object["stringData"], map[string]interface{}{"password": "s3cr3t"})
+
+	if runtime.GOOS == "windows" {
+		return
+	}
+
+	// the kustomize binary can't decrypt the secret
+	binDir := t.TempDir()
+	assert.NilError(t, os.WriteFile(filepath.Join(binDir, "kustomize"), []byte("#!/bin/sh\ncat kustomize/secret.yaml\n"), 0755))
+	builder = NewKustomizeBuilder(filepath.Join(binDir, "kustomize"), &latest.DeploymentConfig{Kubectl: &latest.KubectlConfig{}}, log.Discard)
+	_, err = builder.Build(context.Background(), expand.ListEnviron("PATH="+os.Getenv("PATH")), dir, "kustomize")
+	assert.Error(t, err, "Secret database is encrypted with sops, which is only supported by the built-in kustomize, please remove kustomizeBinaryPath and use kustomizeArgs the built-in kustomize supports")
+}
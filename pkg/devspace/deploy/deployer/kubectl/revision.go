@@ -0,0 +1,177 @@
+package kubectl
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/base64"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+)
+
+// DefaultRevisionHistoryLimit is the default number of revisions kept for a kubectl deployment
+const DefaultRevisionHistoryLimit = 5
+
+func (d *DeployConfig) revisionHistoryLimit() int {
+	if d.DeploymentConfig.Kubectl.RevisionHistoryLimit != nil {
+		return *d.DeploymentConfig.Kubectl.RevisionHistoryLimit
+	}
+
+	return DefaultRevisionHistoryLimit
+}
+
+// Rollback applies the manifests of the given revision again and deletes all objects that
+// are not part of that revision. If revision is 0, the previous revision is used. Rolling back
+// creates a new revision.
+func (d *DeployConfig) Rollback(ctx devspacecontext.Context, revision int) error {
+	deployCache, ok := ctx.Config().RemoteCache().GetDeployment(d.DeploymentConfig.Name)
+	if !ok || deployCache.Kubectl == nil || len(deployCache.Kubectl.Revisions) == 0 {
+		return errors.Errorf("no revision history found for deployment %s", d.DeploymentConfig.Name)
+	}
+
+	target, err := findRevision(deployCache.Kubectl.Revisions, revision)
+	if err != nil {
+		return errors.Wrapf(err, "deployment %s", d.DeploymentConfig.Name)
+	}
+
+	manifests, err := decompressManifests(target.Manifests)
+	if err != nil {
+		return errors.Wrapf(err, "decompress manifests of revision %d", target.Revision)
+	}
+
+	ctx.Log().Infof("Applying manifests of revision %d with kubectl...", target.Revision)
+	err = d.apply(ctx, manifests)
+	if err != nil {
+		return errors.Errorf("%v\nPlease make sure the command `kubectl apply` does work locally", err)
+	}
+
+	// delete the objects that were created after the target revision
+	deleteObjects(ctx, pruneObjects(deployCache.Kubectl.Objects, target.Objects))
+
+	revisions, err := addRevision(deployCache.Kubectl.Revisions, manifests, target.Objects, d.revisionHistoryLimit())
+	if err != nil {
+		return err
+	}
+
+	// make sure the next deploy applies the manifests of the current config again
+	deployCache.DeploymentConfigHash = ""
+	deployCache.Kubectl.Objects = target.Objects
+	deployCache.Kubectl.ManifestsHash = ""
+	deployCache.Kubectl.Revisions = revisions
+	ctx.Config().RemoteCache().SetDeployment(d.DeploymentConfig.Name, deployCache)
+	return nil
+}
+
+// findRevision returns the revision with the given number or the previous revision if revision is 0
+func findRevision(revisions []remotecache.KubectlRevision, revision int) (remotecache.KubectlRevision, error) {
+	if revision == 0 {
+		if len(revisions) < 2 {
+			return remotecache.KubectlRevision{}, errors.New("no previous revision found")
+		}
+
+		return revisions[len(revisions)-2], nil
+	}
+
+	available := []string{}
+	for _, r := range revisions {
+		if r.Revision == revision {
+			return r, nil
+		}
+
+		available = append(available, strconv.Itoa(r.Revision))
+	}
+
+	return remotecache.KubectlRevision{}, errors.Errorf("revision %d not found, available revisions are: %s", revision, strings.Join(available, ", "))
+}
+
+// addRevision appends a new revision with the given manifests and removes the oldest revisions
+// that exceed the limit. If the manifests didn't change since the latest revision, no revision is added.
+func addRevision(revisions []remotecache.KubectlRevision, manifests string, objects []remotecache.KubectlObject, limit int) ([]remotecache.KubectlRevision, error) {
+	if limit <= 0 {
+		return nil, nil
+	}
+
+	compressed, err := compressManifests(manifests)
+	if err != nil {
+		return nil, errors.Wrap(err, "compress manifests")
+	} else if len(revisions) > 0 && revisions[len(revisions)-1].Manifests == compressed {
+		return revisions, nil
+	}
+
+	next := 1
+	if len(revisions) > 0 {
+		next = revisions[len(revisions)-1].Revision + 1
+	}
+
+	newRevisions := append([]remotecache.KubectlRevision{}, revisions...)
+	newRevisions = append(newRevisions, remotecache.KubectlRevision{
+		Revision:  next,
+		Manifests: compressed,
+		Objects:   objects,
+		Deployed:  time.Now().Unix(),
+	})
+	if len(newRevisions) > limit {
+		newRevisions = newRevisions[len(newRevisions)-limit:]
+	}
+
+	return newRevisions, nil
+}
+
+// pruneObjects returns the objects that are not part of the keep objects. Objects are compared by their
+// group, kind, namespace and name, so that changing the api version of an object doesn't prune it.
+func pruneObjects(objects []remotecache.KubectlObject, keep []remotecache.KubectlObject) []remotecache.KubectlObject {
+	keepMap := map[string]bool{}
+	for _, obj := range keep {
+		keepMap[obj.Namespace+"/"+kubectlObjectID(obj)] = true
+	}
+
+	prune := []remotecache.KubectlObject{}
+	for _, obj := range objects {
+		if !keepMap[obj.Namespace+"/"+kubectlObjectID(obj)] {
+			prune = append(prune, obj)
+		}
+	}
+
+	return prune
+}
+
+func compressManifests(manifests string) (string, error) {
+	buffer := &bytes.Buffer{}
+	writer := gzip.NewWriter(buffer)
+	_, err := writer.Write([]byte(manifests))
+	if err != nil {
+		return "", err
+	}
+
+	err = writer.Close()
+	if err != nil {
+		return "", err
+	}
+
+	return base64.StdEncoding.EncodeToString(buffer.Bytes()), nil
+}
+
+func decompressManifests(compressed string) (string, error) {
+	decoded, err := base64.StdEncoding.DecodeString(compressed)
+	if err != nil {
+		return "", err
+	}
+
+	reader, err := gzip.NewReader(bytes.NewReader(decoded))
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	out, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
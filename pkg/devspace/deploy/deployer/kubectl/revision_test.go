@@ -0,0 +1,88 @@
+package kubectl
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"gotest.tools/assert"
+)
+
+func TestAddRevision(t *testing.T) {
+	objects := []remotecache.KubectlObject{{APIVersion: "v1", Kind: "ConfigMap", Name: "test", Namespace: "default"}}
+
+	var (
+		revisions []remotecache.KubectlRevision
+		err       error
+	)
+	for i := 0; i < 4; i++ {
+		revisions, err = addRevision(revisions, fmt.Sprintf("manifest %d", i), objects, 3)
+		assert.NilError(t, err)
+	}
+
+	assert.Equal(t, len(revisions), 3)
+	assert.Equal(t, revisions[0].Revision, 2)
+	assert.Equal(t, revisions[2].Revision, 4)
+	assert.DeepEqual(t, revisions[2].Objects, objects)
+
+	manifests, err := decompressManifests(revisions[2].Manifests)
+	assert.NilError(t, err)
+	assert.Equal(t, manifests, "manifest 3")
+
+	// deploying unchanged manifests doesn't add a revision
+	revisions, err = addRevision(revisions, "manifest 3", objects, 3)
+	assert.NilError(t, err)
+	assert.Equal(t, len(revisions), 3)
+	assert.Equal(t, revisions[2].Revision, 4)
+
+	// rolling back to an older revision adds a revision
+	revisions, err = addRevision(revisions, "manifest 2", objects, 3)
+	assert.NilError(t, err)
+	assert.Equal(t, revisions[2].Revision, 5)
+
+	// a limit of 0 disables the history
+	revisions, err = addRevision(revisions, "manifest", objects, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, len(revisions), 0)
+}
+
+func TestFindRevision(t *testing.T) {
+	revisions := []remotecache.KubectlRevision{{Revision: 3}, {Revision: 4}, {Revision: 5}}
+
+	revision, err := findRevision(revisions, 0)
+	assert.NilError(t, err)
+	assert.Equal(t, revision.Revision, 4)
+
+	revision, err = findRevision(revisions, 3)
+	assert.NilError(t, err)
+	assert.Equal(t, revision.Revision, 3)
+
+	_, err = findRevision(revisions, 1)
+	assert.Error(t, err, "revision 1 not found, available revisions are: 3, 4, 5")
+
+	_, err = findRevision(revisions[:1], 0)
+	assert.Error(t, err, "no previous revision found")
+}
+
+func TestPruneObjects(t *testing.T) {
+	configMap := remotecache.KubectlObject{APIVersion: "v1", Kind: "ConfigMap", Name: "test", Namespace: "default"}
+	secret := remotecache.KubectlObject{APIVersion: "v1", Kind: "Secret", Name: "test", Namespace: "default"}
+
+	assert.DeepEqual(t, pruneObjects([]remotecache.KubectlObject{configMap, secret}, []remotecache.KubectlObject{configMap}), []remotecache.KubectlObject{secret})
+	assert.DeepEqual(t, pruneObjects([]remotecache.KubectlObject{configMap}, []remotecache.KubectlObject{configMap, secret}), []remotecache.KubectlObject{})
+
+	// an api version bump keeps the object
+	hpa := remotecache.KubectlObject{APIVersion: "autoscaling/v2beta2", Kind: "HorizontalPodAutoscaler", Name: "test", Namespace: "default"}
+	hpaV2 := remotecache.KubectlObject{APIVersion: "autoscaling/v2", Kind: "HorizontalPodAutoscaler", Name: "test", Namespace: "default"}
+	assert.DeepEqual(t, pruneObjects([]remotecache.KubectlObject{configMap, hpa}, []remotecache.KubectlObject{configMap, hpaV2}), []remotecache.KubectlObject{})
+
+	// objects with the same name in other groups or namespaces are still pruned
+	otherGroup := remotecache.KubectlObject{APIVersion: "custom.example.com/v1", Kind: "ConfigMap", Name: "test", Namespace: "default"}
+	otherNamespace := remotecache.KubectlObject{APIVersion: "v1", Kind: "ConfigMap", Name: "test", Namespace: "other"}
+	assert.DeepEqual(t, pruneObjects([]remotecache.KubectlObject{otherGroup, otherNamespace}, []remotecache.KubectlObject{configMap}), []remotecache.KubectlObject{otherGroup, otherNamespace})
+}
+
+func TestKubectlObjectID(t *testing.T) {
+	assert.Equal(t, kubectlObjectID(remotecache.KubectlObject{APIVersion: "v1", Kind: "ConfigMap", Name: "test"}), "configmap/test")
+	assert.Equal(t, kubectlObjectID(remotecache.KubectlObject{APIVersion: "apps/v1", Kind: "Deployment", Name: "test"}), "deployment.apps/test")
+}
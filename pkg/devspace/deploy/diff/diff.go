@@ -0,0 +1,296 @@
+package diff
+
+import (
+	"encoding/base64"
+	"fmt"
+	"reflect"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/manifests"
+	"github.com/pkg/errors"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/discovery/cached/memory"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/restmapper"
+	"sigs.k8s.io/yaml"
+)
+
+// RedactedValue replaces the values of secrets in diffs
+const RedactedValue = "***"
+
+// ignoredMetadataFields are set by the api server and are never part of a rendered manifest
+var ignoredMetadataFields = []string{
+	"managedFields",
+	"resourceVersion",
+	"uid",
+	"generation",
+	"creationTimestamp",
+	"selfLink",
+}
+
+// Object is a rendered object together with its live counterpart in the cluster
+type Object struct {
+	APIVersion string
+	Kind       string
+	Name       string
+	Namespace  string
+
+	// Live is the normalized yaml of the object in the cluster, empty if the object does not exist yet
+	Live string
+	// Rendered is the normalized yaml of the rendered object
+	Rendered string
+}
+
+// Changed returns true if applying the rendered object would change the cluster
+func (o Object) Changed() bool {
+	return o.Live != o.Rendered
+}
+
+// ID returns a readable identifier of the object
+func (o Object) ID() string {
+	if o.Namespace == "" {
+		return o.APIVersion + "/" + o.Kind + "/" + o.Name
+	}
+
+	return o.APIVersion + "/" + o.Kind + "/" + o.Namespace + "/" + o.Name
+}
+
+// Objects parses the rendered manifests and retrieves the live counterpart of each object from the cluster.
+// Namespaced objects without a namespace are looked up in the given namespace.
+func Objects(ctx devspacecontext.Context, renderedManifests string, namespace string) ([]Object, error) {
+	rendered, err := manifests.Parse(renderedManifests)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(ctx.KubeClient().RestConfig())
+	if err != nil {
+		return nil, errors.Wrap(err, "create dynamic client")
+	}
+	discoveryClient, err := discovery.NewDiscoveryClientForConfig(ctx.KubeClient().RestConfig())
+	if err != nil {
+		return nil, errors.Wrap(err, "create discovery client")
+	}
+	mapper := restmapper.NewDeferredDiscoveryRESTMapper(memory.NewMemCacheClient(discoveryClient))
+
+	objects := []Object{}
+	for _, obj := range rendered {
+		gvk := obj.GroupVersionKind()
+		mapping, err := mapper.RESTMapping(gvk.GroupKind(), gvk.Version)
+		if err != nil {
+			return nil, errors.Wrapf(err, "find resource of %s", gvk.String())
+		}
+
+		var resource dynamic.ResourceInterface
+		if mapping.Scope.Name() == meta.RESTScopeNameNamespace {
+			if obj.GetNamespace() == "" {
+				obj.SetNamespace(namespace)
+			}
+
+			resource = dynamicClient.Resource(mapping.Resource).Namespace(obj.GetNamespace())
+		} else {
+			obj.SetNamespace("")
+			resource = dynamicClient.Resource(mapping.Resource)
+		}
+
+		var liveObject map[string]interface{}
+		live, err := resource.Get(ctx.Context(), obj.GetName(), metav1.GetOptions{})
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return nil, errors.Wrapf(err, "get %s %s", obj.GetKind(), obj.GetName())
+			}
+		} else {
+			liveObject = live.Object
+		}
+
+		object, err := NewObject(obj, liveObject)
+		if err != nil {
+			return nil, err
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
+
+// NewObject normalizes the rendered object and its live counterpart, live is nil if the object does not exist yet
+func NewObject(rendered *unstructured.Unstructured, live map[string]interface{}) (Object, error) {
+	renderedObject := normalize(rendered.Object)
+	var liveObject map[string]interface{}
+	if live != nil {
+		liveObject = prune(normalize(live), renderedObject).(map[string]interface{})
+	}
+	if IsSecret(rendered.GetAPIVersion(), rendered.GetKind()) {
+		redactSecret(renderedObject, liveObject)
+	}
+
+	renderedYAML, err := yaml.Marshal(renderedObject)
+	if err != nil {
+		return Object{}, err
+	}
+
+	liveYAML := []byte{}
+	if liveObject != nil {
+		liveYAML, err = yaml.Marshal(liveObject)
+		if err != nil {
+			return Object{}, err
+		}
+	}
+
+	return Object{
+		APIVersion: rendered.GetAPIVersion(),
+		Kind:       rendered.GetKind(),
+		Name:       rendered.GetName(),
+		Namespace:  rendered.GetNamespace(),
+		Live:       string(liveYAML),
+		Rendered:   string(renderedYAML),
+	}, nil
+}
+
+// IsSecret returns true if the object is a Secret, whose values must not be printed
+func IsSecret(apiVersion, kind string) bool {
+	return apiVersion == "v1" && kind == "Secret"
+}
+
+// redactSecret replaces the data values of the rendered and live secret. Changed values are
+// marked the same way `kubectl diff` does, so that changes are still visible.
+func redactSecret(rendered, live map[string]interface{}) {
+	renderedData, _ := rendered["data"].(map[string]interface{})
+	var liveData map[string]interface{}
+	if live != nil {
+		liveData, _ = live["data"].(map[string]interface{})
+	}
+
+	for key, value := range renderedData {
+		liveValue, ok := liveData[key]
+		if !ok {
+			renderedData[key] = RedactedValue
+		} else if reflect.DeepEqual(value, liveValue) {
+			renderedData[key] = RedactedValue
+			liveData[key] = RedactedValue
+		} else {
+			renderedData[key] = RedactedValue + " (after)"
+			liveData[key] = RedactedValue + " (before)"
+		}
+	}
+	for key := range liveData {
+		if _, ok := renderedData[key]; !ok {
+			liveData[key] = RedactedValue
+		}
+	}
+}
+
+// normalize removes the status and all fields that are managed by the api server. The stringData
+// of secrets is merged into their data, because the api server only returns the data.
+func normalize(obj map[string]interface{}) map[string]interface{} {
+	normalized := deepCopy(obj)
+	delete(normalized, "status")
+
+	if IsSecret(fmt.Sprint(normalized["apiVersion"]), fmt.Sprint(normalized["kind"])) {
+		stringData, ok := normalized["stringData"].(map[string]interface{})
+		if ok {
+			data, ok := normalized["data"].(map[string]interface{})
+			if !ok {
+				data = map[string]interface{}{}
+			}
+			for key, value := range stringData {
+				data[key] = base64.StdEncoding.EncodeToString([]byte(fmt.Sprint(value)))
+			}
+
+			normalized["data"] = data
+			delete(normalized, "stringData")
+		}
+	}
+
+	metadata, ok := normalized["metadata"].(map[string]interface{})
+	if ok {
+		for _, field := range ignoredMetadataFields {
+			delete(metadata, field)
+		}
+
+		annotations, ok := metadata["annotations"].(map[string]interface{})
+		if ok {
+			delete(annotations, "kubectl.kubernetes.io/last-applied-configuration")
+			if len(annotations) == 0 {
+				delete(metadata, "annotations")
+			}
+		}
+	}
+
+	return normalized
+}
+
+// prune removes all fields from the live object that are not part of the rendered object. This hides
+// values that were defaulted by the api server or added by controllers, while changed and removed values
+// are still shown. Elements of lists are matched by their name if they have one.
+func prune(live interface{}, rendered interface{}) interface{} {
+	switch liveValue := live.(type) {
+	case map[string]interface{}:
+		renderedMap, ok := rendered.(map[string]interface{})
+		if !ok {
+			return live
+		}
+
+		pruned := map[string]interface{}{}
+		for key, value := range liveValue {
+			renderedValue, ok := renderedMap[key]
+			if !ok {
+				continue
+			}
+
+			pruned[key] = prune(value, renderedValue)
+		}
+		return pruned
+	case []interface{}:
+		renderedSlice, ok := rendered.([]interface{})
+		if !ok {
+			return live
+		}
+
+		pruned := []interface{}{}
+		for i, value := range liveValue {
+			renderedValue, ok := findListElement(renderedSlice, value, i)
+			if !ok {
+				pruned = append(pruned, value)
+				continue
+			}
+
+			pruned = append(pruned, prune(value, renderedValue))
+		}
+		return pruned
+	}
+
+	return live
+}
+
+func findListElement(list []interface{}, element interface{}, index int) (interface{}, bool) {
+	elementMap, ok := element.(map[string]interface{})
+	if ok {
+		name, ok := elementMap["name"].(string)
+		if ok {
+			for _, listElement := range list {
+				listElementMap, ok := listElement.(map[string]interface{})
+				if ok && listElementMap["name"] == name {
+					return listElement, true
+				}
+			}
+
+			return nil, false
+		}
+	}
+
+	if index < len(list) {
+		return list[index], true
+	}
+
+	return nil, false
+}
+
+func deepCopy(obj map[string]interface{}) map[string]interface{} {
+	return (&unstructured.Unstructured{Object: obj}).DeepCopy().Object
+}
@@ -0,0 +1,163 @@
+package diff
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/manifests"
+	"gotest.tools/assert"
+)
+
+func TestNewObject(t *testing.T) {
+	rendered, err := manifests.Parse(`
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: test
+  namespace: default
+spec:
+  replicas: 1
+  template:
+    spec:
+      containers:
+      - name: app
+        image: app:v2
+---
+`)
+	assert.NilError(t, err)
+	assert.Equal(t, len(rendered), 1)
+
+	live := map[string]interface{}{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"metadata": map[string]interface{}{
+			"name":            "test",
+			"namespace":       "default",
+			"resourceVersion": "123",
+			"managedFields":   []interface{}{map[string]interface{}{"manager": "kubectl"}},
+			"annotations": map[string]interface{}{
+				"kubectl.kubernetes.io/last-applied-configuration": "{}",
+			},
+		},
+		"spec": map[string]interface{}{
+			"replicas":             int64(1),
+			"revisionHistoryLimit": int64(10),
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{
+							"name":            "app",
+							"image":           "app:v1",
+							"imagePullPolicy": "IfNotPresent",
+						},
+					},
+				},
+			},
+		},
+		"status": map[string]interface{}{
+			"replicas": int64(1),
+		},
+	}
+
+	object, err := NewObject(rendered[0], live)
+	assert.NilError(t, err)
+	assert.Equal(t, object.Changed(), true)
+	assert.Equal(t, object.ID(), "apps/v1/Deployment/default/test")
+	assert.Equal(t, Unified(object.Live, object.Rendered, "live", "rendered", false), `--- live
++++ rendered
+@@ -8,5 +8,5 @@
+   template:
+     spec:
+       containers:
+-      - image: app:v1
++      - image: app:v2
+         name: app
+`)
+
+	// the same image only differs in defaulted values
+	live["spec"].(map[string]interface{})["template"].(map[string]interface{})["spec"].(map[string]interface{})["containers"].([]interface{})[0].(map[string]interface{})["image"] = "app:v2"
+	object, err = NewObject(rendered[0], live)
+	assert.NilError(t, err)
+	assert.Equal(t, object.Changed(), false)
+}
+
+func TestNewObjectSecret(t *testing.T) {
+	rendered, err := manifests.Parse(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: test
+  namespace: default
+data:
+  user: YWRtaW4=
+stringData:
+  password: s3cr3t
+  token: abc
+`)
+	assert.NilError(t, err)
+
+	live := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata": map[string]interface{}{
+			"name":      "test",
+			"namespace": "default",
+		},
+		"type": "Opaque",
+		"data": map[string]interface{}{
+			"user":     "YWRtaW4=",
+			"password": "czNjcjN0",
+			"token":    "YWJj",
+		},
+	}
+
+	// the string data is compared with the data of the live secret
+	object, err := NewObject(rendered[0], live)
+	assert.NilError(t, err)
+	assert.Equal(t, object.Changed(), false)
+
+	// changed values are visible, but not printed
+	live["data"].(map[string]interface{})["token"] = "ZGVm"
+	object, err = NewObject(rendered[0], live)
+	assert.NilError(t, err)
+	assert.Equal(t, object.Changed(), true)
+	assert.Equal(t, Unified(object.Live, object.Rendered, "live", "rendered", false), `--- live
++++ rendered
+@@ -1,7 +1,7 @@
+ apiVersion: v1
+ data:
+   password: '***'
+-  token: '*** (before)'
++  token: '*** (after)'
+   user: '***'
+ kind: Secret
+ metadata:
+`)
+}
+
+func TestUnified(t *testing.T) {
+	from := "a\nb\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nn\n"
+	to := "a\nB\nc\nd\ne\nf\ng\nh\ni\nj\nk\nl\nm\nN\n"
+
+	assert.Equal(t, Unified(from, from, "from", "to", false), "")
+	assert.Equal(t, Unified(from, to, "from", "to", false), `--- from
++++ to
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -11,4 +11,4 @@
+ k
+ l
+ m
+-n
++N
+`)
+	assert.Equal(t, Unified("", "a\n", "/dev/null", "to", false), `--- /dev/null
++++ to
+@@ -0,0 +1,1 @@
++a
+`)
+}
@@ -0,0 +1,138 @@
+package diff
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/mgutz/ansi"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// contextLines is the number of unchanged lines that are printed around a change
+const contextLines = 3
+
+type lineOperation struct {
+	Type diffmatchpatch.Operation
+	Line string
+}
+
+// Print writes a unified diff of all changed objects to the writer
+func Print(out io.Writer, objects []Object, color bool) {
+	for _, object := range objects {
+		if !object.Changed() {
+			continue
+		}
+
+		fromName := "live/" + object.ID()
+		if object.Live == "" {
+			fromName = "/dev/null"
+		}
+
+		_, _ = io.WriteString(out, Unified(object.Live, object.Rendered, fromName, "rendered/"+object.ID(), color))
+	}
+}
+
+// Unified returns the unified diff between from and to or an empty string if both are equal
+func Unified(from, to, fromName, toName string, color bool) string {
+	operations := diffLines(from, to)
+
+	colorize := func(s, style string) string {
+		if !color {
+			return s
+		}
+
+		return ansi.Color(s, style)
+	}
+
+	// find the hunks, which are changes that are closer than twice the context lines
+	builder := &strings.Builder{}
+	for start := 0; start < len(operations); {
+		if operations[start].Type == diffmatchpatch.DiffEqual {
+			start++
+			continue
+		}
+
+		// find the end of the hunk
+		end := start
+		unchanged := 0
+		for i := start; i < len(operations) && unchanged <= 2*contextLines; i++ {
+			if operations[i].Type == diffmatchpatch.DiffEqual {
+				unchanged++
+			} else {
+				unchanged = 0
+				end = i + 1
+			}
+		}
+
+		hunkStart := max(start-contextLines, 0)
+		hunkEnd := min(end+contextLines, len(operations))
+		if builder.Len() == 0 {
+			builder.WriteString(colorize("--- "+fromName, "white+b") + "\n")
+			builder.WriteString(colorize("+++ "+toName, "white+b") + "\n")
+		}
+
+		fromLine, toLine := lineNumbers(operations[:hunkStart])
+		fromCount, toCount := lineNumbers(operations[hunkStart:hunkEnd])
+		builder.WriteString(colorize(fmt.Sprintf("@@ -%s +%s @@", hunkRange(fromLine, fromCount), hunkRange(toLine, toCount)), "cyan") + "\n")
+		for _, operation := range operations[hunkStart:hunkEnd] {
+			switch operation.Type {
+			case diffmatchpatch.DiffDelete:
+				builder.WriteString(colorize("-"+operation.Line, "red") + "\n")
+			case diffmatchpatch.DiffInsert:
+				builder.WriteString(colorize("+"+operation.Line, "green") + "\n")
+			default:
+				builder.WriteString(" " + operation.Line + "\n")
+			}
+		}
+
+		start = hunkEnd
+	}
+
+	return builder.String()
+}
+
+func diffLines(from, to string) []lineOperation {
+	dmp := diffmatchpatch.New()
+	fromChars, toChars, lines := dmp.DiffLinesToChars(from, to)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(fromChars, toChars, false), lines)
+
+	operations := []lineOperation{}
+	for _, diff := range diffs {
+		for _, line := range strings.SplitAfter(diff.Text, "\n") {
+			if line == "" {
+				continue
+			}
+
+			operations = append(operations, lineOperation{
+				Type: diff.Type,
+				Line: strings.TrimSuffix(line, "\n"),
+			})
+		}
+	}
+
+	return operations
+}
+
+// lineNumbers counts the lines of the from and to text within the operations
+func lineNumbers(operations []lineOperation) (int, int) {
+	from, to := 0, 0
+	for _, operation := range operations {
+		if operation.Type != diffmatchpatch.DiffInsert {
+			from++
+		}
+		if operation.Type != diffmatchpatch.DiffDelete {
+			to++
+		}
+	}
+
+	return from, to
+}
+
+func hunkRange(offset, count int) string {
+	if count == 0 {
+		return fmt.Sprintf("%d,0", offset)
+	}
+
+	return fmt.Sprintf("%d,%d", offset+1, count)
+}
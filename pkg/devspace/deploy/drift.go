@@ -0,0 +1,62 @@
+package deploy
+
+import (
+	"fmt"
+	"sort"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/drift"
+	"github.com/pkg/errors"
+)
+
+// Drift compares the deployment configs and the manifests of the last deploy with the cluster
+func (c *controller) Drift(ctx devspacecontext.Context, deployments []string) ([]*drift.Deployment, error) {
+	config := ctx.Config().Config()
+	if len(deployments) == 0 {
+		for name := range config.Deployments {
+			deployments = append(deployments, name)
+		}
+		sort.Strings(deployments)
+	}
+
+	results := []*drift.Deployment{}
+	for _, name := range deployments {
+		deployConfig, ok := config.Deployments[name]
+		if !ok {
+			return nil, fmt.Errorf("couldn't find deployment %v", name)
+		}
+
+		deployClient, _, err := newDeployer(ctx, deployConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		driftClient, ok := deployClient.(deployer.DriftInterface)
+		if !ok {
+			ctx.Log().Infof("Skipping deployment %s, because it does not support drift detection", name)
+			continue
+		}
+
+		deployed, err := driftClient.Deployed(ctx)
+		if err != nil {
+			return nil, errors.Errorf("error retrieving deployed manifests of %s: %v", name, err)
+		} else if deployed == nil {
+			results = append(results, &drift.Deployment{Name: name, NotDeployed: true})
+			continue
+		}
+
+		objects, err := drift.Objects(ctx, deployed.Manifests, deployed.Namespace)
+		if err != nil {
+			return nil, errors.Errorf("error detecting drift of %s: %v", name, err)
+		}
+
+		results = append(results, &drift.Deployment{
+			Name:          name,
+			ConfigChanged: deployed.ConfigChanged,
+			Objects:       objects,
+		})
+	}
+
+	return results, nil
+}
@@ -0,0 +1,200 @@
+package drift
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// Deployment holds the drift of a single deployment
+type Deployment struct {
+	Name string
+
+	// NotDeployed is true if the deployment was not deployed yet
+	NotDeployed bool
+	// ConfigChanged is true if the deployment config changed since the last deploy
+	ConfigChanged bool
+	// Objects are the deployed objects that were changed or deleted in the cluster
+	Objects []Object
+}
+
+// Drifted returns true if the cluster does not match the deployment config anymore
+func (d *Deployment) Drifted() bool {
+	return d.ConfigChanged || len(d.Objects) > 0
+}
+
+// Object is a deployed object that was changed or deleted in the cluster
+type Object struct {
+	diff.Object
+
+	// Deleted is true if the object does not exist in the cluster anymore
+	Deleted bool
+	// Fields are the deployed fields that have a different value in the cluster
+	Fields []Field
+}
+
+// Field is a deployed field that has a different value in the cluster
+type Field struct {
+	Path     string
+	Deployed interface{}
+	Live     interface{}
+
+	// Redacted is true if the values must not be printed, e.g. the data of secrets
+	Redacted bool
+}
+
+// String returns a readable description of the change
+func (f Field) String() string {
+	if f.Redacted {
+		if f.Live == nil {
+			return fmt.Sprintf("%s: <removed>", f.Path)
+		}
+
+		return fmt.Sprintf("%s: changed", f.Path)
+	}
+
+	return fmt.Sprintf("%s: %s -> %s", f.Path, value(f.Deployed), value(f.Live))
+}
+
+// Objects compares the deployed manifests with the cluster and returns the objects that were changed or deleted.
+// Fields that were added in the cluster, e.g. by the api server or controllers, are not considered as drift.
+func Objects(ctx devspacecontext.Context, deployedManifests, namespace string) ([]Object, error) {
+	objects, err := diff.Objects(ctx, deployedManifests, namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	drifted := []Object{}
+	for _, object := range objects {
+		driftedObject, err := objectDrift(object)
+		if err != nil {
+			return nil, err
+		} else if driftedObject != nil {
+			drifted = append(drifted, *driftedObject)
+		}
+	}
+
+	return drifted, nil
+}
+
+// objectDrift returns the changed fields of the object or nil if the object did not change
+func objectDrift(object diff.Object) (*Object, error) {
+	if !object.Changed() {
+		return nil, nil
+	} else if object.Live == "" {
+		return &Object{Object: object, Deleted: true}, nil
+	}
+
+	deployed := map[string]interface{}{}
+	err := yaml.Unmarshal([]byte(object.Rendered), &deployed)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse deployed object")
+	}
+
+	live := map[string]interface{}{}
+	err = yaml.Unmarshal([]byte(object.Live), &live)
+	if err != nil {
+		return nil, errors.Wrap(err, "parse live object")
+	}
+
+	fields := changedFields("", deployed, live)
+	if diff.IsSecret(object.APIVersion, object.Kind) {
+		for i := range fields {
+			fields[i].Redacted = fields[i].Path == "data" || strings.HasPrefix(fields[i].Path, "data.")
+		}
+	}
+
+	return &Object{Object: object, Fields: fields}, nil
+}
+
+// changedFields returns the fields of deployed that have a different value in live
+func changedFields(path string, deployed, live interface{}) []Field {
+	switch deployedValue := deployed.(type) {
+	case map[string]interface{}:
+		liveMap, ok := live.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		keys := make([]string, 0, len(deployedValue))
+		for key := range deployedValue {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+
+		fields := []Field{}
+		for _, key := range keys {
+			childPath := key
+			if path != "" {
+				childPath = path + "." + key
+			}
+
+			fields = append(fields, changedFields(childPath, deployedValue[key], liveMap[key])...)
+		}
+		return fields
+	case []interface{}:
+		liveSlice, ok := live.([]interface{})
+		if !ok || len(liveSlice) != len(deployedValue) {
+			break
+		}
+
+		fields := []Field{}
+		for i, element := range deployedValue {
+			name, liveElement := elementName(element, i), liveSlice[i]
+			if name != strconv.Itoa(i) {
+				liveElement = nil
+				for _, candidate := range liveSlice {
+					if elementName(candidate, -1) == name {
+						liveElement = candidate
+						break
+					}
+				}
+			}
+
+			fields = append(fields, changedFields(path+"["+name+"]", element, liveElement)...)
+		}
+		return fields
+	}
+
+	if reflect.DeepEqual(deployed, live) {
+		return nil
+	}
+
+	return []Field{{Path: path, Deployed: deployed, Live: live}}
+}
+
+// elementName returns the name of a list element or its index if it has no name
+func elementName(element interface{}, index int) string {
+	elementMap, ok := element.(map[string]interface{})
+	if ok {
+		name, ok := elementMap["name"].(string)
+		if ok {
+			return name
+		}
+	}
+
+	return strconv.Itoa(index)
+}
+
+func value(v interface{}) string {
+	if v == nil {
+		return "<removed>"
+	}
+
+	switch v.(type) {
+	case map[string]interface{}, []interface{}:
+		out, err := yaml.Marshal(v)
+		if err == nil {
+			return strings.TrimSpace(strings.ReplaceAll(string(out), "\n", ", "))
+		}
+	}
+
+	return fmt.Sprintf("%v", v)
+}
@@ -0,0 +1,103 @@
+package drift
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/diff"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/manifests"
+	"gotest.tools/assert"
+)
+
+func TestChangedFields(t *testing.T) {
+	deployed := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": 1,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "app", "image": "app:v1"},
+						map[string]interface{}{"name": "sidecar", "image": "sidecar:v1"},
+					},
+				},
+			},
+		},
+		"data": map[string]interface{}{"key": "value"},
+	}
+	live := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"replicas": 3,
+			"template": map[string]interface{}{
+				"spec": map[string]interface{}{
+					"containers": []interface{}{
+						map[string]interface{}{"name": "sidecar", "image": "sidecar:v1"},
+						map[string]interface{}{"name": "app", "image": "app:v2"},
+					},
+				},
+			},
+		},
+		"data": map[string]interface{}{},
+	}
+
+	fields := changedFields("", deployed, live)
+	descriptions := []string{}
+	for _, field := range fields {
+		descriptions = append(descriptions, field.String())
+	}
+
+	assert.DeepEqual(t, descriptions, []string{
+		"data.key: value -> <removed>",
+		"spec.replicas: 1 -> 3",
+		"spec.template.spec.containers[app].image: app:v1 -> app:v2",
+	})
+	assert.Equal(t, len(changedFields("", deployed, deployed)), 0)
+}
+
+func TestDrifted(t *testing.T) {
+	assert.Equal(t, (&Deployment{}).Drifted(), false)
+	assert.Equal(t, (&Deployment{ConfigChanged: true}).Drifted(), true)
+	assert.Equal(t, (&Deployment{Objects: []Object{{Deleted: true}}}).Drifted(), true)
+}
+
+func TestSecretDrift(t *testing.T) {
+	rendered, err := manifests.Parse(`
+apiVersion: v1
+kind: Secret
+metadata:
+  name: test
+  namespace: default
+stringData:
+  password: s3cr3t
+  token: abc
+`)
+	assert.NilError(t, err)
+
+	live := map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Secret",
+		"metadata":   map[string]interface{}{"name": "test", "namespace": "default"},
+		"data":       map[string]interface{}{"password": "czNjcjN0", "token": "YWJj"},
+	}
+
+	// a secret deployed with stringData is in sync with the data of the live secret
+	object, err := diff.NewObject(rendered[0], live)
+	assert.NilError(t, err)
+	drifted, err := objectDrift(object)
+	assert.NilError(t, err)
+	assert.Assert(t, drifted == nil)
+
+	// changed values are reported without printing them
+	live["data"] = map[string]interface{}{"password": "c2VjcmV0"}
+	object, err = diff.NewObject(rendered[0], live)
+	assert.NilError(t, err)
+	drifted, err = objectDrift(object)
+	assert.NilError(t, err)
+
+	descriptions := []string{}
+	for _, field := range drifted.Fields {
+		descriptions = append(descriptions, field.String())
+	}
+	assert.DeepEqual(t, descriptions, []string{
+		"data.password: changed",
+		"data.token: <removed>",
+	})
+}
@@ -0,0 +1,47 @@
+package deploy
+
+import (
+	"fmt"
+	"sort"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/pkg/errors"
+)
+
+// dryRun reports the changes deploying the deployments would make to the cluster without applying them
+func (c *controller) dryRun(ctx devspacecontext.Context, deployments []string) error {
+	config := ctx.Config().Config()
+	if len(deployments) == 0 {
+		for name := range config.Deployments {
+			deployments = append(deployments, name)
+		}
+		sort.Strings(deployments)
+	}
+
+	for _, name := range deployments {
+		deployConfig, ok := config.Deployments[name]
+		if !ok {
+			return fmt.Errorf("couldn't find deployment %v", name)
+		}
+
+		ctx := ctx.WithLogger(ctx.Log().WithPrefix("dry-run:" + name + " "))
+		deployClient, _, err := newDeployer(ctx, deployConfig)
+		if err != nil {
+			return err
+		}
+
+		dryRunClient, ok := deployClient.(deployer.DryRunInterface)
+		if !ok {
+			ctx.Log().Infof("Skipping deployment %s, because dry runs are only supported for kubectl deployments", name)
+			continue
+		}
+
+		err = dryRunClient.DryRun(ctx)
+		if err != nil {
+			return errors.Errorf("error during dry run of %s: %v", name, err)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,47 @@
+package manifests
+
+import (
+	"bytes"
+	"io"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	utilyaml "k8s.io/apimachinery/pkg/util/yaml"
+)
+
+// Parse splits rendered yaml or json manifests into objects. Lists are flattened into their items.
+func Parse(manifests string) ([]*unstructured.Unstructured, error) {
+	objects := []*unstructured.Unstructured{}
+	decoder := utilyaml.NewYAMLOrJSONDecoder(bytes.NewReader([]byte(manifests)), 4096)
+	for {
+		obj := map[string]interface{}{}
+		err := decoder.Decode(&obj)
+		if err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return nil, errors.Wrap(err, "parse rendered manifests")
+		} else if len(obj) == 0 {
+			continue
+		}
+
+		// lists are returned by some charts and kubectl
+		object := &unstructured.Unstructured{Object: obj}
+		if object.IsList() {
+			list, err := object.ToList()
+			if err != nil {
+				return nil, err
+			}
+
+			for i := range list.Items {
+				objects = append(objects, &list.Items[i])
+			}
+			continue
+		}
+
+		objects = append(objects, object)
+	}
+
+	return objects, nil
+}
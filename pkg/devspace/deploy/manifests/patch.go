@@ -0,0 +1,67 @@
+package manifests
+
+import (
+	"github.com/loft-sh/devspace/pkg/devspace/config/loader/patch"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	jsonyaml "sigs.k8s.io/yaml"
+)
+
+// Patch applies the patches that target the given resource
+func Patch(resource *unstructured.Unstructured, patches []*latest.PatchTarget, log log.Logger) (*unstructured.Unstructured, error) {
+	out, err := jsonyaml.Marshal(resource)
+	if err != nil {
+		return resource, err
+	}
+
+	operations := patch.Patch{}
+	for idx, kubepatch := range patches {
+		newPatch := patch.Operation{
+			Op:   patch.Op(kubepatch.Operation),
+			Path: patch.OpPath(patch.TransformPath(kubepatch.Path)),
+		}
+
+		if kubepatch.Target.Name != resource.GetName() {
+			continue
+		}
+
+		// non-mandatory field, check only if defined
+		if kubepatch.Target.Kind != "" && resource.GetKind() != kubepatch.Target.Kind {
+			log.Debugf("skipping patch, resource kind match: %s - %s", kubepatch.Target.Kind, resource.GetKind())
+			continue
+		}
+
+		// non-mandatory field, check only if defined
+		if kubepatch.Target.APIVersion != "" && resource.GetAPIVersion() != kubepatch.Target.APIVersion {
+			log.Debugf("skipping patch, resource api mismatch: %s - %s", kubepatch.Target.APIVersion, resource.GetAPIVersion())
+			continue
+		}
+
+		if kubepatch.Value != nil {
+			value, err := patch.NewNode(&kubepatch.Value)
+			if err != nil {
+				return resource, errors.Errorf("value %d is invalid", idx)
+			}
+			newPatch.Value = value
+		}
+
+		log.Debugf("applying patch: %s.%s", kubepatch.Target.Name, kubepatch.Path)
+		operations = append(operations, newPatch)
+	}
+
+	out, err = operations.Apply(out)
+	if err != nil {
+		return resource, errors.Wrap(err, "apply patches")
+	}
+
+	// transform resource back to unstructured
+	var result unstructured.Unstructured
+	err = jsonyaml.Unmarshal(out, &result)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
@@ -0,0 +1,377 @@
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+)
+
+// helmHookAnnotation marks chart resources that are not part of the release, such as tests
+const helmHookAnnotation = "helm.sh/hook"
+
+var customResourceDefinitionResource = schema.GroupVersionResource{Group: "apiextensions.k8s.io", Version: "v1", Resource: "customresourcedefinitions"}
+
+// Workload is a workload created by a deployment
+type Workload struct {
+	Kind      string
+	Name      string
+	Namespace string
+}
+
+// String returns a readable identifier of the workload
+func (w Workload) String() string {
+	if w.Namespace == "" {
+		return w.Kind + " " + w.Name
+	}
+
+	return w.Kind + " " + w.Namespace + "/" + w.Name
+}
+
+// Status is the rollout status of a single workload
+type Status struct {
+	Workload Workload
+
+	// Ready is true if the workload is rolled out and ready
+	Ready bool
+	// Failed is true if the workload will not become ready anymore
+	Failed bool
+	// Message describes why the workload is not ready
+	Message string
+	// Selector selects the pods of the workload, nil if the workload was not found
+	Selector labels.Selector
+}
+
+// Workloads returns the workloads within the rendered objects. Custom resource definitions and
+// namespaces are returned as well, as later deployments might create resources that depend on them.
+// Namespaced workloads without a namespace are expected in the given namespace.
+func Workloads(objects []*unstructured.Unstructured, namespace string) []Workload {
+	workloads := []Workload{}
+	for _, obj := range objects {
+		if obj.GetAnnotations()[helmHookAnnotation] != "" {
+			continue
+		}
+
+		clusterScoped := false
+		switch obj.GroupVersionKind().GroupKind().String() {
+		case "Deployment.apps", "StatefulSet.apps", "DaemonSet.apps", "ReplicaSet.apps", "Job.batch":
+		case "CustomResourceDefinition.apiextensions.k8s.io", "Namespace":
+			clusterScoped = true
+		default:
+			continue
+		}
+
+		workload := Workload{
+			Kind: obj.GetKind(),
+			Name: obj.GetName(),
+		}
+		if !clusterScoped {
+			workload.Namespace = obj.GetNamespace()
+			if workload.Namespace == "" {
+				workload.Namespace = namespace
+			}
+		}
+
+		workloads = append(workloads, workload)
+	}
+
+	sort.Slice(workloads, func(i, j int) bool {
+		return workloads[i].String() < workloads[j].String()
+	})
+	return workloads
+}
+
+// Wait waits until all workloads are rolled out and ready. It returns the last status of the workloads and
+// an error if a workload failed, did not become ready within the timeout or its status couldn't be retrieved.
+func Wait(ctx devspacecontext.Context, workloads []Workload, timeout time.Duration) ([]Status, error) {
+	var (
+		statuses      []Status
+		failed        bool
+		dynamicClient dynamic.Interface
+		err           error
+	)
+	for _, workload := range workloads {
+		if workload.Kind == "CustomResourceDefinition" {
+			dynamicClient, err = dynamic.NewForConfig(ctx.KubeClient().RestConfig())
+			if err != nil {
+				return nil, err
+			}
+
+			break
+		}
+	}
+
+	err = wait.PollUntilContextTimeout(ctx.Context(), 2*time.Second, timeout, true, func(pollCtx context.Context) (bool, error) {
+		statuses = []Status{}
+		ready := true
+		for _, workload := range workloads {
+			status, err := GetStatus(pollCtx, ctx.KubeClient().KubeClient(), dynamicClient, workload)
+			if err != nil {
+				return false, err
+			}
+
+			statuses = append(statuses, status)
+			if status.Failed {
+				failed = true
+			} else if !status.Ready {
+				ctx.Log().Debugf("Waiting for %s: %s", workload.String(), status.Message)
+				ready = false
+			}
+		}
+
+		return ready || failed, nil
+	})
+	if err != nil && !errors.Is(err, context.DeadlineExceeded) {
+		return statuses, err
+	}
+
+	messages := []string{}
+	for _, status := range statuses {
+		if !status.Ready {
+			messages = append(messages, fmt.Sprintf("%s: %s", status.Workload.String(), status.Message))
+		}
+	}
+	if failed {
+		return statuses, errors.Errorf("workloads failed:\n%s", strings.Join(messages, "\n"))
+	} else if err != nil {
+		return statuses, errors.Errorf("timed out waiting for workloads to become ready:\n%s", strings.Join(messages, "\n"))
+	}
+
+	return statuses, nil
+}
+
+// GetStatus retrieves the rollout status of the workload. A workload that will not become ready anymore is
+// reported by the Failed field, an error is only returned if the status couldn't be retrieved. The dynamic
+// client is only used for custom resource definitions.
+func GetStatus(ctx context.Context, client kubernetes.Interface, dynamicClient dynamic.Interface, workload Workload) (Status, error) {
+	var (
+		ready    bool
+		failed   bool
+		message  string
+		selector *metav1.LabelSelector
+		err      error
+	)
+	switch workload.Kind {
+	case "Deployment":
+		var obj *appsv1.Deployment
+		obj, err = client.AppsV1().Deployments(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			selector = obj.Spec.Selector
+			ready, failed, message = deploymentStatus(obj)
+		}
+	case "StatefulSet":
+		var obj *appsv1.StatefulSet
+		obj, err = client.AppsV1().StatefulSets(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			selector = obj.Spec.Selector
+			ready, message = statefulSetStatus(obj)
+		}
+	case "DaemonSet":
+		var obj *appsv1.DaemonSet
+		obj, err = client.AppsV1().DaemonSets(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			selector = obj.Spec.Selector
+			ready, message = daemonSetStatus(obj)
+		}
+	case "ReplicaSet":
+		var obj *appsv1.ReplicaSet
+		obj, err = client.AppsV1().ReplicaSets(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			selector = obj.Spec.Selector
+			ready, message = replicaSetStatus(obj)
+		}
+	case "Job":
+		var obj *batchv1.Job
+		obj, err = client.BatchV1().Jobs(workload.Namespace).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			selector = obj.Spec.Selector
+			ready, failed, message = jobStatus(obj)
+		}
+	case "Namespace":
+		var obj *corev1.Namespace
+		obj, err = client.CoreV1().Namespaces().Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			ready, message = namespaceStatus(obj)
+		}
+	case "CustomResourceDefinition":
+		var obj *unstructured.Unstructured
+		obj, err = dynamicClient.Resource(customResourceDefinitionResource).Get(ctx, workload.Name, metav1.GetOptions{})
+		if err == nil {
+			ready, failed, message = customResourceDefinitionStatus(obj)
+		}
+	default:
+		return Status{}, errors.Errorf("unsupported workload kind %s", workload.Kind)
+	}
+
+	status := Status{
+		Workload: workload,
+		Ready:    ready,
+		Failed:   failed,
+		Message:  message,
+	}
+	if kerrors.IsNotFound(err) {
+		status.Message = "not found"
+		return status, nil
+	} else if err != nil {
+		return status, errors.Wrap(err, workload.String())
+	}
+
+	if selector != nil {
+		status.Selector, err = metav1.LabelSelectorAsSelector(selector)
+		if err != nil {
+			return status, errors.Wrapf(err, "parse selector of %s", workload.String())
+		}
+	}
+
+	return status, nil
+}
+
+func deploymentStatus(deployment *appsv1.Deployment) (bool, bool, string) {
+	if deployment.Generation > deployment.Status.ObservedGeneration {
+		return false, false, "waiting for the rollout to be observed"
+	}
+
+	for _, condition := range deployment.Status.Conditions {
+		if condition.Type == appsv1.DeploymentProgressing && condition.Reason == "ProgressDeadlineExceeded" {
+			return false, true, "exceeded its progress deadline"
+		}
+	}
+
+	replicas := int32(1)
+	if deployment.Spec.Replicas != nil {
+		replicas = *deployment.Spec.Replicas
+	}
+	if deployment.Status.UpdatedReplicas < replicas {
+		return false, false, fmt.Sprintf("%d of %d replicas updated", deployment.Status.UpdatedReplicas, replicas)
+	} else if deployment.Status.Replicas > deployment.Status.UpdatedReplicas {
+		return false, false, fmt.Sprintf("%d old replicas pending termination", deployment.Status.Replicas-deployment.Status.UpdatedReplicas)
+	} else if deployment.Status.AvailableReplicas < deployment.Status.UpdatedReplicas {
+		return false, false, fmt.Sprintf("%d of %d updated replicas available", deployment.Status.AvailableReplicas, deployment.Status.UpdatedReplicas)
+	}
+
+	return true, false, ""
+}
+
+func statefulSetStatus(statefulSet *appsv1.StatefulSet) (bool, string) {
+	if statefulSet.Generation > statefulSet.Status.ObservedGeneration {
+		return false, "waiting for the rollout to be observed"
+	}
+
+	replicas := int32(1)
+	if statefulSet.Spec.Replicas != nil {
+		replicas = *statefulSet.Spec.Replicas
+	}
+	if statefulSet.Status.ReadyReplicas < replicas {
+		return false, fmt.Sprintf("%d of %d replicas ready", statefulSet.Status.ReadyReplicas, replicas)
+	} else if statefulSet.Spec.UpdateStrategy.Type == appsv1.OnDeleteStatefulSetStrategyType {
+		return true, ""
+	}
+
+	if statefulSet.Spec.UpdateStrategy.RollingUpdate != nil && statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition != nil {
+		updated := replicas - *statefulSet.Spec.UpdateStrategy.RollingUpdate.Partition
+		if statefulSet.Status.UpdatedReplicas < updated {
+			return false, fmt.Sprintf("%d of %d replicas updated", statefulSet.Status.UpdatedReplicas, updated)
+		}
+
+		return true, ""
+	}
+
+	if statefulSet.Status.UpdateRevision != statefulSet.Status.CurrentRevision {
+		return false, fmt.Sprintf("%d of %d replicas updated", statefulSet.Status.UpdatedReplicas, replicas)
+	}
+
+	return true, ""
+}
+
+func daemonSetStatus(daemonSet *appsv1.DaemonSet) (bool, string) {
+	if daemonSet.Generation > daemonSet.Status.ObservedGeneration {
+		return false, "waiting for the rollout to be observed"
+	}
+
+	if daemonSet.Spec.UpdateStrategy.Type == appsv1.RollingUpdateDaemonSetStrategyType && daemonSet.Status.UpdatedNumberScheduled < daemonSet.Status.DesiredNumberScheduled {
+		return false, fmt.Sprintf("%d of %d pods updated", daemonSet.Status.UpdatedNumberScheduled, daemonSet.Status.DesiredNumberScheduled)
+	} else if daemonSet.Status.NumberAvailable < daemonSet.Status.DesiredNumberScheduled {
+		return false, fmt.Sprintf("%d of %d pods available", daemonSet.Status.NumberAvailable, daemonSet.Status.DesiredNumberScheduled)
+	}
+
+	return true, ""
+}
+
+func replicaSetStatus(replicaSet *appsv1.ReplicaSet) (bool, string) {
+	if replicaSet.Generation > replicaSet.Status.ObservedGeneration {
+		return false, "waiting for the rollout to be observed"
+	}
+
+	replicas := int32(1)
+	if replicaSet.Spec.Replicas != nil {
+		replicas = *replicaSet.Spec.Replicas
+	}
+	if replicaSet.Status.AvailableReplicas < replicas {
+		return false, fmt.Sprintf("%d of %d replicas available", replicaSet.Status.AvailableReplicas, replicas)
+	}
+
+	return true, ""
+}
+
+func jobStatus(job *batchv1.Job) (bool, bool, string) {
+	for _, condition := range job.Status.Conditions {
+		if condition.Status != corev1.ConditionTrue {
+			continue
+		}
+
+		switch condition.Type {
+		case batchv1.JobComplete:
+			return true, false, ""
+		case batchv1.JobFailed:
+			return false, true, "failed: " + condition.Message
+		}
+	}
+
+	return false, false, fmt.Sprintf("%d active, %d succeeded, %d failed pods", job.Status.Active, job.Status.Succeeded, job.Status.Failed)
+}
+
+func namespaceStatus(namespace *corev1.Namespace) (bool, string) {
+	if namespace.Status.Phase != corev1.NamespaceActive {
+		return false, fmt.Sprintf("phase is %s", namespace.Status.Phase)
+	}
+
+	return true, ""
+}
+
+func customResourceDefinitionStatus(crd *unstructured.Unstructured) (bool, bool, string) {
+	conditions, _, _ := unstructured.NestedSlice(crd.Object, "status", "conditions")
+	for _, c := range conditions {
+		condition, ok := c.(map[string]interface{})
+		if !ok {
+			continue
+		}
+
+		switch condition["type"] {
+		case "Established":
+			if condition["status"] == string(corev1.ConditionTrue) {
+				return true, false, ""
+			}
+		case "NamesAccepted":
+			if condition["status"] == string(corev1.ConditionFalse) {
+				return false, true, fmt.Sprintf("names not accepted: %v", condition["message"])
+			}
+		}
+	}
+
+	return false, false, "not established yet"
+}
@@ -0,0 +1,215 @@
+package rollout
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/manifests"
+	kubectltesting "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	appsv1 "k8s.io/api/apps/v1"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+)
+
+func TestWorkloads(t *testing.T) {
+	objects, err := manifests.Parse(`
+apiVersion: v1
+kind: Service
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+---
+apiVersion: apps/v1
+kind: StatefulSet
+metadata:
+  name: db
+  namespace: other
+---
+apiVersion: batch/v1
+kind: Job
+metadata:
+  name: test
+  annotations:
+    helm.sh/hook: test
+---
+apiVersion: v1
+kind: Namespace
+metadata:
+  name: other
+---
+apiVersion: apiextensions.k8s.io/v1
+kind: CustomResourceDefinition
+metadata:
+  name: widgets.example.com
+`)
+	assert.NilError(t, err)
+
+	workloads := Workloads(objects, "default")
+	assert.DeepEqual(t, workloads, []Workload{
+		{Kind: "CustomResourceDefinition", Name: "widgets.example.com"},
+		{Kind: "Deployment", Name: "web", Namespace: "default"},
+		{Kind: "Namespace", Name: "other"},
+		{Kind: "StatefulSet", Name: "db", Namespace: "other"},
+	})
+}
+
+func TestGetStatus(t *testing.T) {
+	replicas := int32(2)
+	client := fake.NewSimpleClientset(
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "ready", Namespace: "default", Generation: 1},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: &replicas,
+				Selector: &metav1.LabelSelector{MatchLabels: map[string]string{"app": "ready"}},
+			},
+			Status: appsv1.DeploymentStatus{ObservedGeneration: 1, Replicas: 2, UpdatedReplicas: 2, AvailableReplicas: 2},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "progressing", Namespace: "default", Generation: 2},
+			Spec:       appsv1.DeploymentSpec{Replicas: &replicas},
+			Status:     appsv1.DeploymentStatus{ObservedGeneration: 2, Replicas: 3, UpdatedReplicas: 2, AvailableReplicas: 1},
+		},
+		&appsv1.Deployment{
+			ObjectMeta: metav1.ObjectMeta{Name: "stuck", Namespace: "default"},
+			Status: appsv1.DeploymentStatus{
+				Conditions: []appsv1.DeploymentCondition{{Type: appsv1.DeploymentProgressing, Reason: "ProgressDeadlineExceeded"}},
+			},
+		},
+		&batchv1.Job{
+			ObjectMeta: metav1.ObjectMeta{Name: "failed", Namespace: "default"},
+			Status: batchv1.JobStatus{
+				Conditions: []batchv1.JobCondition{{Type: batchv1.JobFailed, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"}},
+			},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "active"},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceActive},
+		},
+		&corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{Name: "terminating"},
+			Status:     corev1.NamespaceStatus{Phase: corev1.NamespaceTerminating},
+		},
+	)
+	dynamicClient := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(), map[schema.GroupVersionResource]string{
+		customResourceDefinitionResource: "CustomResourceDefinitionList",
+	},
+		customResourceDefinition("established.example.com", map[string]interface{}{"type": "Established", "status": "True"}),
+		customResourceDefinition("pending.example.com", map[string]interface{}{"type": "Established", "status": "False"}),
+		customResourceDefinition("conflict.example.com", map[string]interface{}{"type": "NamesAccepted", "status": "False", "message": "plural name is already in use"}),
+	)
+
+	testCases := []struct {
+		workload        Workload
+		expectedReady   bool
+		expectedFailed  bool
+		expectedMessage string
+	}{
+		{
+			workload:      Workload{Kind: "Deployment", Name: "ready", Namespace: "default"},
+			expectedReady: true,
+		},
+		{
+			workload:        Workload{Kind: "Deployment", Name: "progressing", Namespace: "default"},
+			expectedMessage: "1 old replicas pending termination",
+		},
+		{
+			workload:        Workload{Kind: "Deployment", Name: "stuck", Namespace: "default"},
+			expectedFailed:  true,
+			expectedMessage: "exceeded its progress deadline",
+		},
+		{
+			workload:        Workload{Kind: "Job", Name: "failed", Namespace: "default"},
+			expectedFailed:  true,
+			expectedMessage: "failed: BackoffLimitExceeded",
+		},
+		{
+			workload:        Workload{Kind: "StatefulSet", Name: "missing", Namespace: "default"},
+			expectedMessage: "not found",
+		},
+		{
+			workload:      Workload{Kind: "Namespace", Name: "active"},
+			expectedReady: true,
+		},
+		{
+			workload:        Workload{Kind: "Namespace", Name: "terminating"},
+			expectedMessage: "phase is Terminating",
+		},
+		{
+			workload:      Workload{Kind: "CustomResourceDefinition", Name: "established.example.com"},
+			expectedReady: true,
+		},
+		{
+			workload:        Workload{Kind: "CustomResourceDefinition", Name: "pending.example.com"},
+			expectedMessage: "not established yet",
+		},
+		{
+			workload:        Workload{Kind: "CustomResourceDefinition", Name: "conflict.example.com"},
+			expectedFailed:  true,
+			expectedMessage: "names not accepted: plural name is already in use",
+		},
+		{
+			workload:        Workload{Kind: "CustomResourceDefinition", Name: "missing.example.com"},
+			expectedMessage: "not found",
+		},
+	}
+
+	for _, testCase := range testCases {
+		status, err := GetStatus(context.Background(), client, dynamicClient, testCase.workload)
+		assert.NilError(t, err, testCase.workload.String())
+		assert.Equal(t, status.Ready, testCase.expectedReady, testCase.workload.String())
+		assert.Equal(t, status.Failed, testCase.expectedFailed, testCase.workload.String())
+		assert.Equal(t, status.Message, testCase.expectedMessage, testCase.workload.String())
+	}
+
+	status, err := GetStatus(context.Background(), client, dynamicClient, Workload{Kind: "Deployment", Name: "ready", Namespace: "default"})
+	assert.NilError(t, err)
+	assert.Equal(t, status.Selector.String(), "app=ready")
+}
+
+func customResourceDefinition(name string, condition map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]interface{}{"name": name},
+		"status": map[string]interface{}{
+			"conditions": []interface{}{condition},
+		},
+	}}
+}
+
+func TestWait(t *testing.T) {
+	workloads := []Workload{{Kind: "Deployment", Name: "app", Namespace: "default"}}
+
+	client := fake.NewSimpleClientset()
+	ctx := devspacecontext.NewContext(context.Background(), nil, log.Discard).WithKubeClient(&kubectltesting.Client{Client: client})
+	_, err := Wait(ctx, workloads, 10*time.Millisecond)
+	assert.Error(t, err, "timed out waiting for workloads to become ready:\nDeployment default/app: not found")
+
+	// canceling and errors retrieving the status are not reported as timeout
+	cancelCtx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = Wait(ctx.WithContext(cancelCtx), workloads, time.Minute)
+	assert.ErrorContains(t, err, "context canceled")
+
+	client.PrependReactor("get", "deployments", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewForbidden(schema.GroupResource{Group: "apps", Resource: "deployments"}, "app", nil)
+	})
+	_, err = Wait(ctx, workloads, time.Minute)
+	assert.ErrorContains(t, err, "Deployment default/app: deployments.apps \"app\" is forbidden")
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/drift"
 )
 
 // FakeController is the fake build controller
@@ -23,3 +24,18 @@ func (f *FakeController) Deploy(ctx devspacecontext.Context, deployments []strin
 func (f *FakeController) Purge(ctx devspacecontext.Context, deployments []string, options *deploy.PurgeOptions) error {
 	return nil
 }
+
+// Diff diffs the deployments
+func (f *FakeController) Diff(ctx devspacecontext.Context, deployments []string, options *deploy.Options) (bool, error) {
+	return false, nil
+}
+
+// Rollback rolls back the deployments
+func (f *FakeController) Rollback(ctx devspacecontext.Context, deployments []string, revision int) error {
+	return nil
+}
+
+// Drift detects drift of the deployments
+func (f *FakeController) Drift(ctx devspacecontext.Context, deployments []string) ([]*drift.Deployment, error) {
+	return nil, nil
+}
@@ -0,0 +1,125 @@
+package deploy
+
+import (
+	"bytes"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/analyze"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/deployer"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/manifests"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy/rollout"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// defaultWaitTimeout is used if no timeout is specified in the wait config
+const defaultWaitTimeout = 300 * time.Second
+
+// waitForRollout waits until the workloads of the deployment are rolled out and ready. If they
+// do not become ready, the workloads are analyzed and the report is printed. If force is true,
+// DevSpace waits even if no wait section is configured, unless waiting is explicitly disabled.
+func waitForRollout(ctx devspacecontext.Context, deployClient deployer.Interface, deployConfig *latest.DeploymentConfig, force bool) error {
+	waitConfig := deployConfig.Wait
+	if waitConfig == nil {
+		if !force {
+			return nil
+		}
+
+		waitConfig = &latest.DeploymentWaitConfig{}
+	} else if waitConfig.Enabled != nil && !*waitConfig.Enabled {
+		return nil
+	}
+
+	manifestsStr, namespace, err := deployedManifests(ctx, deployClient, deployConfig)
+	if err != nil {
+		return err
+	}
+
+	objects, err := manifests.Parse(manifestsStr)
+	if err != nil {
+		return errors.Wrap(err, "parse manifests")
+	}
+
+	workloads := rollout.Workloads(objects, namespace)
+	if len(workloads) == 0 {
+		return nil
+	}
+
+	timeout := defaultWaitTimeout
+	if waitConfig.Timeout > 0 {
+		timeout = time.Duration(waitConfig.Timeout) * time.Second
+	}
+
+	ctx.Log().Infof("Waiting for %d workload(s) to become ready...", len(workloads))
+	statuses, err := rollout.Wait(ctx, workloads, timeout)
+	if err != nil {
+		if !waitConfig.DisableAnalyze {
+			analyzeWorkloads(ctx, statuses)
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+// deployedManifests returns the manifests that were deployed and the namespace of namespaced objects
+// without a namespace. For helm this is the manifest of the release instead of a new helm template,
+// which could differ from the installed chart. Deployers that can't return what they deployed are rendered.
+func deployedManifests(ctx devspacecontext.Context, deployClient deployer.Interface, deployConfig *latest.DeploymentConfig) (string, string, error) {
+	namespace := deployConfig.Namespace
+	if namespace == "" {
+		namespace = ctx.KubeClient().Namespace()
+	}
+
+	if driftClient, ok := deployClient.(deployer.DriftInterface); ok {
+		deployed, err := driftClient.Deployed(ctx)
+		if err != nil {
+			return "", "", errors.Wrap(err, "get deployed manifests")
+		} else if deployed != nil {
+			if deployed.Namespace != "" {
+				namespace = deployed.Namespace
+			}
+
+			return deployed.Manifests, namespace, nil
+		}
+	}
+
+	buffer := &bytes.Buffer{}
+	err := deployClient.Render(ctx, buffer)
+	if err != nil {
+		return "", "", errors.Wrap(err, "render manifests")
+	}
+
+	return buffer.String(), namespace, nil
+}
+
+// analyzeWorkloads analyzes the pods of the given workloads and prints the report
+func analyzeWorkloads(ctx devspacecontext.Context, statuses []rollout.Status) {
+	selectors := map[string][]labels.Selector{}
+	namespaces := []string{}
+	for _, status := range statuses {
+		if status.Selector == nil {
+			continue
+		}
+		if _, ok := selectors[status.Workload.Namespace]; !ok {
+			namespaces = append(namespaces, status.Workload.Namespace)
+		}
+
+		selectors[status.Workload.Namespace] = append(selectors[status.Workload.Namespace], status.Selector)
+	}
+
+	analyzer := analyze.NewAnalyzer(ctx.KubeClient(), ctx.Log())
+	for _, namespace := range namespaces {
+		report, err := analyzer.CreateReport(namespace, analyze.Options{Selectors: selectors[namespace]})
+		if err != nil {
+			ctx.Log().Warnf("Error analyzing namespace %s: %v", namespace, err)
+			continue
+		}
+
+		ctx.Log().WriteString(logrus.InfoLevel, analyze.ReportToString(report))
+	}
+}
@@ -0,0 +1,164 @@
+package deploy
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/remotecache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+)
+
+// Waves groups the deployments into waves that have to be deployed one after another. A deployment
+// is placed into a later wave than all deployments with a lower wave number and all deployments
+// it depends on. Dependencies on deployments that are not part of the given deployments are ignored.
+// Within a wave the order of the given deployments is preserved.
+func Waves(deployments []*latest.DeploymentConfig) ([][]*latest.DeploymentConfig, error) {
+	byName := map[string]*latest.DeploymentConfig{}
+	waveNumbers := []int{}
+	for _, deployConfig := range deployments {
+		if _, ok := byName[deployConfig.Name]; !ok {
+			byName[deployConfig.Name] = deployConfig
+		}
+
+		found := false
+		for _, waveNumber := range waveNumbers {
+			if waveNumber == deployConfig.Wave {
+				found = true
+				break
+			}
+		}
+		if !found {
+			waveNumbers = append(waveNumbers, deployConfig.Wave)
+		}
+	}
+	sort.Ints(waveNumbers)
+
+	// calculate the level of each deployment
+	levels := map[string]int{}
+	offset := 0
+	for _, waveNumber := range waveNumbers {
+		maxLevel := offset
+		for _, deployConfig := range deployments {
+			if deployConfig.Wave != waveNumber {
+				continue
+			}
+
+			level, err := deploymentLevel(deployConfig, byName, levels, offset, []string{})
+			if err != nil {
+				return nil, err
+			}
+			maxLevel = max(maxLevel, level)
+		}
+
+		offset = maxLevel + 1
+	}
+
+	waves := make([][]*latest.DeploymentConfig, offset)
+	added := map[string]bool{}
+	for _, deployConfig := range deployments {
+		if added[deployConfig.Name] {
+			continue
+		}
+
+		added[deployConfig.Name] = true
+		level := levels[deployConfig.Name]
+		waves[level] = append(waves[level], deployConfig)
+	}
+
+	// remove empty waves
+	retWaves := [][]*latest.DeploymentConfig{}
+	for _, wave := range waves {
+		if len(wave) > 0 {
+			retWaves = append(retWaves, wave)
+		}
+	}
+
+	return retWaves, nil
+}
+
+func deploymentLevel(deployConfig *latest.DeploymentConfig, byName map[string]*latest.DeploymentConfig, levels map[string]int, offset int, path []string) (int, error) {
+	if level, ok := levels[deployConfig.Name]; ok {
+		return level, nil
+	}
+	for _, name := range path {
+		if name == deployConfig.Name {
+			return 0, errors.Errorf("cyclic dependency between deployments: %s", strings.Join(append(path, deployConfig.Name), " -> "))
+		}
+	}
+
+	level := offset
+	for _, dependency := range deployConfig.DependsOn {
+		dependencyConfig, ok := byName[dependency]
+		if !ok {
+			continue
+		} else if dependencyConfig.Wave > deployConfig.Wave {
+			return 0, errors.Errorf("deployment %s depends on deployment %s which has a higher wave (%d > %d)", deployConfig.Name, dependency, dependencyConfig.Wave, deployConfig.Wave)
+		} else if dependencyConfig.Wave < deployConfig.Wave {
+			// deployments of lower waves are always deployed before
+			continue
+		}
+
+		dependencyLevel, err := deploymentLevel(dependencyConfig, byName, levels, offset, append(path, deployConfig.Name))
+		if err != nil {
+			return 0, err
+		}
+		level = max(level, dependencyLevel+1)
+	}
+
+	levels[deployConfig.Name] = level
+	return level, nil
+}
+
+// purgeOrder returns the deployment caches in the order they should be purged, which is the
+// reverse of the order they were deployed in. Deployments that are not part of the config
+// anymore are purged first.
+func purgeOrder(ctx devspacecontext.Context, deploymentCaches []remotecache.DeploymentCache) []remotecache.DeploymentCache {
+	ordered := []remotecache.DeploymentCache{}
+	for i := len(deploymentCaches) - 1; i >= 0; i-- {
+		ordered = append(ordered, deploymentCaches[i])
+	}
+	if ctx.Config() == nil || ctx.Config().Config() == nil || len(ctx.Config().Config().Deployments) == 0 {
+		return ordered
+	}
+
+	deployments := []*latest.DeploymentConfig{}
+	for _, deployConfig := range ctx.Config().Config().Deployments {
+		deployments = append(deployments, deployConfig)
+	}
+	sort.Slice(deployments, func(i, j int) bool {
+		return deployments[i].Name < deployments[j].Name
+	})
+
+	waves, err := Waves(deployments)
+	if err != nil {
+		ctx.Log().Debugf("Error calculating purge order: %v", err)
+		return ordered
+	}
+
+	// helm deployments are cached under their release name
+	levels := map[string]int{}
+	for level, wave := range waves {
+		for _, deployConfig := range wave {
+			levels[deployConfig.Name] = level
+			if deployConfig.Helm != nil && deployConfig.Helm.ReleaseName != "" {
+				levels[deployConfig.Helm.ReleaseName] = level
+			}
+		}
+	}
+
+	sort.SliceStable(ordered, func(i, j int) bool {
+		levelI, ok := levels[ordered[i].Name]
+		if !ok {
+			levelI = len(waves)
+		}
+		levelJ, ok := levels[ordered[j].Name]
+		if !ok {
+			levelJ = len(waves)
+		}
+
+		return levelI > levelJ
+	})
+	return ordered
+}
@@ -0,0 +1,98 @@
+package deploy
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"gotest.tools/assert"
+)
+
+func TestWaves(t *testing.T) {
+	testCases := []struct {
+		name          string
+		deployments   []*latest.DeploymentConfig
+		expectedWaves [][]string
+		expectedErr   string
+	}{
+		{
+			name: "No ordering",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "a"},
+				{Name: "b"},
+			},
+			expectedWaves: [][]string{{"a", "b"}},
+		},
+		{
+			name: "Waves",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "app", Wave: 2},
+				{Name: "crds", Wave: -1},
+				{Name: "operator"},
+				{Name: "db"},
+			},
+			expectedWaves: [][]string{{"crds"}, {"operator", "db"}, {"app"}},
+		},
+		{
+			name: "Depends on",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "app", DependsOn: []string{"operator", "db"}},
+				{Name: "crds"},
+				{Name: "db"},
+				{Name: "operator", DependsOn: []string{"crds"}},
+			},
+			expectedWaves: [][]string{{"crds", "db"}, {"operator"}, {"app"}},
+		},
+		{
+			name: "Waves and depends on",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "app", Wave: 1, DependsOn: []string{"operator"}},
+				{Name: "frontend", Wave: 1, DependsOn: []string{"app"}},
+				{Name: "operator", DependsOn: []string{"crds"}},
+				{Name: "crds"},
+			},
+			expectedWaves: [][]string{{"crds"}, {"operator"}, {"app"}, {"frontend"}},
+		},
+		{
+			name: "Ignore unselected dependencies",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "app", DependsOn: []string{"db"}},
+			},
+			expectedWaves: [][]string{{"app"}},
+		},
+		{
+			name: "Cyclic dependency",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", DependsOn: []string{"a"}},
+			},
+			expectedErr: "cyclic dependency between deployments: a -> b -> a",
+		},
+		{
+			name: "Dependency with higher wave",
+			deployments: []*latest.DeploymentConfig{
+				{Name: "a", DependsOn: []string{"b"}},
+				{Name: "b", Wave: 1},
+			},
+			expectedErr: "deployment a depends on deployment b which has a higher wave (1 > 0)",
+		},
+	}
+
+	for _, testCase := range testCases {
+		waves, err := Waves(testCase.deployments)
+		if testCase.expectedErr != "" {
+			assert.Error(t, err, testCase.expectedErr, "Unexpected error in testCase %s", testCase.name)
+			continue
+		}
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+
+		names := [][]string{}
+		for _, wave := range waves {
+			waveNames := []string{}
+			for _, deployConfig := range wave {
+				waveNames = append(waveNames, deployConfig.Name)
+			}
+			names = append(names, waveNames)
+		}
+		assert.DeepEqual(t, names, testCase.expectedWaves)
+	}
+}
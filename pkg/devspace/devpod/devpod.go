@@ -288,13 +288,23 @@ func (d *devPod) start(ctx devspacecontext.Context, devPodConfig *latest.DevPod,
 	d.selectedPod = selectedPod
 	d.m.Unlock()
 
+	// allocate auto ports, so they can be used within open urls and hooks
+	err = portforwarding.ResolveAutoPorts(ctx, devPodConfig)
+	if err != nil {
+		return errors.Wrap(err, "allocate ports")
+	}
+
 	// Run dev.open configs
 	if !opts.DisableOpen {
 		ctx := ctx.WithLogger(ctx.Log().WithPrefixColor("open  ", "yellow+b"))
 		for _, openConfig := range devPodConfig.Open {
 			if openConfig.URL != "" {
-				url := openConfig.URL
-				ctx.Log().Infof("Opening '%s' as soon as application will be started", openConfig.URL)
+				url, err := runtimevar.NewRuntimeResolver(ctx.WorkingDir(), true).FillRuntimeVariablesAsString(ctx.Context(), openConfig.URL, ctx.Config(), ctx.Dependencies())
+				if err != nil {
+					return errors.Wrapf(err, "resolve open url %s", openConfig.URL)
+				}
+
+				ctx.Log().Infof("Opening '%s' as soon as application will be started", url)
 				parent.Go(func() error {
 					now := time.Now()
 					for time.Since(now) < openMaxWait {
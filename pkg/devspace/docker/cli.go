@@ -38,6 +38,12 @@ func (c *client) ImageBuildCLI(ctx context.Context, workingDir string, environ e
 	if options.Target != "" {
 		args = append(args, "--target", options.Target)
 	}
+	if options.Platform != "" {
+		args = append(args, "--platform", options.Platform)
+	}
+	for _, cacheFrom := range options.CacheFrom {
+		args = append(args, "--cache-from", cacheFrom)
+	}
 
 	args = append(args, additionalArgs...)
 	args = append(args, "-")
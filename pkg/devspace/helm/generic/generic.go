@@ -1,9 +1,10 @@
 package generic
 
 import (
+	"bytes"
 	"context"
 	"fmt"
-	"os"
+	"io"
 	"strings"
 
 	"github.com/loft-sh/devspace/pkg/devspace/config/constants"
@@ -25,7 +26,7 @@ const stableChartRepo = "https://charts.helm.sh/stable"
 
 type Client interface {
 	Exec(ctx devspacecontext.Context, args []string) ([]byte, error)
-	WriteValues(values map[string]interface{}) (string, error)
+	ExecWithValues(ctx devspacecontext.Context, args []string, values map[string]interface{}) ([]byte, error)
 }
 
 func NewGenericClient(command commands.Command, log log.Logger) Client {
@@ -46,26 +47,22 @@ type client struct {
 	helmPath string
 }
 
-func (c *client) WriteValues(values map[string]interface{}) (string, error) {
-	f, err := os.CreateTemp("", "")
-	if err != nil {
-		return "", err
-	}
-	defer f.Close()
+// ExecWithValues executes helm and passes the values via stdin, so they are never written to disk.
+// The args need to contain "--values -" for helm to read them.
+func (c *client) ExecWithValues(ctx devspacecontext.Context, args []string, values map[string]interface{}) ([]byte, error) {
 	out, err := yaml.Marshal(values)
 	if err != nil {
-		return "", errors.Wrap(err, "marshal values")
+		return nil, errors.Wrap(err, "marshal values")
 	}
 
-	_, err = f.Write(out)
-	if err != nil {
-		return "", err
-	}
-
-	return f.Name(), nil
+	return c.exec(ctx, args, bytes.NewReader(out))
 }
 
 func (c *client) Exec(ctx devspacecontext.Context, args []string) ([]byte, error) {
+	return c.exec(ctx, args, nil)
+}
+
+func (c *client) exec(ctx devspacecontext.Context, args []string, stdin io.Reader) ([]byte, error) {
 	err := c.ensureHelmBinary(ctx.Context())
 	if err != nil {
 		return nil, err
@@ -80,12 +77,13 @@ func (c *client) Exec(ctx devspacecontext.Context, args []string) ([]byte, error
 		c.log.Debugf("Execute '%s %s' in directory %s", c.helmPath, strings.Join(args, " "), ctx.WorkingDir())
 	}
 
-	result, err := command.Output(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), c.helmPath, args...)
+	result := &bytes.Buffer{}
+	err = command.Command(ctx.Context(), ctx.WorkingDir(), ctx.Environ(), result, nil, stdin, c.helmPath, args...)
 	if err != nil {
-		return nil, fmt.Errorf("%s %v", string(result), err)
+		return nil, fmt.Errorf("%s %v", result.String(), err)
 	}
 
-	return result, nil
+	return result.Bytes(), nil
 }
 
 func (c *client) ensureHelmBinary(ctx context.Context) error {
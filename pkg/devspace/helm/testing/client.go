@@ -2,6 +2,7 @@ package v2
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
@@ -13,6 +14,9 @@ import (
 // Client implements Interface
 type Client struct {
 	Releases []*types.Release
+
+	// Manifests are the rendered manifests by release name
+	Manifests map[string]string
 }
 
 func (f *Client) DownloadChart(ctx devspacecontext.Context, helmConfig *latest.HelmConfig) (string, error) {
@@ -35,6 +39,28 @@ func (f *Client) DeleteRelease(ctx devspacecontext.Context, releaseName string,
 	return fmt.Errorf("release %s not found", releaseName)
 }
 
+// RollbackRelease rolls a helm release back by creating a new revision
+func (f *Client) RollbackRelease(ctx devspacecontext.Context, releaseName string, releaseNamespace string, revision string) error {
+	for _, release := range f.Releases {
+		if release.Name == releaseName {
+			current, _ := strconv.Atoi(release.Revision)
+			release.Revision = strconv.Itoa(current + 1)
+			return nil
+		}
+	}
+	return fmt.Errorf("release %s not found", releaseName)
+}
+
+// GetManifest returns the manifests of a helm release
+func (f *Client) GetManifest(ctx devspacecontext.Context, releaseName string, releaseNamespace string) (string, error) {
+	for _, release := range f.Releases {
+		if release.Name == releaseName {
+			return f.Manifests[releaseName], nil
+		}
+	}
+	return "", fmt.Errorf("release %s not found", releaseName)
+}
+
 // ListReleases lists all helm Releases
 func (f *Client) ListReleases(ctx devspacecontext.Context, releaseNamespace string) ([]*types.Release, error) {
 	return f.Releases, nil
@@ -11,6 +11,8 @@ type Client interface {
 	InstallChart(ctx devspacecontext.Context, releaseName string, releaseNamespace string, values map[string]interface{}, helmConfig *latest.HelmConfig) (*Release, error)
 	Template(ctx devspacecontext.Context, releaseName, releaseNamespace string, values map[string]interface{}, helmConfig *latest.HelmConfig) (string, error)
 	DeleteRelease(ctx devspacecontext.Context, releaseName string, releaseNamespace string) error
+	RollbackRelease(ctx devspacecontext.Context, releaseName string, releaseNamespace string, revision string) error
+	GetManifest(ctx devspacecontext.Context, releaseName string, releaseNamespace string) (string, error)
 	ListReleases(ctx devspacecontext.Context, releaseNamespace string) ([]*Release, error)
 }
 
@@ -40,12 +40,6 @@ func (c *client) DownloadChart(ctx devspacecontext.Context, helmConfig *latest.H
 
 // InstallChart installs the given chart via helm v3
 func (c *client) InstallChart(ctx devspacecontext.Context, releaseName string, releaseNamespace string, values map[string]interface{}, helmConfig *latest.HelmConfig) (*types.Release, error) {
-	valuesFile, err := c.genericHelm.WriteValues(values)
-	if err != nil {
-		return nil, err
-	}
-	defer os.Remove(valuesFile)
-
 	if releaseNamespace == "" {
 		releaseNamespace = ctx.KubeClient().Namespace()
 	}
@@ -54,7 +48,7 @@ func (c *client) InstallChart(ctx devspacecontext.Context, releaseName string, r
 		"upgrade",
 		releaseName,
 		"--values",
-		valuesFile,
+		"-",
 		"--install",
 	}
 
@@ -127,7 +121,7 @@ func (c *client) InstallChart(ctx devspacecontext.Context, releaseName string, r
 
 	// Upgrade options
 	args = append(args, helmConfig.UpgradeArgs...)
-	output, err := c.genericHelm.Exec(ctx, args)
+	output, err := c.genericHelm.ExecWithValues(ctx, args, values)
 	if helmConfig.DisplayOutput {
 		writer := ctx.Log().Writer(logrus.InfoLevel, false)
 		_, _ = writer.Write(output)
@@ -152,12 +146,6 @@ func (c *client) InstallChart(ctx devspacecontext.Context, releaseName string, r
 }
 
 func (c *client) Template(ctx devspacecontext.Context, releaseName, releaseNamespace string, values map[string]interface{}, helmConfig *latest.HelmConfig) (string, error) {
-	valuesFile, err := c.genericHelm.WriteValues(values)
-	if err != nil {
-		return "", err
-	}
-	defer os.Remove(valuesFile)
-
 	if releaseNamespace == "" {
 		releaseNamespace = ctx.KubeClient().Namespace()
 	}
@@ -166,7 +154,7 @@ func (c *client) Template(ctx devspacecontext.Context, releaseName, releaseNames
 		"template",
 		releaseName,
 		"--values",
-		valuesFile,
+		"-",
 	}
 	if releaseNamespace != "" {
 		args = append(args, "--namespace", releaseNamespace)
@@ -214,7 +202,7 @@ func (c *client) Template(ctx devspacecontext.Context, releaseName, releaseNames
 		}
 	}
 	args = append(args, helmConfig.TemplateArgs...)
-	result, err := c.genericHelm.Exec(ctx, args)
+	result, err := c.genericHelm.ExecWithValues(ctx, args, values)
 	if err != nil {
 		return "", err
 	}
@@ -244,6 +232,53 @@ func (c *client) DeleteRelease(ctx devspacecontext.Context, releaseName string,
 	return nil
 }
 
+func (c *client) RollbackRelease(ctx devspacecontext.Context, releaseName string, releaseNamespace string, revision string) error {
+	if releaseNamespace == "" {
+		releaseNamespace = ctx.KubeClient().Namespace()
+	}
+
+	args := []string{
+		"rollback",
+		releaseName,
+	}
+	if revision != "" {
+		args = append(args, revision)
+	}
+	if releaseNamespace != "" {
+		args = append(args, "--namespace", releaseNamespace)
+	}
+
+	_, err := c.genericHelm.Exec(ctx, args)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetManifest returns the rendered manifests of the current revision of the release
+func (c *client) GetManifest(ctx devspacecontext.Context, releaseName string, releaseNamespace string) (string, error) {
+	if releaseNamespace == "" {
+		releaseNamespace = ctx.KubeClient().Namespace()
+	}
+
+	args := []string{
+		"get",
+		"manifest",
+		releaseName,
+	}
+	if releaseNamespace != "" {
+		args = append(args, "--namespace", releaseNamespace)
+	}
+
+	out, err := c.genericHelm.Exec(ctx, args)
+	if err != nil {
+		return "", err
+	}
+
+	return string(out), nil
+}
+
 func (c *client) ListReleases(ctx devspacecontext.Context, namespace string) ([]*types.Release, error) {
 	args := []string{
 		"list",
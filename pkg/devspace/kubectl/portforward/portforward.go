@@ -31,6 +31,7 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"sync/atomic"
 )
 
 // PortForwardProtocolV1Name is the subprotocol used for port forwarding.
@@ -54,10 +55,46 @@ type PortForwarder struct {
 	requestID     int
 	out           io.Writer
 	errOut        io.Writer
+	stats         *Stats
 
 	log log.Logger
 }
 
+// Stats holds the traffic counters of a port forwarder. The counters are
+// updated atomically and can be read with the Get* functions at any time.
+type Stats struct {
+	activeConnections int64
+	bytesSent         int64
+	bytesReceived     int64
+}
+
+// GetActiveConnections returns the amount of currently open local connections
+func (s *Stats) GetActiveConnections() int64 {
+	return atomic.LoadInt64(&s.activeConnections)
+}
+
+// GetBytesSent returns the amount of bytes sent from local connections to the pod
+func (s *Stats) GetBytesSent() int64 {
+	return atomic.LoadInt64(&s.bytesSent)
+}
+
+// GetBytesReceived returns the amount of bytes received from the pod
+func (s *Stats) GetBytesReceived() int64 {
+	return atomic.LoadInt64(&s.bytesReceived)
+}
+
+// countingWriter adds the amount of written bytes to counter
+type countingWriter struct {
+	writer  io.Writer
+	counter *int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.writer.Write(p)
+	atomic.AddInt64(c.counter, int64(n))
+	return n, err
+}
+
 // ForwardedPort contains a Local:Remote port pairing.
 type ForwardedPort struct {
 	Local  uint16
@@ -189,6 +226,12 @@ func NewOnAddresses(dialer httpstream.Dialer, addresses []string, ports []string
 	}, nil
 }
 
+// SetStats sets the stats the port forwarder should record its traffic into. This
+// has to be called before ForwardPorts and allows to share stats across forwarders.
+func (pf *PortForwarder) SetStats(stats *Stats) {
+	pf.stats = stats
+}
+
 func (pf *PortForwarder) raiseError(err error) {
 	go func() {
 		if pf.errChan != nil {
@@ -401,9 +444,21 @@ func (pf *PortForwarder) handleConnection(conn io.ReadWriteCloser, port Forwarde
 	localError := make(chan struct{})
 	remoteDone := make(chan struct{})
 
+	var (
+		localWriter  io.Writer = conn
+		remoteWriter io.Writer = dataStream
+	)
+	if pf.stats != nil {
+		atomic.AddInt64(&pf.stats.activeConnections, 1)
+		defer atomic.AddInt64(&pf.stats.activeConnections, -1)
+
+		localWriter = &countingWriter{writer: conn, counter: &pf.stats.bytesReceived}
+		remoteWriter = &countingWriter{writer: dataStream, counter: &pf.stats.bytesSent}
+	}
+
 	go func() {
 		// Copy from the remote side to the local port.
-		if _, err := io.Copy(conn, dataStream); err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
+		if _, err := io.Copy(localWriter, dataStream); err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
 			pf.log.Errorf("error copying from remote stream to local connection: %v", err)
 			//pf.raiseError(fmt.Errorf("error copying from remote stream to local connection: %v", err))
 			// runtime.HandleError(fmt.Errorf("error copying from remote stream to local connection: %v", err))
@@ -418,7 +473,7 @@ func (pf *PortForwarder) handleConnection(conn io.ReadWriteCloser, port Forwarde
 		defer dataStream.Close()
 
 		// Copy from the local port to the remote side.
-		if _, err := io.Copy(dataStream, conn); err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
+		if _, err := io.Copy(remoteWriter, conn); err != nil && !strings.Contains(err.Error(), "use of closed network connection") {
 			pf.log.Errorf("error copying from local connection to remote stream: %v", err)
 			//pf.raiseError(fmt.Errorf("error copying from local connection to remote stream: %v", err))
 			// runtime.HandleError(fmt.Errorf("error copying from local connection to remote stream: %v", err))
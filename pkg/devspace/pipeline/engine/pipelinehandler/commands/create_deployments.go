@@ -84,6 +84,9 @@ func CreateDeployments(ctx devspacecontext.Context, pipeline types.Pipeline, arg
 	if options.RenderWriter == nil {
 		options.RenderWriter = stdout
 	}
+	if options.Diff {
+		return diffDeployments(ctx, args, &options.Options)
+	}
 	return deploy.NewController().Deploy(ctx, args, &options.Options)
 }
 
@@ -0,0 +1,67 @@
+package commands
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/jessevdk/go-flags"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/deploy"
+	"github.com/loft-sh/devspace/pkg/devspace/pipeline/types"
+	"github.com/loft-sh/devspace/pkg/util/stringutil"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/interp"
+)
+
+// DiffDeploymentsOptions describe how deployments should get diffed
+type DiffDeploymentsOptions struct {
+	All      bool     `long:"all" description:"Diff all deployments"`
+	Except   []string `long:"except" description:"If used with --all, will exclude the following deployments"`
+	ExitCode bool     `long:"exit-code" description:"If true, exits with a non zero exit code if deploying would change the cluster"`
+}
+
+func DiffDeployments(ctx devspacecontext.Context, pipeline types.Pipeline, args []string, stdout io.Writer) error {
+	ctx.Log().Debugf("diff_deployments %s", strings.Join(args, " "))
+	if ctx.KubeClient() == nil {
+		return errors.Errorf(ErrMsg)
+	}
+	options := &DiffDeploymentsOptions{}
+	args, err := flags.ParseArgs(options, args)
+	if err != nil {
+		return errors.Wrap(err, "parse args")
+	}
+
+	if options.All {
+		args = []string{}
+		for deployment := range ctx.Config().Config().Deployments {
+			if stringutil.Contains(options.Except, deployment) {
+				continue
+			}
+
+			args = append(args, deployment)
+		}
+		if len(args) == 0 {
+			return nil
+		}
+	} else if len(args) == 0 {
+		return fmt.Errorf("either specify 'diff_deployments --all' or 'diff_deployments deployment1 deployment2'")
+	}
+
+	return diffDeployments(ctx, args, &deploy.Options{
+		RenderWriter: stdout,
+		Diff:         true,
+		DiffExitCode: options.ExitCode,
+	})
+}
+
+func diffDeployments(ctx devspacecontext.Context, deployments []string, options *deploy.Options) error {
+	changed, err := deploy.NewController().Diff(ctx, deployments, options)
+	if err != nil {
+		return err
+	} else if changed && options.DiffExitCode {
+		return interp.NewExitStatus(1)
+	}
+
+	return nil
+}
@@ -49,6 +49,10 @@ var PipelineCommands = map[string]func(devCtx devspacecontext.Context, pipeline
 		hc := interp.HandlerCtx(devCtx.Context())
 		return commands.CreateDeployments(devCtx, pipeline, args, hc.Stdout)
 	},
+	"diff_deployments": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
+		hc := interp.HandlerCtx(devCtx.Context())
+		return commands.DiffDeployments(devCtx, pipeline, args, hc.Stdout)
+	},
 	"purge_deployments": func(devCtx devspacecontext.Context, pipeline types.Pipeline, args []string) error {
 		return commands.PurgeDeployments(devCtx, pipeline, args)
 	},
@@ -23,6 +23,10 @@ type Metadata struct {
 	// Hooks are commands that will be executed at specific events
 	Hooks []Hook `json:"hooks,omitempty"`
 
+	// Builders are image builders that can be referenced in the config via
+	// images.*.external.builder
+	Builders []Builder `json:"builders,omitempty"`
+
 	// This will be filled after parsing the metadata
 	PluginFolder string `json:"pluginFolder,omitempty"`
 }
@@ -38,6 +42,14 @@ type Hook struct {
 	BaseArgs []string `json:"baseArgs,omitempty"`
 }
 
+type Builder struct {
+	// Name is the name of the builder
+	Name string `json:"name"`
+
+	// BaseArgs that will be prepended to the builder action for this plugin builder
+	BaseArgs []string `json:"baseArgs,omitempty"`
+}
+
 type Binary struct {
 	// The current OS
 	OS string `json:"os"`
@@ -0,0 +1,44 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+
+	"github.com/loft-sh/devspace/pkg/devspace/services/inspect"
+)
+
+func (h *handler) httpRequests(w http.ResponseWriter, r *http.Request) {
+	var (
+		since uint64
+		port  int
+		err   error
+	)
+
+	sinceParam, ok := r.URL.Query()["since"]
+	if ok && len(sinceParam) == 1 && sinceParam[0] != "" {
+		since, err = strconv.ParseUint(sinceParam[0], 10, 64)
+		if err != nil {
+			http.Error(w, "since is invalid", http.StatusBadRequest)
+			return
+		}
+	}
+
+	portParam, ok := r.URL.Query()["port"]
+	if ok && len(portParam) == 1 && portParam[0] != "" {
+		port, err = strconv.Atoi(portParam[0])
+		if err != nil {
+			http.Error(w, "port is invalid", http.StatusBadRequest)
+			return
+		}
+	}
+
+	b, err := json.Marshal(inspect.GetStore().List(since, port))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
@@ -0,0 +1,19 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/loft-sh/devspace/pkg/devspace/services/portforwarding"
+)
+
+func (h *handler) portsStatus(w http.ResponseWriter, r *http.Request) {
+	b, err := json.Marshal(portforwarding.Status())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write(b)
+}
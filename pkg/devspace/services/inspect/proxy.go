@@ -0,0 +1,223 @@
+package inspect
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// MaxBodySize is the maximum amount of bytes that is recorded of a request or response body
+const MaxBodySize = 4 * 1024
+
+// RedactedValue replaces the values of headers that hold credentials in the records
+const RedactedValue = "<redacted>"
+
+// sensitiveHeaders are the headers that are never recorded in plaintext
+var sensitiveHeaders = []string{"Authorization", "Proxy-Authorization", "Cookie", "Set-Cookie"}
+
+var (
+	proxies      = map[string]int{}
+	proxiesMutex sync.Mutex
+)
+
+// EnsureProxy makes sure an inspecting reverse proxy listens on bindAddress:localPort and returns
+// the internal port the proxy forwards to. Subsequent calls with the same name and local port reuse
+// the already running proxy, so the returned port stays stable across port forwarding restarts.
+// The proxy is stopped as soon as ctx is done.
+func EnsureProxy(ctx context.Context, name, bindAddress string, localPort int) (int, error) {
+	proxiesMutex.Lock()
+	defer proxiesMutex.Unlock()
+
+	key := name + "/" + bindAddress + ":" + strconv.Itoa(localPort)
+	if targetPort, ok := proxies[key]; ok {
+		return targetPort, nil
+	}
+
+	targetPort, err := freePort()
+	if err != nil {
+		return 0, err
+	}
+
+	listener, err := net.Listen("tcp", net.JoinHostPort(bindAddress, strconv.Itoa(localPort)))
+	if err != nil {
+		return 0, errors.Wrapf(err, "listen on port %d", localPort)
+	}
+
+	target := &url.URL{Scheme: "http", Host: "127.0.0.1:" + strconv.Itoa(targetPort)}
+	server := &http.Server{Handler: NewProxy(name, localPort, target, GetStore())}
+	go func() {
+		_ = server.Serve(listener)
+	}()
+	go func() {
+		<-ctx.Done()
+		_ = server.Close()
+
+		proxiesMutex.Lock()
+		defer proxiesMutex.Unlock()
+		delete(proxies, key)
+	}()
+
+	proxies[key] = targetPort
+	return targetPort, nil
+}
+
+// NewProxy creates a new reverse proxy to target that records every request into the store
+func NewProxy(name string, port int, target *url.URL, store *Store) http.Handler {
+	proxy := httputil.NewSingleHostReverseProxy(target)
+	proxy.Transport = &recordingTransport{
+		name:      name,
+		port:      port,
+		store:     store,
+		transport: http.DefaultTransport,
+	}
+	return proxy
+}
+
+type recordingTransport struct {
+	name  string
+	port  int
+	store *Store
+
+	transport http.RoundTripper
+}
+
+func (r *recordingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	record := &Record{
+		Time:           time.Now(),
+		Name:           r.name,
+		Port:           r.port,
+		Method:         req.Method,
+		Host:           req.Host,
+		Path:           req.URL.RequestURI(),
+		RequestHeaders: redactHeaders(req.Header),
+	}
+
+	requestBody := &limitedBuffer{limit: MaxBodySize}
+	if req.Body != nil && req.Body != http.NoBody {
+		req.Body = &teeReadCloser{ReadCloser: req.Body, buffer: requestBody}
+	}
+
+	resp, err := r.transport.RoundTrip(req)
+	record.Latency = time.Since(record.Time)
+	if err != nil {
+		record.Error = err.Error()
+		record.RequestBody = requestBody.String()
+		r.store.Add(record)
+		return nil, err
+	}
+
+	record.Status = resp.StatusCode
+	record.ResponseHeaders = redactHeaders(resp.Header)
+
+	// upgraded connections need the raw body, so we don't record it
+	if resp.StatusCode == http.StatusSwitchingProtocols {
+		record.RequestBody = requestBody.String()
+		r.store.Add(record)
+		return resp, nil
+	}
+
+	responseBody := &limitedBuffer{limit: MaxBodySize}
+	resp.Body = &teeReadCloser{
+		ReadCloser: resp.Body,
+		buffer:     responseBody,
+		onClose: func() {
+			record.RequestBody = requestBody.String()
+			record.ResponseBody = responseBody.String()
+			r.store.Add(record)
+		},
+	}
+	return resp, nil
+}
+
+// redactHeaders returns a copy of the headers with the values of credential headers replaced
+func redactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	for _, name := range sensitiveHeaders {
+		values := redacted.Values(name)
+		for i := range values {
+			values[i] = RedactedValue
+		}
+	}
+
+	return redacted
+}
+
+type teeReadCloser struct {
+	io.ReadCloser
+
+	buffer  *limitedBuffer
+	once    sync.Once
+	onClose func()
+}
+
+func (t *teeReadCloser) Read(p []byte) (int, error) {
+	n, err := t.ReadCloser.Read(p)
+	if n > 0 {
+		_, _ = t.buffer.Write(p[:n])
+	}
+	return n, err
+}
+
+func (t *teeReadCloser) Close() error {
+	err := t.ReadCloser.Close()
+	if t.onClose != nil {
+		t.once.Do(t.onClose)
+	}
+	return err
+}
+
+// limitedBuffer is a buffer that silently discards everything beyond limit
+type limitedBuffer struct {
+	m sync.Mutex
+
+	limit     int
+	buffer    bytes.Buffer
+	truncated bool
+}
+
+func (l *limitedBuffer) Write(p []byte) (int, error) {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	remaining := l.limit - l.buffer.Len()
+	if remaining < len(p) {
+		l.truncated = true
+		if remaining > 0 {
+			l.buffer.Write(p[:remaining])
+		}
+		return len(p), nil
+	}
+
+	return l.buffer.Write(p)
+}
+
+func (l *limitedBuffer) String() string {
+	l.m.Lock()
+	defer l.m.Unlock()
+
+	if l.truncated {
+		return l.buffer.String() + fmt.Sprintf("... (truncated after %d bytes)", l.limit)
+	}
+
+	return l.buffer.String()
+}
+
+func freePort() (int, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return 0, errors.Wrap(err, "find free port")
+	}
+	defer listener.Close()
+
+	return listener.Addr().(*net.TCPAddr).Port, nil
+}
@@ -0,0 +1,101 @@
+package inspect
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestProxyRecordsRequests(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		w.Header().Set("X-Test", "true")
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("echo:" + string(body) + strings.Repeat("a", MaxBodySize)))
+	}))
+	defer backend.Close()
+
+	target, err := url.Parse(backend.URL)
+	assert.NilError(t, err)
+
+	store := NewStore(2, nil)
+	proxy := httptest.NewServer(NewProxy("test", 8080, target, store))
+	defer proxy.Close()
+
+	for i := 0; i < 3; i++ {
+		resp, err := http.Post(proxy.URL+"/orders?id=1", "text/plain", strings.NewReader("hello"))
+		assert.NilError(t, err)
+		_, _ = io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		assert.Equal(t, resp.StatusCode, http.StatusCreated)
+	}
+
+	records := store.List(0, 0)
+	assert.Equal(t, len(records), 2)
+	assert.Equal(t, records[0].ID, uint64(2))
+	assert.Equal(t, records[1].ID, uint64(3))
+
+	record := records[1]
+	assert.Equal(t, record.Name, "test")
+	assert.Equal(t, record.Port, 8080)
+	assert.Equal(t, record.Method, http.MethodPost)
+	assert.Equal(t, record.Path, "/orders?id=1")
+	assert.Equal(t, record.Status, http.StatusCreated)
+	assert.Equal(t, record.RequestBody, "hello")
+	assert.Equal(t, record.ResponseHeaders.Get("X-Test"), "true")
+	assert.Assert(t, strings.HasPrefix(record.ResponseBody, "echo:hello"))
+	assert.Assert(t, strings.HasSuffix(record.ResponseBody, "(truncated after 4096 bytes)"))
+
+	assert.Equal(t, len(store.List(2, 0)), 1)
+	assert.Equal(t, len(store.List(0, 9090)), 0)
+}
+
+func TestProxyRedactsCredentials(t *testing.T) {
+	backend := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the proxied request itself must still carry the credentials
+		assert.Equal(t, r.Header.Get("Authorization"), "Bearer secret-token")
+		assert.Equal(t, r.Header.Get("Cookie"), "session=secret")
+
+		http.SetCookie(w, &http.Cookie{Name: "session", Value: "new-secret"})
+		w.Header().Set("X-Test", "true")
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer backend.Close()
+
+	target, err := url.Parse(backend.URL)
+	assert.NilError(t, err)
+
+	store := NewStore(10, nil)
+	proxy := httptest.NewServer(NewProxy("test", 8080, target, store))
+	defer proxy.Close()
+
+	req, err := http.NewRequest(http.MethodGet, proxy.URL+"/login", nil)
+	assert.NilError(t, err)
+	req.Header.Set("Authorization", "Bearer secret-token")
+	req.Header.Set("Proxy-Authorization", "Basic c2VjcmV0")
+	req.Header.Set("Cookie", "session=secret")
+	req.Header.Set("X-Request-Id", "1")
+
+	resp, err := http.DefaultClient.Do(req)
+	assert.NilError(t, err)
+	_, _ = io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	assert.Equal(t, resp.Header.Get("Set-Cookie"), "session=new-secret")
+
+	records := store.List(0, 0)
+	assert.Equal(t, len(records), 1)
+
+	record := records[0]
+	assert.Equal(t, record.RequestHeaders.Get("Authorization"), RedactedValue)
+	// hop-by-hop headers are already dropped by the reverse proxy
+	assert.Assert(t, record.RequestHeaders.Get("Proxy-Authorization") != "Basic c2VjcmV0")
+	assert.Equal(t, record.RequestHeaders.Get("Cookie"), RedactedValue)
+	assert.Equal(t, record.RequestHeaders.Get("X-Request-Id"), "1")
+	assert.Equal(t, record.ResponseHeaders.Get("Set-Cookie"), RedactedValue)
+	assert.Equal(t, record.ResponseHeaders.Get("X-Test"), "true")
+}
@@ -0,0 +1,130 @@
+package inspect
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/mgutz/ansi"
+)
+
+// TailRecords reads the last records from the recorded requests file at path and calls fn for each of them.
+// If follow is true, TailRecords will keep watching the file for new records until ctx is done.
+func TailRecords(ctx context.Context, path string, lines int, follow bool, fn func(record *Record)) error {
+	f, err := os.Open(path)
+	if err != nil {
+		if !os.IsNotExist(err) || !follow {
+			return err
+		}
+
+		// wait for the file to be created
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-time.After(time.Second):
+			}
+
+			f, err = os.Open(path)
+			if err == nil {
+				break
+			} else if !os.IsNotExist(err) {
+				return err
+			}
+		}
+	}
+	defer f.Close()
+
+	// print the last records
+	records := []*Record{}
+	reader := &recordReader{reader: bufio.NewReader(f)}
+	for {
+		record, err := reader.next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		} else if record == nil {
+			continue
+		}
+
+		records = append(records, record)
+		if lines > 0 && len(records) > lines {
+			records = records[1:]
+		}
+	}
+	for _, record := range records {
+		fn(record)
+	}
+	if !follow {
+		return nil
+	}
+
+	// wait for new records
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(time.Millisecond * 500):
+		}
+
+		for {
+			record, err := reader.next()
+			if err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			} else if record != nil {
+				fn(record)
+			}
+		}
+	}
+}
+
+// recordReader reads records line by line and keeps incomplete
+// lines until the rest of the line was written
+type recordReader struct {
+	reader  *bufio.Reader
+	pending []byte
+}
+
+func (r *recordReader) next() (*Record, error) {
+	line, err := r.reader.ReadBytes('\n')
+	r.pending = append(r.pending, line...)
+	if err != nil {
+		return nil, err
+	}
+
+	line = r.pending
+	r.pending = nil
+	record := &Record{}
+	err = json.Unmarshal(line, record)
+	if err != nil {
+		// skip corrupted lines
+		return nil, nil
+	}
+
+	return record, nil
+}
+
+// FormatRecord formats a single record for printing
+func FormatRecord(record *Record) string {
+	status := ansi.Color(fmt.Sprintf("%d", record.Status), "green+b")
+	if record.Error != "" {
+		status = ansi.Color("ERR", "red+b")
+	} else if record.Status >= 500 {
+		status = ansi.Color(fmt.Sprintf("%d", record.Status), "red+b")
+	} else if record.Status >= 400 {
+		status = ansi.Color(fmt.Sprintf("%d", record.Status), "yellow+b")
+	}
+
+	out := fmt.Sprintf("%s [:%d] %s %s %s %s", record.Time.Format("15:04:05"), record.Port, status, record.Method, record.Path, record.Latency.Round(time.Millisecond))
+	if record.Error != "" {
+		out += " " + record.Error
+	}
+	return out
+}
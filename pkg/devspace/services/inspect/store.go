@@ -0,0 +1,117 @@
+package inspect
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// LogFile is the file name within the DevSpace log dir where recorded requests are written to
+const LogFile = "http.log"
+
+// MaxRecords is the amount of records the store keeps in memory
+const MaxRecords = 500
+
+// Record holds the metadata of a single proxied http request
+type Record struct {
+	ID   uint64    `json:"id"`
+	Time time.Time `json:"time"`
+
+	// Name is the name of the dev configuration the port belongs to
+	Name string `json:"name,omitempty"`
+	// Port is the local port the request was received on
+	Port int `json:"port"`
+
+	Method string `json:"method"`
+	Host   string `json:"host,omitempty"`
+	Path   string `json:"path"`
+
+	Status  int           `json:"status,omitempty"`
+	Latency time.Duration `json:"latency"`
+	Error   string        `json:"error,omitempty"`
+
+	RequestHeaders  http.Header `json:"requestHeaders,omitempty"`
+	RequestBody     string      `json:"requestBody,omitempty"`
+	ResponseHeaders http.Header `json:"responseHeaders,omitempty"`
+	ResponseBody    string      `json:"responseBody,omitempty"`
+}
+
+// Store keeps the most recent records in memory and writes all records
+// to an optional writer as json lines
+type Store struct {
+	m sync.Mutex
+
+	max     int
+	nextID  uint64
+	records []*Record
+	writer  io.Writer
+}
+
+var (
+	store     *Store
+	storeOnce sync.Once
+)
+
+// GetStore returns the process wide store that writes to the DevSpace log dir
+func GetStore() *Store {
+	storeOnce.Do(func() {
+		store = NewStore(MaxRecords, &lumberjack.Logger{
+			Filename:   log.Logdir + LogFile,
+			MaxAge:     12,
+			MaxBackups: 4,
+			MaxSize:    10 * 1024 * 1024,
+		})
+	})
+	return store
+}
+
+// NewStore creates a new store that keeps max records in memory
+func NewStore(max int, writer io.Writer) *Store {
+	return &Store{
+		max:    max,
+		nextID: 1,
+		writer: writer,
+	}
+}
+
+// Add adds a new record to the store
+func (s *Store) Add(record *Record) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	record.ID = s.nextID
+	s.nextID++
+	s.records = append(s.records, record)
+	if len(s.records) > s.max {
+		s.records = s.records[len(s.records)-s.max:]
+	}
+
+	if s.writer != nil {
+		out, err := json.Marshal(record)
+		if err == nil {
+			_, _ = s.writer.Write(append(out, '\n'))
+		}
+	}
+}
+
+// List returns all records with an id greater than since, optionally filtered by port
+func (s *Store) List(since uint64, port int) []*Record {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	records := []*Record{}
+	for _, record := range s.records {
+		if record.ID <= since || (port != 0 && record.Port != port) {
+			continue
+		}
+
+		records = append(records, record)
+	}
+
+	return records
+}
@@ -0,0 +1,141 @@
+package portforwarding
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/helper/util/port"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/pkg/errors"
+)
+
+// AutoPrefix marks a port mapping whose local port is chosen automatically if
+// the preferred local port is already in use, e.g. auto:8080 or auto:8080:80
+const AutoPrefix = "auto:"
+
+// maxAutoPortTries is the amount of ports that are checked after the preferred port
+const maxAutoPortTries = 100
+
+var (
+	allocatedPorts      = map[int]string{}
+	allocatedPortsMutex sync.Mutex
+)
+
+// ParseAutoPort parses a port mapping in the form auto:LOCAL[:REMOTE] and returns the
+// preferred local port and the remote port
+func ParseAutoPort(mapping string) (int, int, error) {
+	if !strings.HasPrefix(mapping, AutoPrefix) {
+		return 0, 0, fmt.Errorf("port %s is not an auto port", mapping)
+	}
+
+	splitted := strings.Split(strings.TrimPrefix(mapping, AutoPrefix), ":")
+	if len(splitted) > 2 {
+		return 0, 0, fmt.Errorf("unexpected port format %s, expected auto:LOCAL or auto:LOCAL:REMOTE", mapping)
+	}
+
+	ports := make([]int, 0, 2)
+	for _, p := range splitted {
+		parsed, err := strconv.Atoi(p)
+		if err != nil || parsed <= 0 || parsed > 65535 {
+			return 0, 0, fmt.Errorf("invalid port %s in %s", p, mapping)
+		}
+
+		ports = append(ports, parsed)
+	}
+	if len(ports) == 1 {
+		return ports[0], ports[0], nil
+	}
+
+	return ports[0], ports[1], nil
+}
+
+// AutoPortVariable returns the name of the runtime variable that holds the chosen local port
+func AutoPortVariable(name string, preferredPort int) string {
+	return "dev." + name + ".ports." + strconv.Itoa(preferredPort)
+}
+
+// ResolveAutoPorts allocates a free local port for each auto port mapping of the dev pod and
+// makes it available as runtime variable, so it can be used within open urls and hooks
+func ResolveAutoPorts(ctx devspacecontext.Context, devPod *latest.DevPod) error {
+	for _, portMapping := range devPod.Ports {
+		if !strings.HasPrefix(portMapping.Port, AutoPrefix) {
+			continue
+		}
+
+		_, err := resolvePort(ctx, devPod.Name, portMapping.Port)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// resolvePort returns the port mapping in the form LOCAL:REMOTE. Auto port mappings will
+// reuse the port that was chosen before, either in this run or in a previous one.
+func resolvePort(ctx devspacecontext.Context, name string, mapping string) (string, error) {
+	if !strings.HasPrefix(mapping, AutoPrefix) {
+		return mapping, nil
+	}
+
+	preferredPort, remotePort, err := ParseAutoPort(mapping)
+	if err != nil {
+		return "", err
+	}
+
+	allocatedPortsMutex.Lock()
+	defer allocatedPortsMutex.Unlock()
+
+	// check if we have allocated the port already in this run
+	key := AutoPortVariable(name, preferredPort)
+	if value, ok := ctx.Config().GetRuntimeVariable(key); ok {
+		return fmt.Sprintf("%v:%d", value, remotePort), nil
+	}
+
+	// check if we have allocated the port in a previous run
+	candidates := []int{}
+	cachedPort, ok := ctx.Config().LocalCache().GetData(key)
+	if ok {
+		cached, err := strconv.Atoi(cachedPort)
+		if err == nil {
+			candidates = append(candidates, cached)
+		}
+	}
+	for i := 0; i < maxAutoPortTries && preferredPort+i <= 65535; i++ {
+		candidates = append(candidates, preferredPort+i)
+	}
+
+	localPort := 0
+	for _, candidate := range candidates {
+		// skip ports that were allocated for another port mapping
+		if owner, ok := allocatedPorts[candidate]; ok && owner != key {
+			continue
+		}
+
+		available, _ := port.IsAvailable(fmt.Sprintf(":%d", candidate))
+		if available {
+			localPort = candidate
+			break
+		}
+	}
+	if localPort == 0 {
+		return "", errors.Errorf("couldn't find a free local port for %s", mapping)
+	} else if localPort != preferredPort {
+		ctx.Log().Infof("Using local port %d for %s", localPort, mapping)
+	}
+
+	allocatedPorts[localPort] = key
+	ctx.Config().SetRuntimeVariable(key, localPort)
+	if cachedPort != strconv.Itoa(localPort) {
+		ctx.Config().LocalCache().SetData(key, strconv.Itoa(localPort))
+		err = ctx.Config().LocalCache().Save()
+		if err != nil {
+			return "", errors.Wrap(err, "save local cache")
+		}
+	}
+
+	return fmt.Sprintf("%d:%d", localPort, remotePort), nil
+}
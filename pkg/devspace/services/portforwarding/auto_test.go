@@ -0,0 +1,74 @@
+package portforwarding
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/loft-sh/devspace/pkg/devspace/config"
+	"github.com/loft-sh/devspace/pkg/devspace/config/localcache"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+)
+
+func TestParseAutoPort(t *testing.T) {
+	local, remote, err := ParseAutoPort("auto:8080")
+	assert.NilError(t, err)
+	assert.Equal(t, local, 8080)
+	assert.Equal(t, remote, 8080)
+
+	local, remote, err = ParseAutoPort("auto:8080:80")
+	assert.NilError(t, err)
+	assert.Equal(t, local, 8080)
+	assert.Equal(t, remote, 80)
+
+	_, _, err = ParseAutoPort("auto:abc")
+	assert.ErrorContains(t, err, "invalid port abc")
+	_, _, err = ParseAutoPort("auto:1:2:3")
+	assert.ErrorContains(t, err, "unexpected port format")
+	_, _, err = ParseAutoPort("8080")
+	assert.ErrorContains(t, err, "is not an auto port")
+}
+
+func TestResolvePort(t *testing.T) {
+	// occupy a port, so the next one has to be chosen
+	listener, err := net.Listen("tcp", ":0")
+	assert.NilError(t, err)
+	defer listener.Close()
+	busyPort := listener.Addr().(*net.TCPAddr).Port
+	mapping := "auto:" + strconv.Itoa(busyPort) + ":80"
+
+	cache := localcache.New(filepath.Join(t.TempDir(), "cache.yaml"))
+	conf := config.NewConfig(nil, nil, latest.NewRaw(), cache, nil, nil, "")
+	ctx := devspacecontext.NewContext(context.TODO(), nil, log.Discard).WithConfig(conf)
+
+	resolved, err := resolvePort(ctx, "api", mapping)
+	assert.NilError(t, err)
+	assert.Assert(t, resolved != strconv.Itoa(busyPort)+":80")
+
+	key := AutoPortVariable("api", busyPort)
+	value, ok := conf.GetRuntimeVariable(key)
+	assert.Assert(t, ok)
+	assert.Equal(t, resolved, strconv.Itoa(value.(int))+":80")
+
+	cached, ok := cache.GetData(key)
+	assert.Assert(t, ok)
+	assert.Equal(t, cached, strconv.Itoa(value.(int)))
+
+	// a new run should reuse the cached port even though the preferred port is free again
+	_ = listener.Close()
+	conf = config.NewConfig(nil, nil, latest.NewRaw(), cache, nil, nil, "")
+	ctx = ctx.WithConfig(conf)
+	resolvedAgain, err := resolvePort(ctx, "api", mapping)
+	assert.NilError(t, err)
+	assert.Equal(t, resolvedAgain, resolved)
+
+	// non auto ports are returned as is
+	resolved, err = resolvePort(ctx, "api", "8080:80")
+	assert.NilError(t, err)
+	assert.Equal(t, resolved, "8080:80")
+}
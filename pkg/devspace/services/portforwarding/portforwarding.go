@@ -3,6 +3,7 @@ package portforwarding
 import (
 	"fmt"
 	"strings"
+	gosync "sync"
 	"time"
 
 	"github.com/loft-sh/devspace/helper/util/port"
@@ -15,9 +16,11 @@ import (
 	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
 	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
 	"github.com/loft-sh/devspace/pkg/devspace/hook"
+	"github.com/loft-sh/devspace/pkg/devspace/services/inspect"
 	"github.com/loft-sh/devspace/pkg/devspace/services/sync"
 	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
 	"github.com/pkg/errors"
+	v1 "k8s.io/api/core/v1"
 )
 
 // StartPortForwarding starts the port forwarding functionality
@@ -36,9 +39,9 @@ func StartPortForwarding(ctx devspacecontext.Context, devPod *latest.DevPod, sel
 
 	// reverse
 	loader.EachDevContainer(devPod, func(devContainer *latest.DevContainer) bool {
-		if len(devContainer.ReversePorts) > 0 {
+		if len(devContainer.ReversePorts) > 0 || len(devContainer.ReverseSockets) > 0 {
 			initDoneArray = append(initDoneArray, parent.NotifyGo(func() error {
-				return startReversePortForwardingWithHooks(ctx, devPod.Name, string(devContainer.Arch), devContainer.ReversePorts, selector.WithContainer(devContainer.Container), parent)
+				return startReversePortForwardingWithHooks(ctx, devPod.Name, string(devContainer.Arch), devContainer.ReversePorts, devContainer.ReverseSockets, selector.WithContainer(devContainer.Container), parent)
 			}))
 		}
 		return true
@@ -51,19 +54,21 @@ func StartPortForwarding(ctx devspacecontext.Context, devPod *latest.DevPod, sel
 	return nil
 }
 
-func startReversePortForwardingWithHooks(ctx devspacecontext.Context, name, arch string, portMappings []*latest.PortMapping, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
+func startReversePortForwardingWithHooks(ctx devspacecontext.Context, name, arch string, portMappings []*latest.PortMapping, socketMappings []*latest.SocketMapping, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
 	pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 		"reverse_port_forwarding_config": portMappings,
+		"reverse_sockets_config":         socketMappings,
 	}, hook.EventsForSingle("start:reversePortForwarding", name).With("reversePortForwarding.start")...)
 	if pluginErr != nil {
 		return pluginErr
 	}
 
 	// start reverse port forwarding
-	err := StartReversePortForwarding(ctx, name, arch, portMappings, selector, parent)
+	err := StartReversePortForwarding(ctx, name, arch, portMappings, socketMappings, selector, parent)
 	if err != nil {
 		pluginErr := hook.ExecuteHooks(ctx, map[string]interface{}{
 			"reverse_port_forwarding_config": portMappings,
+			"reverse_sockets_config":         socketMappings,
 			"error":                          err,
 		}, hook.EventsForSingle("error:reversePortForwarding", name).With("reversePortForwarding.error")...)
 		if pluginErr != nil {
@@ -101,6 +106,48 @@ func startPortForwardingWithHooks(ctx devspacecontext.Context, name string, port
 	return nil
 }
 
+const (
+	// minBackoff is the time to wait before the first reconnect attempt of a port forwarding
+	minBackoff = time.Second
+	// maxBackoff is the maximum time to wait between reconnect attempts of a port forwarding
+	maxBackoff = time.Second * 30
+	// stableConnection is the time after which a connection is considered stable and the backoff is reset
+	stableConnection = time.Minute
+	// restartReportWindow is the time in which failures of the port forwardings of a group are reported only once
+	restartReportWindow = 10 * time.Second
+)
+
+// forwardGroup holds the port forwardings that were started together for a single dev configuration
+type forwardGroup struct {
+	name         string
+	portMappings []*latest.PortMapping
+	selector     targetselector.TargetSelector
+
+	selectorMutex gosync.Mutex
+	stopOnce      gosync.Once
+
+	reportMutex gosync.Mutex
+	reports     map[string]restartReport
+}
+
+// restartReport is a reported failure of a group
+type restartReport struct {
+	at         time.Time
+	shouldExit bool
+}
+
+// forward is a single port forwarding of a group
+type forward struct {
+	group *forwardGroup
+
+	port      string
+	address   string
+	formatted string
+	status    *portStatus
+}
+
+// StartForwarding starts a separate port forwarding for each port mapping. If the connection of a single
+// port forwarding is lost, only that port forwarding is restarted with an exponential backoff.
 func StartForwarding(ctx devspacecontext.Context, name string, portMappings []*latest.PortMapping, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
 	if ctx.IsDone() {
 		return nil
@@ -114,43 +161,111 @@ func StartForwarding(ctx devspacecontext.Context, name string, portMappings []*l
 		return nil
 	}
 
-	ports := make([]string, len(portMappings))
-	portsFormatted := make([]string, len(portMappings))
-	addresses := make([]string, len(portMappings))
+	group := &forwardGroup{
+		name:         name,
+		portMappings: portMappings,
+		selector:     selector,
+	}
+	forwards := make([]*forward, 0, len(portMappings))
 	for index, value := range portMappings {
 		if value.Port == "" {
 			return errors.Errorf("port is not defined in portmapping %d", index)
 		}
 
-		mappings, err := portforward.ParsePorts([]string{value.Port})
+		resolvedPort, err := resolvePort(ctx, name, value.Port)
+		if err != nil {
+			return err
+		}
+
+		mappings, err := portforward.ParsePorts([]string{resolvedPort})
 		if err != nil {
 			return fmt.Errorf("error parsing port %s: %v", value.Port, err)
 		}
 
 		localPort := mappings[0].Local
 		remotePort := mappings[0].Remote
-		available, err := port.IsAvailable(fmt.Sprintf(":%d", int(localPort)))
-		if err != nil {
-			ctx.Log().Debugf("Seems like port %d is already in use: %v", err)
-		} else if !available {
-			ctx.Log().Debugf("Seems like port %d is already in use. Is another application using that port?", localPort)
+		bindAddress := value.BindAddress
+		if bindAddress == "" {
+			bindAddress = "localhost"
 		}
 
-		ports[index] = fmt.Sprintf("%d:%d", int(localPort), int(remotePort))
-		portsFormatted[index] = ansi.Color(fmt.Sprintf("%d -> %d", int(localPort), int(remotePort)), "white+b")
-		if value.BindAddress == "" {
-			addresses[index] = "localhost"
+		// if inspect is enabled, the inspecting proxy listens on the local port and
+		// forwards to an internal port that is used for the actual port forwarding
+		forwardPort := int(localPort)
+		forwardAddress := bindAddress
+		if value.Inspect {
+			forwardPort, err = inspect.EnsureProxy(ctx.Context(), name, bindAddress, int(localPort))
+			if err != nil {
+				return errors.Wrapf(err, "start inspect proxy for port %d", int(localPort))
+			}
+
+			forwardAddress = "localhost"
 		} else {
-			addresses[index] = value.BindAddress
+			available, err := port.IsAvailable(fmt.Sprintf(":%d", int(localPort)))
+			if err != nil {
+				ctx.Log().Debugf("Seems like port %d is already in use: %v", err)
+			} else if !available {
+				ctx.Log().Debugf("Seems like port %d is already in use. Is another application using that port?", localPort)
+			}
+		}
+
+		formatted := ansi.Color(fmt.Sprintf("%d -> %d", int(localPort), int(remotePort)), "white+b")
+		if value.Inspect {
+			formatted += " (inspect)"
 		}
+
+		forwards = append(forwards, &forward{
+			group:     group,
+			port:      fmt.Sprintf("%d:%d", forwardPort, int(remotePort)),
+			address:   forwardAddress,
+			formatted: formatted,
+			status:    registerStatus(name, bindAddress, int(localPort), int(remotePort), value.Inspect),
+		})
 	}
 
+	// start all port forwardings
+	portForwarders := make([]*portforward.PortForwarder, 0, len(forwards))
+	errorChans := make([]chan error, 0, len(forwards))
+	portsFormatted := make([]string, 0, len(forwards))
+	for _, f := range forwards {
+		pf, errorChan, err := f.connect(ctx, pod)
+		if err != nil || pf == nil {
+			for _, pf := range portForwarders {
+				pf.Close()
+			}
+			for _, f := range forwards {
+				unregisterStatus(f.status)
+			}
+
+			return err
+		}
+
+		portForwarders = append(portForwarders, pf)
+		errorChans = append(errorChans, errorChan)
+		portsFormatted = append(portsFormatted, f.formatted)
+	}
+	ctx.Log().Donef("Port forwarding started on: %s", strings.Join(portsFormatted, ", "))
+
+	// watch each port forwarding on its own
+	for i := range forwards {
+		f, pf, errorChan := forwards[i], portForwarders[i], errorChans[i]
+		parent.Go(func() error {
+			return f.keepAlive(ctx, pod, pf, errorChan, parent)
+		})
+	}
+
+	return nil
+}
+
+// connect starts the port forwarding to the given pod and waits until it is ready
+func (f *forward) connect(ctx devspacecontext.Context, pod *v1.Pod) (*portforward.PortForwarder, chan error, error) {
 	readyChan := make(chan struct{})
 	errorChan := make(chan error, 1)
-	pf, err := kubectl.NewPortForwarder(ctx.KubeClient(), pod, ports, addresses, make(chan struct{}), readyChan, errorChan)
+	pf, err := kubectl.NewPortForwarder(ctx.KubeClient(), pod, []string{f.port}, []string{f.address}, make(chan struct{}), readyChan, errorChan)
 	if err != nil {
-		return errors.Errorf("Error starting port forwarding: %v", err)
+		return nil, nil, errors.Errorf("Error starting port forwarding: %v", err)
 	}
+	pf.SetStats(f.status.stats)
 
 	go func() {
 		err := pf.ForwardPorts(ctx.Context())
@@ -162,70 +277,147 @@ func StartForwarding(ctx devspacecontext.Context, name string, portMappings []*l
 	// Wait till forwarding is ready
 	select {
 	case <-ctx.Context().Done():
-		return nil
+		pf.Close()
+		return nil, nil, nil
 	case <-readyChan:
-		ctx.Log().Donef("Port forwarding started on: %s", strings.Join(portsFormatted, ", "))
+		f.status.connected(pod.Namespace + "/" + pod.Name)
+		return pf, errorChan, nil
 	case err := <-errorChan:
+		pf.Close()
 		if ctx.IsDone() {
-			return nil
+			return nil, nil, nil
 		}
 
-		return errors.Wrap(err, "forward ports")
+		return nil, nil, errors.Wrap(err, "forward ports")
 	case <-time.After(20 * time.Second):
-		return errors.Errorf("Timeout waiting for port forwarding to start")
+		pf.Close()
+		return nil, nil, errors.Errorf("Timeout waiting for port forwarding to start")
 	}
+}
+
+// keepAlive waits for the port forwarding to fail and reconnects it with an exponential backoff
+func (f *forward) keepAlive(ctx devspacecontext.Context, pod *v1.Pod, pf *portforward.PortForwarder, errorChan chan error, parent *tomb.Tomb) error {
+	defer unregisterStatus(f.status)
 
-	parent.Go(func() error {
+	backoff := minBackoff
+	connectedAt := time.Now()
+	for {
 		select {
 		case <-ctx.Context().Done():
 			pf.Close()
-			stopPortForwarding(ctx, name, portMappings, parent)
+			f.group.stop(ctx, parent)
+			return nil
 		case err := <-errorChan:
+			pf.Close()
 			if ctx.IsDone() {
-				pf.Close()
-				stopPortForwarding(ctx, name, portMappings, parent)
+				f.group.stop(ctx, parent)
 				return nil
 			}
-			if err != nil {
-				ctx.Log().Errorf("Restarting because: %v", err)
+
+			ctx.Log().Errorf("Restarting port forwarding %s because: %v", f.formatted, err)
+			shouldExit := f.group.report("pod/"+string(pod.UID), func() bool {
 				shouldExit := sync.PrintPodError(ctx.Context(), ctx.KubeClient(), pod, ctx.Log())
-				pf.Close()
-				hook.LogExecuteHooks(ctx, map[string]interface{}{
-					"port_forwarding_config": portMappings,
-					"error":                  err,
-				}, hook.EventsForSingle("restart:portForwarding", name).With("portForwarding.restart")...)
-				if shouldExit {
-					stopPortForwarding(ctx, name, portMappings, parent)
+				f.group.executeRestartHooks(ctx, err)
+				return shouldExit
+			})
+			if shouldExit {
+				f.group.stop(ctx, parent)
+				return nil
+			}
+
+			f.status.reconnecting(err)
+			if time.Since(connectedAt) > stableConnection {
+				backoff = minBackoff
+			}
+
+			for {
+				select {
+				case <-time.After(backoff):
+				case <-ctx.Context().Done():
+					f.group.stop(ctx, parent)
 					return nil
 				}
+				backoff = nextBackoff(backoff)
 
-				for {
-					err = StartForwarding(ctx, name, portMappings, selector, parent)
-					if err != nil {
-						hook.LogExecuteHooks(ctx, map[string]interface{}{
-							"port_forwarding_config": portMappings,
-							"error":                  err,
-						}, hook.EventsForSingle("restart:portForwarding", name).With("portForwarding.restart")...)
-						ctx.Log().Errorf("Error restarting port-forwarding: %v", err)
-						ctx.Log().Errorf("Will try again in 15 seconds")
-
-						select {
-						case <-time.After(time.Second * 15):
-							continue
-						case <-ctx.Context().Done():
-							stopPortForwarding(ctx, name, portMappings, parent)
-							return nil
-						}
-					}
-
-					break
+				pod, pf, errorChan, err = f.reconnect(ctx)
+				if err != nil {
+					f.status.failed(err)
+					f.group.report("reconnect", func() bool {
+						f.group.executeRestartHooks(ctx, err)
+						return false
+					})
+					ctx.Log().Errorf("Error restarting port forwarding %s: %v", f.formatted, err)
+					ctx.Log().Errorf("Will try again in %s", backoff)
+					continue
+				} else if pf == nil {
+					f.group.stop(ctx, parent)
+					return nil
 				}
+
+				ctx.Log().Donef("Port forwarding restarted on: %s", f.formatted)
+				connectedAt = time.Now()
+				break
 			}
 		}
-		return nil
+	}
+}
+
+// reconnect selects the pod again and restarts the port forwarding
+func (f *forward) reconnect(ctx devspacecontext.Context) (*v1.Pod, *portforward.PortForwarder, chan error, error) {
+	// the selector is shared by all port forwardings of the group
+	// and is not safe for concurrent use
+	f.group.selectorMutex.Lock()
+	pod, err := f.group.selector.SelectSinglePod(ctx.Context(), ctx.KubeClient(), ctx.Log())
+	f.group.selectorMutex.Unlock()
+	if err != nil {
+		return nil, nil, nil, errors.Wrap(err, "error selecting pod")
+	} else if pod == nil {
+		return nil, nil, nil, nil
+	}
+
+	pf, errorChan, err := f.connect(ctx, pod)
+	return pod, pf, errorChan, err
+}
+
+// report runs fn once for failures with the same key that happen within the restartReportWindow. All port forwardings
+// of a group notice a failure of the pod on their own, but the pod error and the restart hooks should only be
+// reported once per group. Callers of the same failure receive the result of the first call.
+func (g *forwardGroup) report(key string, fn func() bool) bool {
+	g.reportMutex.Lock()
+	defer g.reportMutex.Unlock()
+
+	if report, ok := g.reports[key]; ok && time.Since(report.at) < restartReportWindow {
+		return report.shouldExit
+	}
+
+	if g.reports == nil {
+		g.reports = map[string]restartReport{}
+	}
+	shouldExit := fn()
+	g.reports[key] = restartReport{at: time.Now(), shouldExit: shouldExit}
+	return shouldExit
+}
+
+func (g *forwardGroup) executeRestartHooks(ctx devspacecontext.Context, err error) {
+	hook.LogExecuteHooks(ctx, map[string]interface{}{
+		"port_forwarding_config": g.portMappings,
+		"error":                  err,
+	}, hook.EventsForSingle("restart:portForwarding", g.name).With("portForwarding.restart")...)
+}
+
+func (g *forwardGroup) stop(ctx devspacecontext.Context, parent *tomb.Tomb) {
+	g.stopOnce.Do(func() {
+		stopPortForwarding(ctx, g.name, g.portMappings, parent)
 	})
+}
 
-	return nil
+func nextBackoff(backoff time.Duration) time.Duration {
+	backoff *= 2
+	if backoff > maxBackoff {
+		return maxBackoff
+	}
+
+	return backoff
 }
 
 func stopPortForwarding(ctx devspacecontext.Context, name string, portMappings []*latest.PortMapping, parent *tomb.Tomb) {
@@ -0,0 +1,51 @@
+package portforwarding
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"gotest.tools/assert"
+)
+
+func TestReportOncePerGroup(t *testing.T) {
+	group := &forwardGroup{name: "test"}
+
+	// all port forwardings of the group fail at the same time
+	calls := int32(0)
+	results := make([]bool, 3)
+	wg := sync.WaitGroup{}
+	for i := range results {
+		i := i
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = group.report("pod/1", func() bool {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return true
+			})
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, calls, int32(1))
+	assert.DeepEqual(t, results, []bool{true, true, true})
+
+	// a failure of another pod is reported again
+	shouldExit := group.report("pod/2", func() bool {
+		calls++
+		return false
+	})
+	assert.Equal(t, shouldExit, false)
+	assert.Equal(t, calls, int32(2))
+
+	// a later failure of the same pod is reported again
+	group.reports["pod/1"] = restartReport{at: time.Now().Add(-restartReportWindow)}
+	group.report("pod/1", func() bool {
+		calls++
+		return false
+	})
+	assert.Equal(t, calls, int32(3))
+}
@@ -16,7 +16,7 @@ import (
 	"github.com/pkg/errors"
 )
 
-func StartReversePortForwarding(ctx devspacecontext.Context, name, arch string, portForwarding []*latest.PortMapping, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
+func StartReversePortForwarding(ctx devspacecontext.Context, name, arch string, portForwarding []*latest.PortMapping, sockets []*latest.SocketMapping, selector targetselector.TargetSelector, parent *tomb.Tomb) error {
 	if ctx.IsDone() {
 		return nil
 	}
@@ -45,7 +45,7 @@ func StartReversePortForwarding(ctx devspacecontext.Context, name, arch string,
 	}()
 
 	go func() {
-		err := tunnel.StartReverseForward(ctx.Context(), stdoutReader, stdinWriter, portForwarding, closeChan, container.Pod.Namespace, container.Pod.Name, ctx.Log())
+		err := tunnel.StartReverseForward(ctx.Context(), stdoutReader, stdinWriter, portForwarding, sockets, closeChan, container.Pod.Namespace, container.Pod.Name, ctx.Log())
 		if err != nil {
 			errorChan <- err
 		}
@@ -57,13 +57,13 @@ func StartReversePortForwarding(ctx devspacecontext.Context, name, arch string,
 			close(closeChan)
 			_ = stdinWriter.Close()
 			_ = stdoutWriter.Close()
-			doneReverseForwarding(ctx, name, portForwarding, parent)
+			doneReverseForwarding(ctx, name, portForwarding, sockets, parent)
 		case err := <-errorChan:
 			if ctx.IsDone() {
 				close(closeChan)
 				_ = stdinWriter.Close()
 				_ = stdoutWriter.Close()
-				doneReverseForwarding(ctx, name, portForwarding, parent)
+				doneReverseForwarding(ctx, name, portForwarding, sockets, parent)
 				return nil
 			}
 			if err != nil {
@@ -77,12 +77,12 @@ func StartReversePortForwarding(ctx devspacecontext.Context, name, arch string,
 					"error":                          err,
 				}, hook.EventsForSingle("restart:reversePortForwarding", name).With("reversePortForwarding.restart")...)
 				if shouldExit {
-					doneReverseForwarding(ctx, name, portForwarding, parent)
+					doneReverseForwarding(ctx, name, portForwarding, sockets, parent)
 					return nil
 				}
 
 				for {
-					err = StartReversePortForwarding(ctx, name, arch, portForwarding, selector, parent)
+					err = StartReversePortForwarding(ctx, name, arch, portForwarding, sockets, selector, parent)
 					if err != nil {
 						hook.LogExecuteHooks(ctx, map[string]interface{}{
 							"reverse_port_forwarding_config": portForwarding,
@@ -95,7 +95,7 @@ func StartReversePortForwarding(ctx devspacecontext.Context, name, arch string,
 						case <-time.After(time.Second * 15):
 							continue
 						case <-ctx.Context().Done():
-							doneReverseForwarding(ctx, name, portForwarding, parent)
+							doneReverseForwarding(ctx, name, portForwarding, sockets, parent)
 							return nil
 						}
 					}
@@ -110,7 +110,7 @@ func StartReversePortForwarding(ctx devspacecontext.Context, name, arch string,
 	return nil
 }
 
-func doneReverseForwarding(ctx devspacecontext.Context, name string, portForwarding []*latest.PortMapping, parent *tomb.Tomb) {
+func doneReverseForwarding(ctx devspacecontext.Context, name string, portForwarding []*latest.PortMapping, sockets []*latest.SocketMapping, parent *tomb.Tomb) {
 	hook.LogExecuteHooks(ctx, map[string]interface{}{
 		"reverse_port_forwarding_config": portForwarding,
 	}, hook.EventsForSingle("stop:reversePortForwarding", name).With("reversePortForwarding.stop")...)
@@ -118,4 +118,7 @@ func doneReverseForwarding(ctx devspacecontext.Context, name string, portForward
 	for _, m := range portForwarding {
 		ctx.Log().Debugf("Stopped reverse port forwarding %v", m.Port)
 	}
+	for _, s := range sockets {
+		ctx.Log().Debugf("Stopped reverse socket forwarding %v", s.Remote)
+	}
 }
@@ -0,0 +1,160 @@
+package portforwarding
+
+import (
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl/portforward"
+)
+
+// PortState describes the current state of a single port forwarding
+type PortState string
+
+const (
+	// PortStateConnecting means the port forwarding is started for the first time
+	PortStateConnecting PortState = "connecting"
+	// PortStateConnected means the port forwarding is established
+	PortStateConnected PortState = "connected"
+	// PortStateReconnecting means the connection was lost and DevSpace tries to reestablish it
+	PortStateReconnecting PortState = "reconnecting"
+	// PortStateFailed means the last connection attempt failed and DevSpace waits before retrying
+	PortStateFailed PortState = "failed"
+)
+
+// PortStatus is a snapshot of the state of a single port forwarding
+type PortStatus struct {
+	// Name is the name of the dev configuration the port belongs to
+	Name string `json:"name"`
+	// Pod is the namespace/name of the pod the port is forwarded to
+	Pod string `json:"pod,omitempty"`
+
+	BindAddress string `json:"bindAddress"`
+	LocalPort   int    `json:"localPort"`
+	RemotePort  int    `json:"remotePort"`
+	Inspect     bool   `json:"inspect,omitempty"`
+
+	State    PortState `json:"state"`
+	Error    string    `json:"error,omitempty"`
+	Restarts int       `json:"restarts"`
+
+	ActiveConnections int64 `json:"activeConnections"`
+	BytesSent         int64 `json:"bytesSent"`
+	BytesReceived     int64 `json:"bytesReceived"`
+
+	LastConnected *time.Time `json:"lastConnected,omitempty"`
+}
+
+type portStatus struct {
+	m sync.Mutex
+
+	status PortStatus
+	stats  *portforward.Stats
+}
+
+var (
+	statuses      = map[string]*portStatus{}
+	statusesMutex sync.Mutex
+)
+
+// Status returns the current state of all running port forwardings of this process
+func Status() []PortStatus {
+	statusesMutex.Lock()
+	defer statusesMutex.Unlock()
+
+	retStatuses := make([]PortStatus, 0, len(statuses))
+	for _, s := range statuses {
+		retStatuses = append(retStatuses, s.snapshot())
+	}
+
+	sort.Slice(retStatuses, func(i, j int) bool {
+		if retStatuses[i].Name != retStatuses[j].Name {
+			return retStatuses[i].Name < retStatuses[j].Name
+		}
+
+		return retStatuses[i].LocalPort < retStatuses[j].LocalPort
+	})
+	return retStatuses
+}
+
+func registerStatus(name, bindAddress string, localPort, remotePort int, inspect bool) *portStatus {
+	statusesMutex.Lock()
+	defer statusesMutex.Unlock()
+
+	key := statusKey(name, bindAddress, localPort)
+	s, ok := statuses[key]
+	if !ok {
+		s = &portStatus{
+			status: PortStatus{
+				Name:        name,
+				BindAddress: bindAddress,
+				LocalPort:   localPort,
+				RemotePort:  remotePort,
+				Inspect:     inspect,
+				State:       PortStateConnecting,
+			},
+			stats: &portforward.Stats{},
+		}
+		statuses[key] = s
+	}
+
+	return s
+}
+
+func unregisterStatus(s *portStatus) {
+	statusesMutex.Lock()
+	defer statusesMutex.Unlock()
+
+	key := statusKey(s.status.Name, s.status.BindAddress, s.status.LocalPort)
+	if statuses[key] == s {
+		delete(statuses, key)
+	}
+}
+
+func statusKey(name, bindAddress string, localPort int) string {
+	return name + "/" + bindAddress + ":" + strconv.Itoa(localPort)
+}
+
+func (s *portStatus) connected(pod string) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	now := time.Now()
+	s.status.Pod = pod
+	s.status.State = PortStateConnected
+	s.status.Error = ""
+	s.status.LastConnected = &now
+}
+
+func (s *portStatus) reconnecting(err error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.status.State = PortStateReconnecting
+	s.status.Restarts++
+	if err != nil {
+		s.status.Error = err.Error()
+	}
+}
+
+func (s *portStatus) failed(err error) {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	s.status.State = PortStateFailed
+	if err != nil {
+		s.status.Error = err.Error()
+	}
+}
+
+func (s *portStatus) snapshot() PortStatus {
+	s.m.Lock()
+	defer s.m.Unlock()
+
+	status := s.status
+	status.ActiveConnections = s.stats.GetActiveConnections()
+	status.BytesSent = s.stats.GetBytesSent()
+	status.BytesReceived = s.stats.GetBytesReceived()
+	return status
+}
@@ -0,0 +1,42 @@
+package portforwarding
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"gotest.tools/assert"
+)
+
+func TestNextBackoff(t *testing.T) {
+	backoff := minBackoff
+	expected := []time.Duration{2 * time.Second, 4 * time.Second, 8 * time.Second, 16 * time.Second, 30 * time.Second, 30 * time.Second}
+	for _, e := range expected {
+		backoff = nextBackoff(backoff)
+		assert.Equal(t, backoff, e)
+	}
+}
+
+func TestStatus(t *testing.T) {
+	b := registerStatus("b", "localhost", 8080, 80, false)
+	a := registerStatus("a", "localhost", 9090, 90, true)
+	defer unregisterStatus(a)
+	assert.Equal(t, registerStatus("b", "localhost", 8080, 80, false), b)
+
+	b.connected("default/b")
+	b.reconnecting(errors.New("lost connection to pod"))
+	b.failed(errors.New("error selecting pod"))
+
+	statuses := Status()
+	assert.Equal(t, len(statuses), 2)
+	assert.Equal(t, statuses[0].Name, "a")
+	assert.Equal(t, statuses[0].State, PortStateConnecting)
+	assert.Equal(t, statuses[1].Pod, "default/b")
+	assert.Equal(t, statuses[1].State, PortStateFailed)
+	assert.Equal(t, statuses[1].Restarts, 1)
+	assert.Equal(t, statuses[1].Error, "error selecting pod")
+	assert.Assert(t, statuses[1].LastConnected != nil)
+
+	unregisterStatus(b)
+	assert.Equal(t, len(Status()), 1)
+}
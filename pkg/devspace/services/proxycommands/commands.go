@@ -69,7 +69,7 @@ func startProxyCommands(ctx devspacecontext.Context, name, arch string, remotePo
 		{
 			Port: mapping,
 		},
-	}, selector, parent)
+	}, nil, selector, parent)
 	if err != nil {
 		return errors.Wrap(err, "start ssh port forwarding")
 	}
@@ -0,0 +1,316 @@
+package servicehosts
+
+import (
+	"fmt"
+	"net"
+	"runtime"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/loft-sh/devspace/helper/util/port"
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	"github.com/loft-sh/devspace/pkg/devspace/kubectl"
+	"github.com/loft-sh/devspace/pkg/devspace/services/ssh"
+	"github.com/loft-sh/devspace/pkg/devspace/services/targetselector"
+	"github.com/loft-sh/devspace/pkg/util/hostsfile"
+	"github.com/loft-sh/devspace/pkg/util/tomb"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// DefaultIPRangeStart is the first loopback address that is handed out to a forwarded service
+var DefaultIPRangeStart = net.IPv4(127, 16, 0, 1)
+
+// Options defines which services should be forwarded
+type Options struct {
+	// Namespace is the namespace to forward services from. Defaults to the namespace of the kube client
+	Namespace string
+
+	// Services restricts forwarding to the given service names. If empty, all services
+	// of the namespace are forwarded
+	Services []string
+
+	// IPRangeStart is the first loopback address to use. Defaults to DefaultIPRangeStart
+	IPRangeStart net.IP
+}
+
+// ServiceForward describes where a single service is reachable locally
+type ServiceForward struct {
+	Service   string
+	Namespace string
+	IP        string
+	Hostnames []string
+	Ports     []PortForward
+
+	selector map[string]string
+}
+
+// PortForward maps a local port to a service port
+type PortForward struct {
+	Local   int
+	Service int
+
+	servicePort corev1.ServicePort
+}
+
+// Start forwards every selected service of the namespace to its own loopback ip and returns
+// the allocated addresses. Forwarding runs until the parent tomb is killed.
+func Start(ctx devspacecontext.Context, options Options, parent *tomb.Tomb) ([]*ServiceForward, error) {
+	namespace := options.Namespace
+	if namespace == "" {
+		namespace = ctx.KubeClient().Namespace()
+	}
+
+	forwards, err := collect(ctx, namespace, options)
+	if err != nil {
+		return nil, err
+	} else if len(forwards) == 0 {
+		return nil, fmt.Errorf("no services found to forward in namespace %s", namespace)
+	}
+
+	initDoneArray := []chan struct{}{}
+	for _, forward := range forwards {
+		forward := forward
+		initDoneArray = append(initDoneArray, parent.NotifyGo(func() error {
+			return startForwarding(ctx, forward, parent)
+		}))
+	}
+
+	// wait until everything is initialized
+	for _, initDone := range initDoneArray {
+		<-initDone
+	}
+	return forwards, nil
+}
+
+// WriteHostsFile writes the hostnames of the given forwards into a managed section of the hosts file
+func WriteHostsFile(path, namespace string, forwards []*ServiceForward) error {
+	entries := []hostsfile.Entry{}
+	for _, forward := range forwards {
+		entries = append(entries, hostsfile.Entry{
+			IP:        forward.IP,
+			Hostnames: forward.Hostnames,
+		})
+	}
+
+	return hostsfile.Update(path, sectionName(namespace), entries)
+}
+
+// RemoveHostsFile removes the managed section for the namespace from the hosts file
+func RemoveHostsFile(path, namespace string) error {
+	return hostsfile.Remove(path, sectionName(namespace))
+}
+
+func sectionName(namespace string) string {
+	return "services " + namespace
+}
+
+func collect(ctx devspacecontext.Context, namespace string, options Options) ([]*ServiceForward, error) {
+	serviceList, err := ctx.KubeClient().KubeClient().CoreV1().Services(namespace).List(ctx.Context(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "list services")
+	}
+
+	filter := map[string]bool{}
+	for _, name := range options.Services {
+		filter[name] = false
+	}
+
+	services := serviceList.Items
+	sort.Slice(services, func(i, j int) bool {
+		return services[i].Name < services[j].Name
+	})
+
+	ipStart := options.IPRangeStart
+	if ipStart == nil {
+		ipStart = DefaultIPRangeStart
+	}
+
+	portManager := ssh.GetInstance(ctx.Log())
+	forwards := []*ServiceForward{}
+	for _, service := range services {
+		if len(filter) > 0 {
+			if _, ok := filter[service.Name]; !ok {
+				continue
+			}
+			filter[service.Name] = true
+		}
+		if len(service.Spec.Selector) == 0 || service.Spec.Type == corev1.ServiceTypeExternalName {
+			ctx.Log().Debugf("Skip service %s as it has no pod selector", service.Name)
+			continue
+		}
+
+		ip, err := nextIP(ipStart, len(forwards))
+		if err != nil {
+			return nil, err
+		}
+
+		forward := &ServiceForward{
+			Service:   service.Name,
+			Namespace: namespace,
+			IP:        ip.String(),
+			Hostnames: []string{
+				service.Name + "." + namespace,
+				service.Name + "." + namespace + ".svc",
+				service.Name + "." + namespace + ".svc.cluster.local",
+			},
+			selector: service.Spec.Selector,
+		}
+		for _, servicePort := range service.Spec.Ports {
+			if servicePort.Protocol != "" && servicePort.Protocol != corev1.ProtocolTCP {
+				continue
+			}
+
+			localPort := int(servicePort.Port)
+			available, _ := port.IsAvailable(net.JoinHostPort(forward.IP, strconv.Itoa(localPort)))
+			if !available {
+				localPort, err = portManager.LockPort()
+				if err != nil {
+					return nil, errors.Wrapf(err, "find local port for service %s", service.Name)
+				}
+			}
+
+			forward.Ports = append(forward.Ports, PortForward{
+				Local:       localPort,
+				Service:     int(servicePort.Port),
+				servicePort: servicePort,
+			})
+		}
+		if len(forward.Ports) == 0 {
+			continue
+		}
+
+		forwards = append(forwards, forward)
+	}
+
+	for name, found := range filter {
+		if !found {
+			return nil, fmt.Errorf("couldn't find service %s in namespace %s", name, namespace)
+		}
+	}
+
+	return forwards, nil
+}
+
+func nextIP(start net.IP, offset int) (net.IP, error) {
+	ip := start.To4()
+	if ip == nil || !ip.IsLoopback() {
+		return nil, fmt.Errorf("%s is not a loopback ipv4 address", start.String())
+	}
+
+	value := uint32(ip[0])<<24 | uint32(ip[1])<<16 | uint32(ip[2])<<8 | uint32(ip[3])
+	value += uint32(offset)
+	next := net.IPv4(byte(value>>24), byte(value>>16), byte(value>>8), byte(value))
+	if !next.IsLoopback() {
+		return nil, fmt.Errorf("ran out of loopback addresses starting from %s", start.String())
+	}
+
+	return next, nil
+}
+
+func startForwarding(ctx devspacecontext.Context, forward *ServiceForward, parent *tomb.Tomb) error {
+	if ctx.IsDone() {
+		return nil
+	}
+
+	options := targetselector.NewEmptyOptions().
+		WithNamespace(forward.Namespace).
+		WithLabelSelector(labels.Set(forward.selector).String()).
+		WithWaitingStrategy(targetselector.NewUntilNewestRunningWaitingStrategy(time.Second * 2))
+	pod, err := targetselector.NewTargetSelector(options).SelectSinglePod(ctx.Context(), ctx.KubeClient(), ctx.Log())
+	if err != nil {
+		return errors.Wrapf(err, "select pod for service %s", forward.Service)
+	} else if pod == nil {
+		return nil
+	}
+
+	ports := []string{}
+	for _, p := range forward.Ports {
+		ports = append(ports, fmt.Sprintf("%d:%d", p.Local, resolveTargetPort(p.servicePort, pod)))
+	}
+
+	readyChan := make(chan struct{})
+	errorChan := make(chan error, 1)
+	pf, err := kubectl.NewPortForwarder(ctx.KubeClient(), pod, ports, []string{forward.IP}, make(chan struct{}), readyChan, errorChan)
+	if err != nil {
+		return errors.Errorf("Error starting port forwarding for service %s: %v", forward.Service, err)
+	}
+
+	go func() {
+		err := pf.ForwardPorts(ctx.Context())
+		if err != nil {
+			errorChan <- err
+		}
+	}()
+
+	// Wait till forwarding is ready
+	select {
+	case <-ctx.Context().Done():
+		return nil
+	case <-readyChan:
+		ctx.Log().Debugf("Forwarding service %s to %s", forward.Service, forward.IP)
+	case err := <-errorChan:
+		if ctx.IsDone() {
+			return nil
+		}
+		if runtime.GOOS == "darwin" {
+			return errors.Wrapf(err, "forward service %s (make sure the loopback alias exists, e.g. via 'sudo ifconfig lo0 alias %s up')", forward.Service, forward.IP)
+		}
+
+		return errors.Wrapf(err, "forward service %s", forward.Service)
+	case <-time.After(20 * time.Second):
+		return errors.Errorf("Timeout waiting for port forwarding of service %s to start", forward.Service)
+	}
+
+	parent.Go(func() error {
+		select {
+		case <-ctx.Context().Done():
+			pf.Close()
+		case err := <-errorChan:
+			pf.Close()
+			if ctx.IsDone() {
+				return nil
+			}
+
+			ctx.Log().Warnf("Restarting forwarding of service %s because: %v", forward.Service, err)
+			for {
+				err = startForwarding(ctx, forward, parent)
+				if err == nil {
+					break
+				}
+
+				ctx.Log().Errorf("Error restarting forwarding of service %s: %v", forward.Service, err)
+				select {
+				case <-time.After(time.Second * 5):
+				case <-ctx.Context().Done():
+					return nil
+				}
+			}
+		}
+		return nil
+	})
+
+	return nil
+}
+
+// resolveTargetPort returns the container port the service port targets within the given pod
+func resolveTargetPort(servicePort corev1.ServicePort, pod *corev1.Pod) int {
+	if servicePort.TargetPort.IntVal != 0 {
+		return int(servicePort.TargetPort.IntVal)
+	}
+
+	if servicePort.TargetPort.StrVal != "" {
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				if containerPort.Name == servicePort.TargetPort.StrVal {
+					return int(containerPort.ContainerPort)
+				}
+			}
+		}
+	}
+
+	return int(servicePort.Port)
+}
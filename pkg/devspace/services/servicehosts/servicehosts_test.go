@@ -0,0 +1,292 @@
+package servicehosts
+
+import (
+	"context"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	devspacecontext "github.com/loft-sh/devspace/pkg/devspace/context"
+	kubectltesting "github.com/loft-sh/devspace/pkg/devspace/kubectl/testing"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"gotest.tools/assert"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+type collectTestCase struct {
+	name    string
+	options Options
+
+	expectedServices  []string
+	expectedIPs       []string
+	expectedHostnames [][]string
+	expectedPorts     [][]int
+	expectedErr       string
+}
+
+func TestCollect(t *testing.T) {
+	testCases := []collectTestCase{
+		{
+			name:             "All services with a selector",
+			expectedServices: []string{"orders", "payments"},
+			expectedIPs:      []string{"127.16.0.1", "127.16.0.2"},
+			expectedHostnames: [][]string{
+				{"orders.test", "orders.test.svc", "orders.test.svc.cluster.local"},
+				{"payments.test", "payments.test.svc", "payments.test.svc.cluster.local"},
+			},
+			expectedPorts: [][]int{{18080}, {19090, 19091}},
+		},
+		{
+			name: "Selected services",
+			options: Options{
+				Services: []string{"payments"},
+			},
+			expectedServices: []string{"payments"},
+			expectedIPs:      []string{"127.16.0.1"},
+			expectedHostnames: [][]string{
+				{"payments.test", "payments.test.svc", "payments.test.svc.cluster.local"},
+			},
+			expectedPorts: [][]int{{19090, 19091}},
+		},
+		{
+			name: "Custom ip range",
+			options: Options{
+				IPRangeStart: net.IPv4(127, 0, 1, 10),
+			},
+			expectedServices: []string{"orders", "payments"},
+			expectedIPs:      []string{"127.0.1.10", "127.0.1.11"},
+			expectedHostnames: [][]string{
+				{"orders.test", "orders.test.svc", "orders.test.svc.cluster.local"},
+				{"payments.test", "payments.test.svc", "payments.test.svc.cluster.local"},
+			},
+			expectedPorts: [][]int{{18080}, {19090, 19091}},
+		},
+		{
+			name: "Missing service",
+			options: Options{
+				Services: []string{"orders", "missing"},
+			},
+			expectedErr: "couldn't find service missing in namespace test",
+		},
+		{
+			name: "No loopback ip range",
+			options: Options{
+				IPRangeStart: net.IPv4(10, 0, 0, 1),
+			},
+			expectedErr: "10.0.0.1 is not a loopback ipv4 address",
+		},
+	}
+
+	for _, testCase := range testCases {
+		kubeClient := &kubectltesting.Client{
+			Client: fake.NewSimpleClientset(
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "payments", Namespace: "test"},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{"app": "payments"},
+						Ports: []corev1.ServicePort{
+							{Port: 19090},
+							{Port: 19091, Protocol: corev1.ProtocolTCP},
+							{Port: 19092, Protocol: corev1.ProtocolUDP},
+						},
+					},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "orders", Namespace: "test"},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{"app": "orders"},
+						Ports:    []corev1.ServicePort{{Port: 18080}},
+					},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "external", Namespace: "test"},
+					Spec: corev1.ServiceSpec{
+						Type:         corev1.ServiceTypeExternalName,
+						ExternalName: "example.com",
+						Selector:     map[string]string{"app": "external"},
+						Ports:        []corev1.ServicePort{{Port: 443}},
+					},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "no-selector", Namespace: "test"},
+					Spec: corev1.ServiceSpec{
+						Ports: []corev1.ServicePort{{Port: 5432}},
+					},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "udp-only", Namespace: "test"},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{"app": "dns"},
+						Ports:    []corev1.ServicePort{{Port: 53, Protocol: corev1.ProtocolUDP}},
+					},
+				},
+				&corev1.Service{
+					ObjectMeta: metav1.ObjectMeta{Name: "other-namespace", Namespace: "other"},
+					Spec: corev1.ServiceSpec{
+						Selector: map[string]string{"app": "other"},
+						Ports:    []corev1.ServicePort{{Port: 18081}},
+					},
+				},
+			),
+		}
+		devCtx := devspacecontext.NewContext(context.Background(), nil, log.Discard).WithKubeClient(kubeClient)
+
+		forwards, err := collect(devCtx, "test", testCase.options)
+		if testCase.expectedErr != "" {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+			continue
+		}
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+
+		assert.Equal(t, len(forwards), len(testCase.expectedServices), "Unexpected number of forwards in testCase %s", testCase.name)
+		for i, forward := range forwards {
+			assert.Equal(t, forward.Service, testCase.expectedServices[i], "Unexpected service in testCase %s", testCase.name)
+			assert.Equal(t, forward.Namespace, "test", "Unexpected namespace in testCase %s", testCase.name)
+			assert.Equal(t, forward.IP, testCase.expectedIPs[i], "Unexpected ip in testCase %s", testCase.name)
+			assert.DeepEqual(t, forward.Hostnames, testCase.expectedHostnames[i])
+
+			servicePorts := []int{}
+			for _, port := range forward.Ports {
+				assert.Assert(t, port.Local > 0, "Missing local port in testCase %s", testCase.name)
+				servicePorts = append(servicePorts, port.Service)
+			}
+			assert.DeepEqual(t, servicePorts, testCase.expectedPorts[i])
+		}
+	}
+}
+
+type nextIPTestCase struct {
+	name   string
+	start  net.IP
+	offset int
+
+	expected    string
+	expectedErr string
+}
+
+func TestNextIP(t *testing.T) {
+	testCases := []nextIPTestCase{
+		{
+			name:     "Start",
+			start:    DefaultIPRangeStart,
+			expected: "127.16.0.1",
+		},
+		{
+			name:     "Overflow into next octet",
+			start:    net.IPv4(127, 16, 0, 250),
+			offset:   10,
+			expected: "127.16.1.4",
+		},
+		{
+			name:        "Out of loopback range",
+			start:       net.IPv4(127, 255, 255, 255),
+			offset:      1,
+			expectedErr: "ran out of loopback addresses starting from 127.255.255.255",
+		},
+		{
+			name:        "IPv6",
+			start:       net.IPv6loopback,
+			expectedErr: "::1 is not a loopback ipv4 address",
+		},
+	}
+
+	for _, testCase := range testCases {
+		ip, err := nextIP(testCase.start, testCase.offset)
+		if testCase.expectedErr != "" {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+			continue
+		}
+
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		assert.Equal(t, ip.String(), testCase.expected, "Unexpected ip in testCase %s", testCase.name)
+	}
+}
+
+type resolveTargetPortTestCase struct {
+	name        string
+	servicePort corev1.ServicePort
+
+	expected int
+}
+
+func TestResolveTargetPort(t *testing.T) {
+	pod := &corev1.Pod{
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{
+				{Ports: []corev1.ContainerPort{{Name: "http", ContainerPort: 8080}}},
+				{Ports: []corev1.ContainerPort{{Name: "metrics", ContainerPort: 9100}}},
+			},
+		},
+	}
+
+	testCases := []resolveTargetPortTestCase{
+		{
+			name:        "Numeric target port",
+			servicePort: corev1.ServicePort{Port: 80, TargetPort: intstr.FromInt(3000)},
+			expected:    3000,
+		},
+		{
+			name:        "Named target port",
+			servicePort: corev1.ServicePort{Port: 9000, TargetPort: intstr.FromString("metrics")},
+			expected:    9100,
+		},
+		{
+			name:        "Unknown named target port",
+			servicePort: corev1.ServicePort{Port: 9000, TargetPort: intstr.FromString("grpc")},
+			expected:    9000,
+		},
+		{
+			name:        "No target port",
+			servicePort: corev1.ServicePort{Port: 80},
+			expected:    80,
+		},
+	}
+
+	for _, testCase := range testCases {
+		assert.Equal(t, resolveTargetPort(testCase.servicePort, pod), testCase.expected, "Unexpected target port in testCase %s", testCase.name)
+	}
+}
+
+func TestWriteAndRemoveHostsFile(t *testing.T) {
+	hostsPath := filepath.Join(t.TempDir(), "hosts")
+	original := "127.0.0.1\tlocalhost\n"
+	err := os.WriteFile(hostsPath, []byte(original), 0644)
+	assert.NilError(t, err)
+
+	forwards := []*ServiceForward{
+		{Service: "orders", IP: "127.16.0.1", Hostnames: []string{"orders.test", "orders.test.svc"}},
+		{Service: "payments", IP: "127.16.0.2", Hostnames: []string{"payments.test"}},
+	}
+	err = WriteHostsFile(hostsPath, "test", forwards)
+	assert.NilError(t, err)
+
+	// another namespace gets its own section
+	err = WriteHostsFile(hostsPath, "other", []*ServiceForward{
+		{Service: "db", IP: "127.16.0.3", Hostnames: []string{"db.other"}},
+	})
+	assert.NilError(t, err)
+
+	out, err := os.ReadFile(hostsPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), original+
+		"# DevSpace Start services test\n127.16.0.1\torders.test orders.test.svc\n127.16.0.2\tpayments.test\n# DevSpace End services test\n"+
+		"# DevSpace Start services other\n127.16.0.3\tdb.other\n# DevSpace End services other\n")
+
+	err = RemoveHostsFile(hostsPath, "test")
+	assert.NilError(t, err)
+
+	out, err = os.ReadFile(hostsPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), original+"# DevSpace Start services other\n127.16.0.3\tdb.other\n# DevSpace End services other\n")
+
+	err = RemoveHostsFile(hostsPath, "other")
+	assert.NilError(t, err)
+
+	out, err = os.ReadFile(hostsPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), original)
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/mgutz/ansi"
 	"io"
 	"net"
+	"strconv"
 	"strings"
 	"time"
 
@@ -19,13 +20,16 @@ import (
 	"golang.org/x/net/context"
 )
 
-func ReceiveData(stream remote.Tunnel_InitTunnelClient, closeStream <-chan bool, sessionsOut chan<- *tunnel.Session, port int32, scheme string, log logpkg.Logger) error {
+// DialFunc opens a new local connection for a connection that was accepted within the container
+type DialFunc func() (net.Conn, error)
+
+func ReceiveData(stream remote.Tunnel_InitTunnelClient, closeStream <-chan bool, sessionsOut chan<- *tunnel.Session, dial DialFunc, log logpkg.Logger) error {
 loop:
 	for {
 		m, err := stream.Recv()
 		select {
 		case <-closeStream:
-			log.Debugf("closing listener on %d", port)
+			log.Debugf("closing listener")
 			_ = stream.CloseSend()
 			break loop
 		case <-stream.Context().Done():
@@ -54,9 +58,9 @@ loop:
 				log.Debugf("new connection %s", requestID)
 
 				// new session
-				conn, err := net.DialTimeout(strings.ToLower(scheme), fmt.Sprintf("localhost:%d", port), time.Millisecond*500)
+				conn, err := dial()
 				if err != nil {
-					log.Errorf("failed connecting to localhost on port %d scheme %s: %v", port, scheme, err)
+					log.Errorf("failed connecting to local endpoint: %v", err)
 					// close the remote connection
 					resp := &remote.SocketDataRequest{
 						RequestId:   requestID.String(),
@@ -187,8 +191,102 @@ func SendData(stream remote.Tunnel_InitTunnelClient, sessions <-chan *tunnel.Ses
 	}
 }
 
-func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.WriteCloser, tunnels []*latest.PortMapping, stopChan chan struct{}, namespace string, name string, log logpkg.Logger) error {
+// tunnelRequest is a single listener that is opened within the container
+type tunnelRequest struct {
+	request *remote.SocketDataRequest
+	dial    DialFunc
+	name    string
+}
+
+// StartReverseForward makes the local ports and sockets available inside the container
+func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.WriteCloser, tunnels []*latest.PortMapping, sockets []*latest.SocketMapping, stopChan chan struct{}, namespace string, name string, log logpkg.Logger) error {
+	requests, err := buildTunnelRequests(tunnels, sockets)
+	if err != nil {
+		return err
+	}
+
+	return startTunnels(ctx, reader, writer, requests, stopChan, log)
+}
+
+// buildTunnelRequests creates the requests sent to the helper and the functions
+// that open the local connections for the given port and socket mappings
+func buildTunnelRequests(tunnels []*latest.PortMapping, sockets []*latest.SocketMapping) ([]*tunnelRequest, error) {
 	scheme := "TCP"
+	requests := []*tunnelRequest{}
+	for _, portMapping := range tunnels {
+		if portMapping.Port == "" {
+			return nil, fmt.Errorf("local port cannot be undefined")
+		}
+
+		mappings, err := portforward.ParsePorts([]string{portMapping.Port})
+		if err != nil {
+			return nil, fmt.Errorf("error parsing port %s: %v", portMapping.Port, err)
+		}
+
+		tunnelScheme, ok := remote.TunnelScheme_value[scheme]
+		if !ok {
+			return nil, fmt.Errorf("unsupported connection scheme %s", scheme)
+		}
+
+		localPort := int32(mappings[0].Local)
+		remotePort := int32(mappings[0].Remote)
+		requests = append(requests, &tunnelRequest{
+			request: &remote.SocketDataRequest{
+				Port:     remotePort,
+				LogLevel: 0,
+				Scheme:   remote.TunnelScheme(tunnelScheme),
+			},
+			dial: func() (net.Conn, error) {
+				return net.DialTimeout(strings.ToLower(scheme), fmt.Sprintf("localhost:%d", localPort), time.Millisecond*500)
+			},
+			name: fmt.Sprintf("%d <- %d", localPort, remotePort),
+		})
+	}
+	for _, socketMapping := range sockets {
+		if socketMapping.Local == "" || socketMapping.Remote == "" {
+			return nil, fmt.Errorf("local and remote socket path cannot be undefined")
+		}
+
+		mode, err := ParseSocketMode(socketMapping.Mode)
+		if err != nil {
+			return nil, err
+		}
+
+		local := socketMapping.Local
+		requests = append(requests, &tunnelRequest{
+			request: &remote.SocketDataRequest{
+				LogLevel:    0,
+				Scheme:      remote.TunnelScheme_UNIX,
+				SocketPath:  socketMapping.Remote,
+				SocketMode:  mode,
+				SocketOwner: socketMapping.Owner,
+				SocketGroup: socketMapping.Group,
+			},
+			dial: func() (net.Conn, error) {
+				return dialSocket(local, time.Millisecond*500)
+			},
+			name: fmt.Sprintf("%s <- %s", local, socketMapping.Remote),
+		})
+	}
+
+	return requests, nil
+}
+
+// ParseSocketMode parses an octal file mode such as 0660
+func ParseSocketMode(mode string) (uint32, error) {
+	if mode == "" {
+		return 0, nil
+	}
+
+	parsed, err := strconv.ParseUint(mode, 8, 32)
+	if err != nil || parsed > 0777 {
+		return 0, fmt.Errorf("invalid socket mode %s, expected an octal mode such as 0660", mode)
+	}
+
+	return uint32(parsed), nil
+}
+
+func startTunnels(ctx context.Context, reader io.ReadCloser, writer io.WriteCloser, tunnels []*tunnelRequest, stopChan chan struct{}, log logpkg.Logger) error {
 	closeStreams := make([]chan bool, len(tunnels))
 	defer func() {
 		for _, c := range closeStreams {
@@ -233,37 +331,16 @@ func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.Wr
 		}
 	}()
 
-	for i, portMapping := range tunnels {
-		if portMapping.Port == "" {
-			return fmt.Errorf("local port cannot be undefined")
-		}
-
-		mappings, err := portforward.ParsePorts([]string{portMapping.Port})
-		if err != nil {
-			return fmt.Errorf("error parsing port %s: %v", portMapping.Port, err)
-		}
-
-		localPort := mappings[0].Local
-		remotePort := mappings[0].Remote
+	for i, t := range tunnels {
 		c := make(chan bool, 1)
-		go func(closeStream chan bool, localPort, remotePort int32) {
-			tunnelScheme, ok := remote.TunnelScheme_value[scheme]
-			if !ok {
-				errorsChan <- fmt.Errorf("unsupported connection scheme %s", scheme)
-				return
-			}
-			req := &remote.SocketDataRequest{
-				Port:     remotePort,
-				LogLevel: 0,
-				Scheme:   remote.TunnelScheme(tunnelScheme),
-			}
+		go func(closeStream chan bool, t *tunnelRequest) {
 			stream, err := client.InitTunnel(ctx)
 			if err != nil {
 				errorsChan <- fmt.Errorf("error sending init tunnel request: %v", err)
 				return
 			}
 
-			err = stream.Send(req)
+			err = stream.Send(t.request)
 			if err != nil {
 				errorsChan <- fmt.Errorf("failed to send initial tunnel request to server")
 				return
@@ -271,7 +348,7 @@ func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.Wr
 
 			sessions := make(chan *tunnel.Session)
 			go func() {
-				err = ReceiveData(stream, closeStream, sessions, localPort, scheme, logFile)
+				err = ReceiveData(stream, closeStream, sessions, t.dial, logFile)
 				if err != nil {
 					errorsChan <- err
 				}
@@ -284,9 +361,9 @@ func StartReverseForward(ctx context.Context, reader io.ReadCloser, writer io.Wr
 			}()
 
 			// wait until close
-			log.Donef("Port forwarding started on: %s", ansi.Color(fmt.Sprintf("%d <- %d", localPort, remotePort), "white+b"))
+			log.Donef("Port forwarding started on: %s", ansi.Color(t.name, "white+b"))
 			<-closeStream
-		}(c, int32(localPort), int32(remotePort))
+		}(c, t)
 		closeStreams[i] = c
 	}
 
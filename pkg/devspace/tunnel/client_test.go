@@ -0,0 +1,94 @@
+package tunnel
+
+import (
+	"testing"
+
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"google.golang.org/protobuf/proto"
+	"gotest.tools/assert"
+)
+
+func TestParseSocketMode(t *testing.T) {
+	mode, err := ParseSocketMode("")
+	assert.NilError(t, err)
+	assert.Equal(t, mode, uint32(0))
+
+	mode, err = ParseSocketMode("0660")
+	assert.NilError(t, err)
+	assert.Equal(t, mode, uint32(0660))
+
+	mode, err = ParseSocketMode("777")
+	assert.NilError(t, err)
+	assert.Equal(t, mode, uint32(0777))
+
+	_, err = ParseSocketMode("0990")
+	assert.ErrorContains(t, err, "invalid socket mode")
+	_, err = ParseSocketMode("01777")
+	assert.ErrorContains(t, err, "invalid socket mode")
+}
+
+type buildTunnelRequestsTestCase struct {
+	name    string
+	tunnels []*latest.PortMapping
+	sockets []*latest.SocketMapping
+
+	expectedRequests []*remote.SocketDataRequest
+	expectedNames    []string
+	expectedErr      string
+}
+
+func TestBuildTunnelRequests(t *testing.T) {
+	testCases := []buildTunnelRequestsTestCase{
+		{
+			name:    "Ports and sockets",
+			tunnels: []*latest.PortMapping{{Port: "8080"}, {Port: "9090:3000"}},
+			sockets: []*latest.SocketMapping{{Local: "/var/run/docker.sock", Remote: "/tmp/docker.sock", Mode: "0660", Owner: "node", Group: "docker"}},
+			expectedRequests: []*remote.SocketDataRequest{
+				{Port: 8080, Scheme: remote.TunnelScheme_TCP},
+				{Port: 3000, Scheme: remote.TunnelScheme_TCP},
+				{Scheme: remote.TunnelScheme_UNIX, SocketPath: "/tmp/docker.sock", SocketMode: 0660, SocketOwner: "node", SocketGroup: "docker"},
+			},
+			expectedNames: []string{"8080 <- 8080", "9090 <- 3000", "/var/run/docker.sock <- /tmp/docker.sock"},
+		},
+		{
+			name:          "Socket without mode",
+			sockets:       []*latest.SocketMapping{{Local: "/tmp/agent.sock", Remote: "/tmp/agent.sock"}},
+			expectedNames: []string{"/tmp/agent.sock <- /tmp/agent.sock"},
+			expectedRequests: []*remote.SocketDataRequest{
+				{Scheme: remote.TunnelScheme_UNIX, SocketPath: "/tmp/agent.sock"},
+			},
+		},
+		{
+			name:        "Missing port",
+			tunnels:     []*latest.PortMapping{{}},
+			expectedErr: "local port cannot be undefined",
+		},
+		{
+			name:        "Missing remote socket",
+			sockets:     []*latest.SocketMapping{{Local: "/var/run/docker.sock"}},
+			expectedErr: "local and remote socket path cannot be undefined",
+		},
+		{
+			name:        "Invalid socket mode",
+			sockets:     []*latest.SocketMapping{{Local: "/var/run/docker.sock", Remote: "/tmp/docker.sock", Mode: "rw"}},
+			expectedErr: "invalid socket mode rw, expected an octal mode such as 0660",
+		},
+	}
+
+	for _, testCase := range testCases {
+		requests, err := buildTunnelRequests(testCase.tunnels, testCase.sockets)
+		if testCase.expectedErr != "" {
+			assert.Error(t, err, testCase.expectedErr, "Wrong or no error in testCase %s", testCase.name)
+			continue
+		}
+
+		assert.NilError(t, err, "Error in testCase %s", testCase.name)
+		assert.Equal(t, len(requests), len(testCase.expectedRequests), "Unexpected number of requests in testCase %s", testCase.name)
+		for i, request := range requests {
+			assert.Assert(t, proto.Equal(request.request, testCase.expectedRequests[i]), "Unexpected request %s in testCase %s", request.request.String(), testCase.name)
+			assert.Equal(t, request.name, testCase.expectedNames[i], "Unexpected name in testCase %s", testCase.name)
+			assert.Assert(t, request.dial != nil, "Missing dial func in testCase %s", testCase.name)
+		}
+	}
+}
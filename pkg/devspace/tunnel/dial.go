@@ -0,0 +1,20 @@
+//go:build !windows
+// +build !windows
+
+package tunnel
+
+import (
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+// dialSocket connects to the local unix socket at address
+func dialSocket(address string, timeout time.Duration) (net.Conn, error) {
+	if strings.HasPrefix(address, "npipe://") {
+		return nil, fmt.Errorf("named pipe %s is only supported on windows", address)
+	}
+
+	return net.DialTimeout("unix", strings.TrimPrefix(address, "unix://"), timeout)
+}
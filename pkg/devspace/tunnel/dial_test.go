@@ -0,0 +1,137 @@
+//go:build !windows
+// +build !windows
+
+package tunnel
+
+import (
+	"context"
+	"io"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/loft-sh/devspace/helper/remote"
+	"github.com/loft-sh/devspace/helper/tunnel"
+	"github.com/loft-sh/devspace/pkg/devspace/config/versions/latest"
+	"github.com/loft-sh/devspace/pkg/util/log"
+	"google.golang.org/grpc"
+	"gotest.tools/assert"
+)
+
+// fakeTunnelStream returns the given messages and blocks afterwards until it is closed
+type fakeTunnelStream struct {
+	grpc.ClientStream
+
+	messages chan *remote.SocketDataResponse
+	sent     chan *remote.SocketDataRequest
+}
+
+func (f *fakeTunnelStream) Recv() (*remote.SocketDataResponse, error) {
+	m, ok := <-f.messages
+	if !ok {
+		return nil, io.EOF
+	}
+
+	return m, nil
+}
+
+func (f *fakeTunnelStream) Send(m *remote.SocketDataRequest) error {
+	f.sent <- m
+	return nil
+}
+
+func (f *fakeTunnelStream) CloseSend() error {
+	return nil
+}
+
+func (f *fakeTunnelStream) Context() context.Context {
+	return context.Background()
+}
+
+func TestReceiveDataDialsSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "tunnel")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	// local socket that answers every message
+	socketPath := filepath.Join(dir, "local.sock")
+	ln, err := net.Listen("unix", socketPath)
+	assert.NilError(t, err)
+	defer ln.Close()
+	go func() {
+		conn, err := ln.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+
+		buf := make([]byte, 4)
+		_, err = io.ReadFull(conn, buf)
+		if err == nil {
+			_, _ = conn.Write([]byte("pong"))
+		}
+	}()
+
+	requests, err := buildTunnelRequests(nil, []*latest.SocketMapping{{Local: "unix://" + socketPath, Remote: "/tmp/remote.sock"}})
+	assert.NilError(t, err)
+	assert.Equal(t, len(requests), 1)
+
+	stream := &fakeTunnelStream{
+		messages: make(chan *remote.SocketDataResponse, 1),
+		sent:     make(chan *remote.SocketDataRequest, 1),
+	}
+	closeStream := make(chan bool)
+	sessions := make(chan *tunnel.Session, 10)
+	done := make(chan error)
+	go func() {
+		done <- ReceiveData(stream, closeStream, sessions, requests[0].dial, log.Discard)
+	}()
+
+	// a connection accepted within the container opens a new local connection
+	requestID := uuid.New()
+	stream.messages <- &remote.SocketDataResponse{RequestId: requestID.String(), Data: []byte("ping")}
+
+	session := <-sessions
+	assert.Equal(t, session.ID, requestID)
+	session.Lock()
+	assert.Equal(t, session.Buf.String(), "pong")
+	session.Unlock()
+
+	close(closeStream)
+	close(stream.messages)
+	assert.NilError(t, <-done)
+	session.Close()
+}
+
+func TestReceiveDataClosesUnreachableSocket(t *testing.T) {
+	dir, err := os.MkdirTemp("", "tunnel")
+	assert.NilError(t, err)
+	defer os.RemoveAll(dir)
+
+	requests, err := buildTunnelRequests(nil, []*latest.SocketMapping{{Local: filepath.Join(dir, "missing.sock"), Remote: "/tmp/remote.sock"}})
+	assert.NilError(t, err)
+
+	stream := &fakeTunnelStream{
+		messages: make(chan *remote.SocketDataResponse, 1),
+		sent:     make(chan *remote.SocketDataRequest, 1),
+	}
+	closeStream := make(chan bool)
+	done := make(chan error)
+	go func() {
+		done <- ReceiveData(stream, closeStream, make(chan *tunnel.Session), requests[0].dial, log.Discard)
+	}()
+
+	// the remote connection is closed if the local socket can't be reached
+	requestID := uuid.New()
+	stream.messages <- &remote.SocketDataResponse{RequestId: requestID.String(), Data: []byte("ping")}
+
+	sent := <-stream.sent
+	assert.Equal(t, sent.RequestId, requestID.String())
+	assert.Equal(t, sent.ShouldClose, true)
+
+	close(closeStream)
+	close(stream.messages)
+	assert.NilError(t, <-done)
+}
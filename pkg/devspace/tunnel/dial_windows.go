@@ -0,0 +1,23 @@
+//go:build windows
+// +build windows
+
+package tunnel
+
+import (
+	"net"
+	"strings"
+	"time"
+
+	"github.com/Microsoft/go-winio"
+)
+
+// dialSocket connects to the local named pipe or unix socket at address. Named pipes
+// are specified in the form npipe:////./pipe/docker_engine
+func dialSocket(address string, timeout time.Duration) (net.Conn, error) {
+	if strings.HasPrefix(address, "npipe://") {
+		pipe := strings.ReplaceAll(strings.TrimPrefix(address, "npipe://"), "/", `\`)
+		return winio.DialPipe(pipe, &timeout)
+	}
+
+	return net.DialTimeout("unix", strings.TrimPrefix(address, "unix://"), timeout)
+}
@@ -11,6 +11,9 @@ import (
 )
 
 var findExposePortsRegEx = regexp.MustCompile(`^EXPOSE\s(.*)$`)
+var findFromRegEx = regexp.MustCompile(`(?i)^\s*FROM\s+(.*)$`)
+var findArgRegEx = regexp.MustCompile(`(?i)^\s*ARG\s+([^=\s]+)(=(\S*))?`)
+var argReferenceRegEx = regexp.MustCompile(`\$\{?([A-Za-z0-9_]+)\}?`)
 
 // GetStrippedDockerImageName returns a tag stripped image name and checks if it's a valid image name
 func GetStrippedDockerImageName(imageName string) (string, string, error) {
@@ -94,3 +97,64 @@ func NormalizeNewlines(d []byte) []byte {
 	d = bytes.Replace(d, []byte{13}, []byte{10}, -1)
 	return d
 }
+
+// GetFromImages returns the images that are referenced in the FROM instructions of a dockerfile.
+// References to earlier build stages are omitted and build args are replaced by their default
+// values, references to build args without a default value are kept as is.
+func GetFromImages(filename string) ([]string, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+
+	data = NormalizeNewlines(data)
+	lines := strings.Split(string(data), "\n")
+	args := map[string]string{}
+	stages := map[string]bool{}
+	images := []string{}
+	for _, line := range lines {
+		match := findArgRegEx.FindStringSubmatch(line)
+		if match != nil {
+			if _, ok := args[match[1]]; !ok && match[3] != "" {
+				args[match[1]] = strings.Trim(match[3], `"'`)
+			}
+			continue
+		}
+
+		match = findFromRegEx.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+
+		fields := []string{}
+		for _, field := range strings.Fields(match[1]) {
+			if strings.HasPrefix(field, "--") {
+				continue
+			}
+
+			fields = append(fields, field)
+		}
+		if len(fields) == 0 {
+			continue
+		}
+
+		image := argReferenceRegEx.ReplaceAllStringFunc(fields[0], func(ref string) string {
+			name := argReferenceRegEx.FindStringSubmatch(ref)[1]
+			if value, ok := args[name]; ok {
+				return value
+			}
+
+			return ref
+		})
+		if len(fields) == 3 && strings.EqualFold(fields[1], "as") {
+			stages[strings.ToLower(fields[2])] = true
+		}
+		if stages[strings.ToLower(image)] || strings.ToLower(image) == "scratch" {
+			continue
+		}
+
+		images = append(images, image)
+	}
+
+	return images, nil
+}
@@ -2,6 +2,7 @@ package dockerfile
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"gotest.tools/assert"
@@ -50,3 +51,22 @@ EXPOSE `))
 	assert.Equal(t, 8080, ports[0], "Wrong port returned")
 
 }
+
+func TestGetFromImages(t *testing.T) {
+	dir := t.TempDir()
+	filename := filepath.Join(dir, "Dockerfile")
+	err := os.WriteFile(filename, []byte(`ARG BASE_IMAGE=myregistry.com/base:latest
+ARG DEVSPACE_IMAGE_TOOLS
+FROM golang:1.21 AS builder
+FROM --platform=linux/amd64 ${BASE_IMAGE}
+COPY --from=builder /app /app
+FROM $DEVSPACE_IMAGE_TOOLS as tools
+FROM builder
+FROM scratch
+`), 0666)
+	assert.NilError(t, err)
+
+	images, err := GetFromImages(filename)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, images, []string{"golang:1.21", "myregistry.com/base:latest", "$DEVSPACE_IMAGE_TOOLS"})
+}
@@ -110,9 +110,41 @@ func DirectoryExcludes(srcPath string, excludePatterns []string, fast bool) (str
 		srcPath = longpath.AddPrefix(srcPath)
 	}
 
+	err = walkExcludes(srcPath, excludePatterns, func(filePath, relFilePath string, f os.FileInfo) error {
+		if f.IsDir() {
+			// Path is enough
+			_, _ = io.WriteString(hash, filePath)
+		} else {
+			if fast {
+				_, _ = io.WriteString(hash, filePath+";"+strconv.FormatInt(f.Size(), 10)+";"+strconv.FormatInt(f.ModTime().Unix(), 10))
+			} else {
+				// Check file change
+				checksum, err := hashFileCRC32(filePath, 0xedb88320)
+				if err != nil {
+					return nil
+				}
+
+				_, _ = io.WriteString(hash, filePath+";"+checksum)
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return "", errors.Errorf("Error hashing %s: %v", srcPath, err)
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), nil
+}
+
+// walkExcludes walks the given directory in lexical order and calls walkFn for each file and
+// directory that is not excluded by the given patterns. The patterns follow the .dockerignore
+// semantics, which means excluded directories are still walked if an exception pattern could
+// match a file within them.
+func walkExcludes(srcPath string, excludePatterns []string, walkFn func(filePath, relFilePath string, f os.FileInfo) error) error {
 	pm, err := patternmatcher.New(excludePatterns)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	// In general we log errors here but ignore them because
@@ -122,18 +154,18 @@ func DirectoryExcludes(srcPath string, excludePatterns []string, fast bool) (str
 
 	stat, err := os.Lstat(srcPath)
 	if err != nil {
-		return "", err
+		return err
 	}
 
 	if !stat.IsDir() {
-		return "", errors.Errorf("Path %s is not a directory", srcPath)
+		return errors.Errorf("Path %s is not a directory", srcPath)
 	}
 
 	include := "."
 	seen := make(map[string]bool)
 
 	walkRoot := filepath.Join(srcPath, include)
-	err = filepath.Walk(walkRoot, func(filePath string, f os.FileInfo, err error) error {
+	return filepath.Walk(walkRoot, func(filePath string, f os.FileInfo, err error) error {
 		if err != nil {
 			return errors.Errorf("Hash: Can't stat file %s to hash: %s", srcPath, err)
 		}
@@ -195,31 +227,8 @@ func DirectoryExcludes(srcPath string, excludePatterns []string, fast bool) (str
 			return nil
 		}
 		seen[relFilePath] = true
-		if f.IsDir() {
-			// Path is enough
-			_, _ = io.WriteString(hash, filePath)
-		} else {
-			if fast {
-				_, _ = io.WriteString(hash, filePath+";"+strconv.FormatInt(f.Size(), 10)+";"+strconv.FormatInt(f.ModTime().Unix(), 10))
-			} else {
-				// Check file change
-				checksum, err := hashFileCRC32(filePath, 0xedb88320)
-				if err != nil {
-					return nil
-				}
-
-				_, _ = io.WriteString(hash, filePath+";"+checksum)
-			}
-		}
-
-		return nil
+		return walkFn(filePath, relFilePath, f)
 	})
-
-	if err != nil {
-		return "", errors.Errorf("Error hashing %s: %v", srcPath, err)
-	}
-
-	return fmt.Sprintf("%x", hash.Sum(nil)), nil
 }
 
 // StringToNumber hashes a given string to a number
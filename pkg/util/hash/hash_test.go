@@ -2,6 +2,7 @@ package hash
 
 import (
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/loft-sh/devspace/pkg/util/fsutil"
@@ -86,3 +87,36 @@ func TestHashDirectoryExcludes(t *testing.T) {
 	}
 
 }
+
+func TestHashDirectoryExcludesIncremental(t *testing.T) {
+	dir := t.TempDir()
+	_ = fsutil.WriteToFile([]byte("a"), filepath.Join(dir, "includedFile"))
+	_ = fsutil.WriteToFile([]byte("b"), filepath.Join(dir, "excludedFile"))
+	_ = fsutil.WriteToFile([]byte("c"), filepath.Join(dir, "excludedDir/someFile"))
+	_ = fsutil.WriteToFile([]byte("d"), filepath.Join(dir, "excludedDir/includedFile"))
+	excludes := []string{"excludedFile", "excludedDir", "!excludedDir/includedFile"}
+
+	expected, err := DirectoryExcludes(dir, excludes, false)
+	assert.NilError(t, err)
+	hash, entries, err := DirectoryExcludesIncremental(dir, excludes, nil)
+	assert.NilError(t, err)
+	assert.Equal(t, hash, expected)
+	assert.Equal(t, len(entries), 2)
+	assert.Assert(t, entries[filepath.Join("excludedDir", "includedFile")].Hash != "")
+
+	// unchanged files are not rehashed
+	entry := entries["includedFile"]
+	entry.Hash = "cached"
+	entries["includedFile"] = entry
+	cachedHash, _, err := DirectoryExcludesIncremental(dir, excludes, entries)
+	assert.NilError(t, err)
+	assert.Assert(t, cachedHash != expected)
+
+	// changed files are rehashed
+	_ = fsutil.WriteToFile([]byte("changed"), filepath.Join(dir, "includedFile"))
+	expected, err = DirectoryExcludes(dir, excludes, false)
+	assert.NilError(t, err)
+	hash, _, err = DirectoryExcludesIncremental(dir, excludes, entries)
+	assert.NilError(t, err)
+	assert.Equal(t, hash, expected)
+}
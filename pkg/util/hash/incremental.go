@@ -0,0 +1,117 @@
+package hash
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sync"
+
+	"github.com/docker/docker/pkg/longpath"
+	"github.com/pkg/errors"
+)
+
+// FileEntry is the cached state of a single file that was hashed
+type FileEntry struct {
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"modTime"`
+	Hash    string `json:"hash"`
+}
+
+type walkedFile struct {
+	filePath    string
+	relFilePath string
+	isDir       bool
+	entry       FileEntry
+}
+
+// DirectoryExcludesIncremental calculates the same hash as DirectoryExcludes, but only rehashes files
+// whose size or modification time differ from the given entries. Changed files are hashed in parallel.
+// The returned entries contain all files of the directory that are not excluded.
+func DirectoryExcludesIncremental(srcPath string, excludePatterns []string, entries map[string]FileEntry) (string, map[string]FileEntry, error) {
+	srcPath, err := filepath.Abs(srcPath)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Single files are not cached, DirectoryExcludes hashes them by their path, size and
+	// modification time, regardless of fast
+	fileInfo, err := os.Stat(srcPath)
+	if err != nil {
+		return "", nil, err
+	} else if !fileInfo.IsDir() {
+		hash, err := DirectoryExcludes(srcPath, excludePatterns, false)
+		return hash, map[string]FileEntry{}, err
+	}
+
+	// Fix the source path to work with long path names. This is a no-op
+	// on platforms other than Windows.
+	if runtime.GOOS == "windows" {
+		srcPath = longpath.AddPrefix(srcPath)
+	}
+
+	// Collect all files first, so the hash is calculated in walk order afterwards
+	files := []*walkedFile{}
+	changed := make(chan *walkedFile)
+	err = walkExcludes(srcPath, excludePatterns, func(filePath, relFilePath string, f os.FileInfo) error {
+		file := &walkedFile{
+			filePath:    filePath,
+			relFilePath: relFilePath,
+			isDir:       f.IsDir(),
+		}
+		if !file.isDir {
+			file.entry = FileEntry{
+				Size:    f.Size(),
+				ModTime: f.ModTime().UnixNano(),
+			}
+
+			cached, ok := entries[relFilePath]
+			if ok && cached.Size == file.entry.Size && cached.ModTime == file.entry.ModTime && cached.Hash != "" {
+				file.entry.Hash = cached.Hash
+			}
+		}
+
+		files = append(files, file)
+		return nil
+	})
+	if err != nil {
+		return "", nil, errors.Errorf("Error hashing %s: %v", srcPath, err)
+	}
+
+	// Rehash the changed files in parallel
+	waitGroup := sync.WaitGroup{}
+	for i := 0; i < runtime.NumCPU(); i++ {
+		waitGroup.Add(1)
+		go func() {
+			defer waitGroup.Done()
+
+			for file := range changed {
+				// Files that cannot be read are ignored, the same as in DirectoryExcludes
+				file.entry.Hash, _ = hashFileCRC32(file.filePath, 0xedb88320)
+			}
+		}()
+	}
+	for _, file := range files {
+		if !file.isDir && file.entry.Hash == "" {
+			changed <- file
+		}
+	}
+	close(changed)
+	waitGroup.Wait()
+
+	hash := sha256.New()
+	newEntries := map[string]FileEntry{}
+	for _, file := range files {
+		if file.isDir {
+			// Path is enough
+			_, _ = io.WriteString(hash, file.filePath)
+		} else if file.entry.Hash != "" {
+			_, _ = io.WriteString(hash, file.filePath+";"+file.entry.Hash)
+			newEntries[file.relFilePath] = file.entry
+		}
+	}
+
+	return fmt.Sprintf("%x", hash.Sum(nil)), newEntries, nil
+}
@@ -0,0 +1,123 @@
+package hostsfile
+
+import (
+	"io"
+	"os"
+	"runtime"
+	"strings"
+	"sync"
+
+	"github.com/loft-sh/devspace/pkg/util/scanner"
+	"github.com/pkg/errors"
+)
+
+var fileLock sync.Mutex
+
+var (
+	MarkerStartPrefix = "# DevSpace Start "
+	MarkerEndPrefix   = "# DevSpace End "
+)
+
+// Entry is a single line within the hosts file that maps
+// one ip to one or more hostnames
+type Entry struct {
+	IP        string
+	Hostnames []string
+}
+
+// DefaultPath returns the path of the system hosts file
+func DefaultPath() string {
+	if runtime.GOOS == "windows" {
+		systemRoot := os.Getenv("SystemRoot")
+		if systemRoot == "" {
+			systemRoot = "C:\\Windows"
+		}
+
+		return systemRoot + "\\System32\\drivers\\etc\\hosts"
+	}
+
+	return "/etc/hosts"
+}
+
+// Update replaces the DevSpace managed section with the given name in the hosts file
+// at path with the given entries. Lines outside the section are left untouched.
+func Update(path, section string, entries []Entry) error {
+	fileLock.Lock()
+	defer fileLock.Unlock()
+
+	lines, err := readWithoutSection(path, section)
+	if err != nil {
+		return err
+	}
+
+	lines = append(lines, MarkerStartPrefix+section)
+	for _, entry := range entries {
+		lines = append(lines, entry.IP+"\t"+strings.Join(entry.Hostnames, " "))
+	}
+	lines = append(lines, MarkerEndPrefix+section)
+	return write(path, lines)
+}
+
+// Remove removes the DevSpace managed section with the given name from the hosts file at path
+func Remove(path, section string) error {
+	fileLock.Lock()
+	defer fileLock.Unlock()
+
+	lines, err := readWithoutSection(path, section)
+	if err != nil {
+		return err
+	}
+
+	return write(path, lines)
+}
+
+func readWithoutSection(path, section string) ([]string, error) {
+	var reader io.Reader
+	f, err := os.Open(path)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return nil, err
+		}
+
+		reader = strings.NewReader("")
+	} else {
+		reader = f
+		defer f.Close()
+	}
+
+	hostsScanner := scanner.NewScanner(reader)
+	newLines := []string{}
+	inSection := false
+	startMarker := MarkerStartPrefix + section
+	endMarker := MarkerEndPrefix + section
+	for hostsScanner.Scan() {
+		text := hostsScanner.Text()
+		if strings.TrimSpace(text) == startMarker {
+			inSection = true
+		} else if strings.TrimSpace(text) == endMarker {
+			inSection = false
+		} else if !inSection {
+			newLines = append(newLines, text)
+		}
+	}
+	if hostsScanner.Err() != nil {
+		return nil, errors.Wrap(hostsScanner.Err(), "parse hosts file")
+	}
+
+	return newLines, nil
+}
+
+func write(path string, lines []string) error {
+	mode := os.FileMode(0644)
+	stat, err := os.Stat(path)
+	if err == nil {
+		mode = stat.Mode()
+	}
+
+	err = os.WriteFile(path, []byte(strings.Join(lines, "\n")+"\n"), mode)
+	if err != nil {
+		return errors.Wrapf(err, "write hosts file %s", path)
+	}
+
+	return nil
+}
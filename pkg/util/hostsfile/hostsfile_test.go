@@ -0,0 +1,42 @@
+package hostsfile
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"gotest.tools/assert"
+)
+
+func TestUpdateAndRemove(t *testing.T) {
+	hostsPath := filepath.Join(t.TempDir(), "hosts")
+	original := "127.0.0.1\tlocalhost\n::1\tlocalhost\n"
+	err := os.WriteFile(hostsPath, []byte(original), 0644)
+	assert.NilError(t, err)
+
+	err = Update(hostsPath, "my-namespace", []Entry{
+		{IP: "127.16.0.1", Hostnames: []string{"orders.my-namespace", "orders.my-namespace.svc"}},
+	})
+	assert.NilError(t, err)
+
+	out, err := os.ReadFile(hostsPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), original+"# DevSpace Start my-namespace\n127.16.0.1\torders.my-namespace orders.my-namespace.svc\n# DevSpace End my-namespace\n")
+
+	// updating again should replace the existing section
+	err = Update(hostsPath, "my-namespace", []Entry{
+		{IP: "127.16.0.2", Hostnames: []string{"payments.my-namespace"}},
+	})
+	assert.NilError(t, err)
+
+	out, err = os.ReadFile(hostsPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), original+"# DevSpace Start my-namespace\n127.16.0.2\tpayments.my-namespace\n# DevSpace End my-namespace\n")
+
+	err = Remove(hostsPath, "my-namespace")
+	assert.NilError(t, err)
+
+	out, err = os.ReadFile(hostsPath)
+	assert.NilError(t, err)
+	assert.Equal(t, string(out), original)
+}
@@ -0,0 +1,170 @@
+package sops
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/getsops/sops/v3/age"
+	"github.com/getsops/sops/v3/keyservice"
+	"github.com/getsops/sops/v3/pgp"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/expand"
+)
+
+const (
+	// AgeKeyEnv holds age identities, the same variable sops reads
+	AgeKeyEnv = "SOPS_AGE_KEY"
+	// AgeKeyFileEnv points to a file with age identities, the same variable sops reads
+	AgeKeyFileEnv = "SOPS_AGE_KEY_FILE"
+	// PGPKeyEnv holds armored pgp private keys that are imported into a temporary gpg home
+	PGPKeyEnv = "DEVSPACE_SOPS_PGP_KEY"
+	// PGPKeyFileEnv points to a file with armored or binary pgp private keys that are imported into a temporary gpg home
+	PGPKeyFileEnv = "DEVSPACE_SOPS_PGP_KEY_FILE"
+)
+
+// keyServer decrypts data keys with the age identities and pgp keys of the pipeline environment
+// and falls back to the sops key service, which reads the keys from the environment of the process
+type keyServer struct {
+	keyservice.Server
+
+	environ expand.Environ
+
+	// gnuPGHome is the temporary gpg home the pgp keys of the environment were imported into
+	gnuPGHome pgp.GnuPGHome
+}
+
+// Decrypt decrypts the data key of a file
+func (s *keyServer) Decrypt(ctx context.Context, req *keyservice.DecryptRequest) (*keyservice.DecryptResponse, error) {
+	switch key := req.Key.KeyType.(type) {
+	case *keyservice.Key_AgeKey:
+		identities, err := ageIdentities(s.environ)
+		if err != nil {
+			return nil, err
+		} else if len(identities) == 0 {
+			break
+		}
+
+		masterKey := &age.MasterKey{Recipient: key.AgeKey.Recipient, EncryptedKey: string(req.Ciphertext)}
+		identities.ApplyToMasterKey(masterKey)
+		plaintext, err := masterKey.Decrypt()
+		if err != nil {
+			return nil, err
+		}
+
+		return &keyservice.DecryptResponse{Plaintext: plaintext}, nil
+	case *keyservice.Key_PgpKey:
+		gnuPGHome, err := s.pgpHome()
+		if err != nil {
+			return nil, err
+		} else if gnuPGHome == "" {
+			break
+		}
+
+		masterKey := pgp.NewMasterKeyFromFingerprint(key.PgpKey.Fingerprint)
+		masterKey.EncryptedKey = string(req.Ciphertext)
+		gnuPGHome.ApplyToMasterKey(masterKey)
+		plaintext, err := masterKey.Decrypt()
+		if err != nil {
+			return nil, err
+		}
+
+		return &keyservice.DecryptResponse{Plaintext: plaintext}, nil
+	}
+
+	return s.Server.Decrypt(ctx, req)
+}
+
+// pgpHome imports the pgp keys from DEVSPACE_SOPS_PGP_KEY or DEVSPACE_SOPS_PGP_KEY_FILE into a temporary
+// gpg home or returns the GNUPGHOME of the environment
+func (s *keyServer) pgpHome() (pgp.GnuPGHome, error) {
+	if s.gnuPGHome != "" {
+		return s.gnuPGHome, nil
+	}
+
+	keys := [][]byte{}
+	if value := s.environ.Get(PGPKeyEnv).String(); value != "" {
+		keys = append(keys, []byte(value))
+	}
+	if keyFile := s.environ.Get(PGPKeyFileEnv).String(); keyFile != "" {
+		out, err := os.ReadFile(keyFile)
+		if err != nil {
+			return "", errors.Wrap(err, "read pgp keys")
+		}
+
+		keys = append(keys, out)
+	}
+	if len(keys) == 0 {
+		return pgp.GnuPGHome(s.environ.Get("GNUPGHOME").String()), nil
+	}
+
+	gnuPGHome, err := pgp.NewGnuPGHome()
+	if err != nil {
+		return "", err
+	}
+
+	s.gnuPGHome = gnuPGHome
+	err = os.Chmod(gnuPGHome.String(), 0700)
+	if err != nil {
+		return "", err
+	}
+	err = gnuPGHome.Import(bytes.Join(keys, []byte("\n")))
+	if err != nil {
+		return "", errors.Wrapf(err, "import pgp keys from %s or %s", PGPKeyEnv, PGPKeyFileEnv)
+	}
+
+	return gnuPGHome, nil
+}
+
+// Close removes the temporary gpg home
+func (s *keyServer) Close() error {
+	if s.gnuPGHome == "" {
+		return nil
+	}
+
+	return s.gnuPGHome.Cleanup()
+}
+
+// ageIdentities returns the age identities from SOPS_AGE_KEY, SOPS_AGE_KEY_FILE or the default sops keys file
+func ageIdentities(environ expand.Environ) (age.ParsedIdentities, error) {
+	identities := age.ParsedIdentities{}
+	if value := environ.Get(AgeKeyEnv).String(); value != "" {
+		err := identities.Import(value)
+		if err != nil {
+			return nil, errors.Wrapf(err, "parse age identities from %s", AgeKeyEnv)
+		}
+	}
+
+	keyFile := environ.Get(AgeKeyFileEnv).String()
+	if keyFile == "" {
+		keyFile = defaultAgeKeyFile(environ)
+	}
+	if keyFile != "" {
+		out, err := os.ReadFile(keyFile)
+		if err != nil && (!os.IsNotExist(err) || environ.Get(AgeKeyFileEnv).String() != "") {
+			return nil, errors.Wrap(err, "read age identities")
+		} else if err == nil {
+			err = identities.Import(string(out))
+			if err != nil {
+				return nil, errors.Wrapf(err, "parse age identities from %s", keyFile)
+			}
+		}
+	}
+
+	return identities, nil
+}
+
+// defaultAgeKeyFile returns the path of the keys file sops uses by default
+func defaultAgeKeyFile(environ expand.Environ) string {
+	configDir := environ.Get("XDG_CONFIG_HOME").String()
+	if configDir == "" {
+		var err error
+		configDir, err = os.UserConfigDir()
+		if err != nil {
+			return ""
+		}
+	}
+
+	return filepath.Join(configDir, "sops", "age", "keys.txt")
+}
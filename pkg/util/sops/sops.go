@@ -0,0 +1,93 @@
+package sops
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"time"
+
+	"github.com/getsops/sops/v3"
+	"github.com/getsops/sops/v3/aes"
+	"github.com/getsops/sops/v3/config"
+	"github.com/getsops/sops/v3/keyservice"
+	"github.com/getsops/sops/v3/stores/json"
+	"github.com/getsops/sops/v3/stores/yaml"
+	"github.com/pkg/errors"
+	"mvdan.cc/sh/v3/expand"
+)
+
+// store loads sops encrypted files and emits the decrypted documents
+type store interface {
+	LoadEncryptedFile(in []byte) (sops.Tree, error)
+	EmitPlainFile(branches sops.TreeBranches) ([]byte, error)
+}
+
+// storeFor returns the json store for json objects and the yaml store for everything else
+func storeFor(data []byte) store {
+	if bytes.HasPrefix(bytes.TrimSpace(data), []byte("{")) {
+		return json.NewStore(&config.JSONStoreConfig{})
+	}
+
+	return yaml.NewStore(&config.YAMLStoreConfig{Indent: 2})
+}
+
+// IsEncrypted returns true if the given yaml or json data was encrypted with sops
+func IsEncrypted(data []byte) bool {
+	_, err := storeFor(data).LoadEncryptedFile(data)
+	return err == nil
+}
+
+// ReadFile reads the given file and decrypts it in memory if it was encrypted with sops
+func ReadFile(ctx context.Context, path string, environ expand.Environ) ([]byte, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	} else if !IsEncrypted(data) {
+		return data, nil
+	}
+
+	decrypted, err := Decrypt(ctx, data, environ)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decrypt %s", path)
+	}
+
+	return decrypted, nil
+}
+
+// Decrypt decrypts the given sops encrypted yaml or json data and returns the plain documents
+// without the sops metadata. The data key is decrypted with the keys found in the environment,
+// the plain values are never written to disk.
+func Decrypt(_ context.Context, data []byte, environ expand.Environ) ([]byte, error) {
+	s := storeFor(data)
+	tree, err := s.LoadEncryptedFile(data)
+	if err != nil {
+		return nil, errors.Wrap(err, "load sops metadata")
+	}
+
+	server := &keyServer{environ: environ}
+	defer server.Close()
+
+	dataKey, err := tree.Metadata.GetDataKeyWithKeyServices([]keyservice.KeyServiceClient{keyservice.NewCustomLocalClient(server)}, nil)
+	if userErr, ok := err.(sops.UserError); ok {
+		// the user error lists why each key failed, like the sops cli prints it
+		return nil, errors.New(userErr.UserError())
+	} else if err != nil {
+		return nil, err
+	}
+
+	cipher := aes.NewCipher()
+	mac, err := tree.Decrypt(dataKey, cipher)
+	if err != nil {
+		return nil, err
+	}
+
+	// verify the message authentication code to make sure the file was not tampered with
+	originalMAC, err := cipher.Decrypt(tree.Metadata.MessageAuthenticationCode, dataKey, tree.Metadata.LastModified.Format(time.RFC3339))
+	if err != nil {
+		return nil, errors.Wrap(err, "decrypt mac")
+	} else if originalMAC != mac {
+		return nil, errors.New("mac mismatch, the file was modified after it was encrypted")
+	}
+
+	return s.EmitPlainFile(tree.Branches)
+}